@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// backupResult is the JSON payload for `faz backup`.
+type backupResult struct {
+	Path string `json:"path"`
+}
+
+// restoreResult is the JSON payload for `faz restore <snapshot>`.
+type restoreResult struct {
+	Source   string       `json:"source"`
+	Snapshot *db.Snapshot `json:"snapshot"`
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [path]",
+	Short: "Write a consistent copy of the task store",
+	Long:  "Backup copies the task store with VACUUM INTO, which is safe while other agents are writing. Without a path the copy is saved under .faz/snapshots and shows up in `faz restore`.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		sqlDB, _, err := db.OpenProjectDBUnchecked(projectDir)
+		if err != nil {
+			return schemaOpenError(err)
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		path := db.NewSnapshot(projectDir, "manual").Path
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if err := db.Backup(sqlDB, path); err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "backup", backupResult{Path: path})
+		}
+		stdoutPrintf(cmd, "Backed up task store: %s\n", path)
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [snapshot]",
+	Short: "List snapshots, or replace the task store with one",
+	Long:  "Without an argument, restore lists the snapshots in .faz/snapshots with their issue counts. With a snapshot name or a backup file path, it replaces the task store after snapshotting the current one. Running agents and `faz serve` keep working and see the restored issues on their next read.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		_, dbPath := fazPaths(projectDir)
+		if !fileExists(dbPath) {
+			return schemaOpenError(db.ErrNotInitialized)
+		}
+
+		if len(args) == 0 {
+			snapshots, unreadable, err := db.ListSnapshots(projectDir)
+			if err != nil {
+				return err
+			}
+			for _, skipped := range unreadable {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: skipped unreadable snapshot %s: %v\n", skipped.Name, skipped.Err)
+			}
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), "snapshot_list", snapshots)
+			}
+			printSnapshots(cmd, snapshots)
+			return nil
+		}
+
+		source, err := db.ResolveSnapshot(projectDir, args[0])
+		if err != nil {
+			return err
+		}
+		effective, err := projectConfig(projectDir)
+		if err != nil {
+			return err
+		}
+		snapshot, err := db.Restore(projectDir, source, effective.Config.SnapshotKeep())
+		if err != nil {
+			return err
+		}
+
+		result := restoreResult{Source: source}
+		if snapshot.Path != "" {
+			result.Snapshot = &snapshot
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "restore", result)
+		}
+		stdoutPrintf(cmd, "Restored task store from: %s\n", source)
+		if result.Snapshot != nil {
+			stdoutPrintf(cmd, "  Previous store saved as: %s\n", snapshot.Name)
+		}
+		if restored, _, err := db.OpenProjectDBUnchecked(projectDir); err == nil {
+			if db.CheckVersion(restored) != nil {
+				stdoutPrintln(cmd, "  Schema is older than this faz; run `faz db migrate`")
+			}
+			_ = restored.Close()
+		}
+		return nil
+	},
+}
+
+// printSnapshots writes the snapshot table, newest first.
+func printSnapshots(cmd *cobra.Command, snapshots []db.Snapshot) {
+	if len(snapshots) == 0 {
+		stdoutPrintln(cmd, "No snapshots found")
+		return
+	}
+	tableWriter := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tableWriter, "SNAPSHOT\tCREATED\tREASON\tISSUES\tSIZE")
+	for _, snapshot := range snapshots {
+		_, _ = fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%d\t%d KB\n",
+			snapshot.Name,
+			snapshot.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			snapshot.Reason,
+			snapshot.IssueCount,
+			(snapshot.SizeBytes+1023)/1024,
+		)
+	}
+	_ = tableWriter.Flush()
+}
+
+// snapshotBefore saves a rotating snapshot before a destructive command.
+// A failed snapshot aborts the command so nothing is lost without a copy.
+func snapshotBefore(sqlDB *sql.DB, reason string) error {
+	projectDir, err := currentProjectDir()
+	if err != nil {
+		return err
+	}
+	effective, err := projectConfig(projectDir)
+	if err != nil {
+		return err
+	}
+	_, err = db.TakeSnapshot(sqlDB, projectDir, reason, effective.Config.SnapshotKeep())
+	return err
+}
+
+// init registers backup and restore commands.
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
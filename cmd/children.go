@@ -27,9 +27,10 @@ var childrenCmd = &cobra.Command{
 			return err
 		}
 
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Children of %s:\n", ids[0])
-		printIssueList(cmd.OutOrStdout(), children)
-		return nil
+		if !structuredOutput() {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Children of %s:\n", ids[0])
+		}
+		return writeIssues(cmd, "issue_list", children)
 	},
 }
 
@@ -5,53 +5,248 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/rpcarvs/faz/internal/model"
 	"github.com/rpcarvs/faz/internal/repo"
+	"github.com/rpcarvs/faz/internal/service"
 	"github.com/spf13/cobra"
 )
 
-var claimTTL time.Duration
+var (
+	claimTTL         time.Duration
+	claimRenew       bool
+	claimNext        bool
+	claimType        string
+	claimParent      string
+	claimLabels      []string
+	claimLabelMatch  string
+	claimPriorityMax int
+	heartbeatTTL     time.Duration
+)
+
+// claimResult is the JSON payload for `faz claim`.
+type claimResult struct {
+	Issue        model.Issue     `json:"issue"`
+	LeaseSeconds int64           `json:"lease_seconds"`
+	Comments     []model.Comment `json:"comments"`
+}
 
 var claimCmd = &cobra.Command{
-	Use:   "claim <id>",
+	Use:   "claim <id> | claim --next",
 	Short: "Claim a work issue and move it to in_progress",
-	Args:  cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if claimNext {
+			if len(args) > 0 {
+				return usageErrorf("accepts no issue ID with --next")
+			}
+			if claimRenew {
+				return usageErrorf("cannot combine --next with --renew")
+			}
+			return nil
+		}
+		for _, name := range []string{"type", "parent", "label", "label-match", "priority-max"} {
+			if cmd.Flags().Changed(name) {
+				return usageErrorf("flag --%s requires --next", name)
+			}
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		svc, sqlDB, err := openService()
 		if err != nil {
 			return err
 		}
 		defer func() { _ = sqlDB.Close() }()
+		if !cmd.Flags().Changed("ttl") {
+			claimTTL = svc.Settings().DefaultClaimTTL
+		}
+
+		if claimNext {
+			filter := model.ListFilter{Type: claimType, Labels: claimLabels, LabelMatch: claimLabelMatch}
+			if cmd.Flags().Changed("parent") {
+				parent, err := parseIDs([]string{claimParent})
+				if err != nil {
+					return err
+				}
+				filter.ParentID = parent[0]
+			}
+			if cmd.Flags().Changed("priority-max") {
+				filter.PriorityMax = &claimPriorityMax
+			}
+			id, err := svc.ClaimNext(filter, claimTTL)
+			if err != nil {
+				if errors.Is(err, repo.ErrNoReadyIssue) {
+					return friendlyError{msg: "no ready work matches these filters", err: err}
+				}
+				return err
+			}
+			return printClaim(cmd, svc, id)
+		}
 
 		ids, err := parseIDs(args)
 		if err != nil {
 			return err
 		}
+		if claimRenew {
+			return renewClaim(cmd, svc, ids[0], claimTTL)
+		}
 
 		if err := svc.Claim(ids[0], claimTTL); err != nil {
 			if errors.Is(err, repo.ErrIssueAlreadyClaimed) {
-				return fmt.Errorf("this task is already claimed, try another one")
+				if issue, getErr := svc.Get(ids[0]); getErr == nil && issue.ClaimedBy != nil {
+					return friendlyError{msg: fmt.Sprintf("this task is already claimed by %s, try another one", *issue.ClaimedBy), err: err}
+				}
+				return friendlyError{msg: "this task is already claimed, try another one", err: err}
 			}
 			if errors.Is(err, repo.ErrIssueTypeNotClaimable) {
-				return fmt.Errorf("this issue type cannot be claimed")
+				return friendlyError{msg: "this issue type cannot be claimed", err: err}
 			}
 			return err
 		}
+		return printClaim(cmd, svc, ids[0])
+	},
+}
 
-		issue, err := svc.Get(ids[0])
+// printClaim reports a fresh claim with the issue reminder and recent notes.
+func printClaim(cmd *cobra.Command, svc *service.IssueService, id string) error {
+	issue, err := svc.Get(id)
+	if err != nil {
+		return err
+	}
+	notes, err := svc.Comments(id, claimCommentLimit)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return writeStructured(cmd.OutOrStdout(), "claim", claimResult{
+			Issue:        issue,
+			LeaseSeconds: int64(claimTTL.Seconds()),
+			Comments:     notes,
+		})
+	}
+
+	stdoutPrintf(cmd, "Claimed issue: %s\n", id)
+	stdoutPrintf(cmd, "  Status: in_progress\n")
+	stdoutPrintf(cmd, "  Owner: %s\n", claimOwner(issue))
+	stdoutPrintf(cmd, "  Lease TTL: %s\n", claimTTL)
+	printIssueReminder(cmd.OutOrStdout(), issue)
+	if len(notes) > 0 {
+		stdoutPrintln(cmd, "  Recent notes:")
+		printComments(cmd.OutOrStdout(), "    ", notes)
+	}
+	return nil
+}
+
+var heartbeatCmd = &cobra.Command{
+	Use:   "heartbeat <id>",
+	Short: "Extend the lease on an issue you have claimed",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("ttl") {
+			heartbeatTTL = svc.Settings().DefaultClaimTTL
+		}
+		return renewClaim(cmd, svc, ids[0], heartbeatTTL)
+	},
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release <id>",
+	Short: "Give up a claim and return the issue to open",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
 		if err != nil {
 			return err
 		}
+		defer func() { _ = sqlDB.Close() }()
 
-		stdoutPrintf(cmd, "Claimed issue: %s\n", ids[0])
-		stdoutPrintf(cmd, "  Status: in_progress\n")
-		stdoutPrintf(cmd, "  Lease TTL: %s\n", claimTTL)
-		printIssueReminder(cmd.OutOrStdout(), issue)
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		if err := svc.Release(ids[0]); err != nil {
+			return claimOwnershipError(err)
+		}
+
+		issue, err := svc.Get(ids[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "issue", issue)
+		}
+		stdoutPrintf(cmd, "Released issue: %s\n", ids[0])
+		stdoutPrintf(cmd, "  Status: %s\n", issue.Status)
 		return nil
 	},
 }
 
+// renewClaim extends the caller's lease and reports the new expiry.
+func renewClaim(cmd *cobra.Command, svc *service.IssueService, id string, ttl time.Duration) error {
+	if err := svc.Renew(id, ttl); err != nil {
+		return claimOwnershipError(err)
+	}
+
+	issue, err := svc.Get(id)
+	if err != nil {
+		return err
+	}
+	if structuredOutput() {
+		return writeStructured(cmd.OutOrStdout(), "claim", claimResult{
+			Issue:        issue,
+			LeaseSeconds: int64(ttl.Seconds()),
+			Comments:     []model.Comment{},
+		})
+	}
+	stdoutPrintf(cmd, "Renewed claim: %s\n", id)
+	stdoutPrintf(cmd, "  Owner: %s\n", claimOwner(issue))
+	if issue.ClaimExpiresAt != nil {
+		stdoutPrintf(cmd, "  Lease expires: %s\n", issue.ClaimExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
+
+// claimOwnershipError turns lease ownership failures into actionable messages.
+func claimOwnershipError(err error) error {
+	switch {
+	case errors.Is(err, repo.ErrNotClaimOwner):
+		return friendlyError{msg: err.Error() + "; only the claim owner can do this (set --as or FAZ_AGENT to match)", err: err}
+	case errors.Is(err, repo.ErrIssueNotClaimed):
+		return friendlyError{msg: err.Error() + "; claim it first with `faz claim`", err: err}
+	default:
+		return err
+	}
+}
+
+// claimOwner returns the recorded claimant or a placeholder for legacy claims.
+func claimOwner(issue model.Issue) string {
+	if issue.ClaimedBy == nil || *issue.ClaimedBy == "" {
+		return "unknown"
+	}
+	return *issue.ClaimedBy
+}
+
 // init wires command flags and registration.
 func init() {
-	claimCmd.Flags().DurationVar(&claimTTL, "ttl", 10*time.Minute, "Claim lease duration (example: 10m, 30m, 1h)")
+	claimCmd.Flags().DurationVar(&claimTTL, "ttl", 10*time.Minute, "Claim lease duration (example: 10m, 30m, 1h; default from defaults.claim_ttl)")
+	claimCmd.Flags().BoolVar(&claimRenew, "renew", false, "Extend the lease on an issue you already hold instead of claiming")
+	claimCmd.Flags().BoolVar(&claimNext, "next", false, "Atomically claim the highest-priority ready issue")
+	claimCmd.Flags().StringVar(&claimType, "type", "", "With --next, only claim this issue type")
+	claimCmd.Flags().StringVar(&claimParent, "parent", "", "With --next, only claim children of this epic")
+	claimCmd.Flags().IntVar(&claimPriorityMax, "priority-max", 3, "With --next, only claim priority <= N (0 is highest)")
+	addLabelFilterFlags(claimCmd, &claimLabels, &claimLabelMatch)
+	heartbeatCmd.Flags().DurationVar(&heartbeatTTL, "ttl", 10*time.Minute, "New lease duration from now (default from defaults.claim_ttl)")
 	rootCmd.AddCommand(claimCmd)
+	rootCmd.AddCommand(heartbeatCmd)
+	rootCmd.AddCommand(releaseCmd)
 }
@@ -1,6 +1,9 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
 
 var closeCmd = &cobra.Command{
 	Use:   "close <id> [id...]",
@@ -18,14 +21,26 @@ var closeCmd = &cobra.Command{
 			return err
 		}
 
+		closed := make([]model.Issue, 0, len(ids))
 		for _, id := range ids {
 			if err := svc.Close(id); err != nil {
 				return err
 			}
+			if structuredOutput() {
+				issue, err := svc.Get(id)
+				if err != nil {
+					return err
+				}
+				closed = append(closed, issue)
+				continue
+			}
 			stdoutPrintf(cmd, "Closed issue: %s\n", id)
 			stdoutPrintf(cmd, "  Status: closed\n")
 		}
 
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "issue_list", closed)
+		}
 		return nil
 	},
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// showCommentLimit caps how many notes `faz show` prints.
+const showCommentLimit = 5
+
+// claimCommentLimit caps how many notes `faz claim` prints with the reminder.
+const claimCommentLimit = 3
+
+var commentsLimit int
+
+var commentCmd = &cobra.Command{
+	Use:   "comment <id> \"text\"",
+	Short: "Add a work-log note to an issue",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+
+		comment, err := svc.Comment(ids[0], resolveActor(), args[1])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "comment", comment)
+		}
+		stdoutPrintf(cmd, "Added note to issue: %s\n", comment.IssueID)
+		stdoutPrintf(cmd, "  Author: %s\n", comment.Author)
+		return nil
+	},
+}
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments <id>",
+	Short: "Show the work-log notes for an issue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+
+		comments, err := svc.Comments(ids[0], commentsLimit)
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "comment_list", comments)
+		}
+		stdoutPrintf(cmd, "Notes for %s:\n", ids[0])
+		if len(comments) == 0 {
+			stdoutPrintln(cmd, "  none")
+			return nil
+		}
+		printComments(cmd.OutOrStdout(), "  ", comments)
+		return nil
+	},
+}
+
+// init wires command flags and registration.
+func init() {
+	commentsCmd.Flags().IntVar(&commentsLimit, "limit", 0, "Show only the newest N notes (0 shows all)")
+	rootCmd.AddCommand(commentCmd)
+	rootCmd.AddCommand(commentsCmd)
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/rpcarvs/faz/internal/config"
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var configGlobal bool
+
+// configEntry is the JSON payload for one effective setting.
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and write faz settings",
+	Long:  "Settings live in .faz/config.toml for the project and in ~/.config/faz/config.toml (or $XDG_CONFIG_HOME/faz/config.toml) for every project. Project values override global ones.",
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		effective, err := currentConfig()
+		if err != nil {
+			return err
+		}
+		value, err := effective.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "config", configEntry{Key: args[0], Value: value, Source: effective.Sources[args[0]]})
+		}
+		stdoutPrintln(cmd, value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Write a setting to the project (or --global) config file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		globalPath, err := config.GlobalPath()
+		if err != nil {
+			return err
+		}
+		projectPath, err := projectConfigPath()
+		if err != nil {
+			return err
+		}
+		if err := config.Set(globalPath, projectPath, configGlobal, args[0], args[1]); err != nil {
+			return err
+		}
+		path := projectPath
+		if configGlobal {
+			path = globalPath
+		}
+		effective, err := currentConfig()
+		if err != nil {
+			return err
+		}
+		value, err := effective.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "config", configEntry{Key: args[0], Value: value, Source: effective.Sources[args[0]]})
+		}
+		stdoutPrintf(cmd, "Set %s = %s\n", args[0], value)
+		stdoutPrintf(cmd, "  File: %s\n", path)
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting with its effective value and source",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		effective, err := currentConfig()
+		if err != nil {
+			return err
+		}
+		entries := make([]configEntry, 0, len(config.Keys()))
+		for _, key := range config.Keys() {
+			value, err := effective.Get(key)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, configEntry{Key: key, Value: value, Source: effective.Sources[key]})
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "config", entries)
+		}
+
+		tableWriter := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tableWriter, "KEY\tVALUE\tSOURCE\tDESCRIPTION")
+		for _, entry := range entries {
+			help, _ := config.Describe(entry.Key)
+			value := entry.Value
+			if value == "" {
+				value = "-"
+			}
+			_, _ = fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%s\n", entry.Key, value, entry.Source, help)
+		}
+		return tableWriter.Flush()
+	},
+}
+
+// currentConfig loads the effective configuration for the current repository.
+func currentConfig() (config.Effective, error) {
+	projectDir, err := currentProjectDir()
+	if err != nil {
+		return config.Effective{}, err
+	}
+	return projectConfig(projectDir)
+}
+
+// projectConfigPath returns the project config file, .faz/config.toml.
+func projectConfigPath() (string, error) {
+	projectDir, err := currentProjectDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectDir, db.DirName, config.FileName), nil
+}
+
+// init wires command flags and registration.
+func init() {
+	configSetCmd.Flags().BoolVar(&configGlobal, "global", false, "Write to the global config file instead of .faz/config.toml")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
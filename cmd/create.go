@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +13,8 @@ var (
 	createPriority    int
 	createDescription string
 	createParent      string
+	createLabels      []string
+	createEstimate    int
 )
 
 var createCmd = &cobra.Command{
@@ -25,8 +28,16 @@ var createCmd = &cobra.Command{
 		}
 		defer func() { _ = sqlDB.Close() }()
 
+		settings := svc.Settings()
+		if !cmd.Flags().Changed("type") {
+			createType = settings.DefaultType
+		}
+		if !cmd.Flags().Changed("priority") {
+			createPriority = settings.DefaultPriority
+		}
+
 		description := defaultDescription(createDescription)
-		if description == "" {
+		if description == "" && !structuredOutput() {
 			stdoutPrintln(cmd, "Warning: creating issue without description.")
 			stdoutPrintln(cmd, "  Issues without descriptions lack context for future work.")
 			stdoutPrintln(cmd, "  Consider adding --description \"Why this issue exists and what needs to be done\"")
@@ -41,6 +52,11 @@ var createCmd = &cobra.Command{
 			parentID = &normalizedParent[0]
 		}
 
+		var estimate *int
+		if cmd.Flags().Changed("estimate") {
+			estimate = &createEstimate
+		}
+
 		id, err := svc.Create(model.Issue{
 			Title:       args[0],
 			Description: description,
@@ -48,11 +64,21 @@ var createCmd = &cobra.Command{
 			Priority:    createPriority,
 			Status:      "open",
 			ParentID:    parentID,
+			Labels:      createLabels,
+			Estimate:    estimate,
 		})
 		if err != nil {
 			return err
 		}
 
+		if structuredOutput() {
+			issue, err := svc.Get(id)
+			if err != nil {
+				return err
+			}
+			return writeStructured(cmd.OutOrStdout(), "issue", issue)
+		}
+
 		stdoutPrintf(cmd, "Created issue: %s\n", id)
 		stdoutPrintf(cmd, "  Title: %s\n", args[0])
 		stdoutPrintf(cmd, "  Type: %s\n", createType)
@@ -61,15 +87,24 @@ var createCmd = &cobra.Command{
 		if parentID != nil {
 			stdoutPrintf(cmd, "  Parent: %s\n", *parentID)
 		}
+		if len(createLabels) > 0 {
+			labels, err := service.NormalizeLabels(createLabels)
+			if err != nil {
+				return err
+			}
+			stdoutPrintf(cmd, "  Labels: %s\n", strings.Join(labels, ", "))
+		}
 		return nil
 	},
 }
 
 // init wires command flags and registration.
 func init() {
-	createCmd.Flags().StringVar(&createType, "type", "task", "Issue type (epic|task|bug|feature|chore|decision)")
-	createCmd.Flags().IntVar(&createPriority, "priority", 2, "Issue priority (0-3)")
+	createCmd.Flags().StringVar(&createType, "type", "task", "Issue type (epic|task|bug|feature|chore|decision or a types.extra entry; default from defaults.type)")
+	createCmd.Flags().IntVar(&createPriority, "priority", 2, "Issue priority (0-3; default from defaults.priority)")
 	createCmd.Flags().StringVar(&createDescription, "description", "", "Issue description")
 	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent issue ID")
+	createCmd.Flags().StringSliceVar(&createLabels, "label", nil, "Label to attach (repeatable or comma-separated)")
+	createCmd.Flags().IntVar(&createEstimate, "estimate", 0, "Effort estimate in your own units (hours, points), used to weight faz plan")
 	rootCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/spf13/cobra"
+)
+
+// dbMigrateResult is the JSON payload for `faz db migrate`.
+type dbMigrateResult struct {
+	From    int            `json:"from"`
+	To      int            `json:"to"`
+	Applied []db.Migration `json:"applied"`
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and upgrade the task store schema",
+}
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := projectSchemaStatus()
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "db_status", status)
+		}
+
+		stdoutPrintf(cmd, "Schema version: %d\n", status.Current)
+		stdoutPrintf(cmd, "  Latest: %d\n", status.Latest)
+		switch {
+		case status.Current > status.Latest:
+			stdoutPrintln(cmd, "  State: written by a newer faz; upgrade faz to use it")
+		case len(status.Pending) == 0:
+			stdoutPrintln(cmd, "  State: up to date")
+		default:
+			stdoutPrintln(cmd, "  Pending:")
+			for _, step := range status.Pending {
+				stdoutPrintf(cmd, "    %d %s\n", step.Version, step.Name)
+			}
+			stdoutPrintln(cmd, "  Run `faz db migrate` to apply them")
+		}
+		return nil
+	},
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		sqlDB, _, err := db.OpenProjectDBUnchecked(projectDir)
+		if err != nil {
+			return schemaOpenError(err)
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		before, err := db.Status(sqlDB)
+		if err != nil {
+			return err
+		}
+		if len(before.Pending) > 0 && before.Current > 0 {
+			if err := snapshotBefore(sqlDB, "migrate"); err != nil {
+				return err
+			}
+		}
+		if err := db.Migrate(sqlDB); err != nil {
+			if errors.Is(err, db.ErrSchemaTooNew) {
+				return friendlyError{msg: err.Error() + ". Upgrade faz to use this task store", err: err}
+			}
+			return err
+		}
+
+		result := dbMigrateResult{From: before.Current, To: before.Latest, Applied: before.Pending}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "db_migrate", result)
+		}
+		if len(result.Applied) == 0 {
+			stdoutPrintf(cmd, "Schema is up to date (version %d)\n", result.To)
+			return nil
+		}
+		stdoutPrintf(cmd, "Migrated schema: %d -> %d\n", result.From, result.To)
+		for _, step := range result.Applied {
+			stdoutPrintf(cmd, "  Applied: %d %s\n", step.Version, step.Name)
+		}
+		return nil
+	},
+}
+
+// projectSchemaStatus opens the project database without the version check and reads its status.
+func projectSchemaStatus() (db.SchemaStatus, error) {
+	projectDir, err := currentProjectDir()
+	if err != nil {
+		return db.SchemaStatus{}, err
+	}
+	sqlDB, _, err := db.OpenProjectDBUnchecked(projectDir)
+	if err != nil {
+		return db.SchemaStatus{}, schemaOpenError(err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+	return db.Status(sqlDB)
+}
+
+// schemaOpenError explains a missing task store to the db subcommands.
+func schemaOpenError(err error) error {
+	if errors.Is(err, db.ErrNotInitialized) {
+		return friendlyError{msg: "faz is not initialized for this Git repository. Run `faz init`", err: err}
+	}
+	return err
+}
+
+// init registers schema commands.
+func init() {
+	dbCmd.AddCommand(dbStatusCmd)
+	dbCmd.AddCommand(dbMigrateCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -2,6 +2,11 @@ package cmd
 
 import "github.com/spf13/cobra"
 
+// deletedIssues is the JSON payload for `faz delete`.
+type deletedIssues struct {
+	IDs []string `json:"ids"`
+}
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete <id> [id...]",
 	Short: "Permanently delete one or more issues",
@@ -17,12 +22,20 @@ var deleteCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if err := snapshotBefore(sqlDB, "delete"); err != nil {
+			return err
+		}
 
 		for _, id := range ids {
 			if err := svc.Delete(id); err != nil {
 				return err
 			}
-			stdoutPrintf(cmd, "Deleted issue: %s\n", id)
+			if !structuredOutput() {
+				stdoutPrintf(cmd, "Deleted issue: %s\n", id)
+			}
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "deleted", deletedIssues{IDs: ids})
 		}
 		return nil
 	},
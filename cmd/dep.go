@@ -11,6 +11,13 @@ var depCmd = &cobra.Command{
 	Short: "Manage issue dependencies",
 }
 
+// dependencyChange is the JSON payload for dependency mutations.
+type dependencyChange struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Action      string `json:"action"`
+}
+
 var depAddCmd = &cobra.Command{
 	Use:   "add <issue-id> <depends-on-id>",
 	Short: "Add dependency",
@@ -29,6 +36,9 @@ var depAddCmd = &cobra.Command{
 		if err := svc.AddDependency(ids[0], ids[1]); err != nil {
 			return err
 		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "dependency", dependencyChange{IssueID: ids[0], DependsOnID: ids[1], Action: "added"})
+		}
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Added dependency:")
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s depends on %s\n", ids[0], ids[1])
 		return nil
@@ -53,6 +63,9 @@ var depRemoveCmd = &cobra.Command{
 		if err := svc.RemoveDependency(ids[0], ids[1]); err != nil {
 			return err
 		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "dependency", dependencyChange{IssueID: ids[0], DependsOnID: ids[1], Action: "removed"})
+		}
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Removed dependency:")
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s no longer depends on %s\n", ids[0], ids[1])
 		return nil
@@ -82,6 +95,9 @@ var depListCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), "issue_list", items)
+			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Issues blocked by %s:\n", id[0])
 			printIssueTable(cmd.OutOrStdout(), items)
 			return nil
@@ -91,17 +107,55 @@ var depListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "issue_list", items)
+		}
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Dependencies for %s:\n", id[0])
 		printIssueTable(cmd.OutOrStdout(), items)
 		return nil
 	},
 }
 
+var depCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Scan the dependency graph for cycles and stale blockers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		problems, err := svc.CheckDependencies()
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "dependency_check", problems)
+		}
+		if len(problems) == 0 {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No dependency problems found")
+			return nil
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Dependency problems:")
+		for _, problem := range problems {
+			target := problem.IssueID
+			if problem.DependsOnID != "" {
+				target = fmt.Sprintf("%s -> %s", problem.IssueID, problem.DependsOnID)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  [%s] %s: %s\n", problem.Kind, target, problem.Detail)
+		}
+		return fmt.Errorf("found %d dependency problem(s)", len(problems))
+	},
+}
+
 // init registers dependency commands and list flags.
 func init() {
 	depListCmd.Flags().BoolVar(&depListUp, "up", false, "List dependents (issues blocked by this issue)")
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depListCmd)
+	depCmd.AddCommand(depCheckCmd)
 	rootCmd.AddCommand(depCmd)
 }
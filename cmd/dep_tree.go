@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// depTreeNode is one issue in a transitive dependency tree.
+type depTreeNode struct {
+	Issue    model.Issue   `json:"issue"`
+	Repeated bool          `json:"repeated,omitempty"`
+	Cycle    bool          `json:"cycle,omitempty"`
+	Nodes    []depTreeNode `json:"nodes"`
+}
+
+// depTree is the JSON payload for `faz dep tree`.
+type depTree struct {
+	Issue     model.Issue   `json:"issue"`
+	BlockedBy []depTreeNode `json:"blocked_by"`
+	Blocks    []depTreeNode `json:"blocks"`
+}
+
+var depTreeCmd = &cobra.Command{
+	Use:   "tree <id>",
+	Short: "Show transitive blockers and dependents as a tree",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+
+		issue, err := svc.Get(ids[0])
+		if err != nil {
+			return err
+		}
+		blockedBy, err := buildDepTree(svc.Dependencies, issue.ID, map[string]bool{issue.ID: true}, map[string]bool{})
+		if err != nil {
+			return err
+		}
+		blocks, err := buildDepTree(svc.Dependents, issue.ID, map[string]bool{issue.ID: true}, map[string]bool{})
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "dependency_tree", depTree{Issue: issue, BlockedBy: blockedBy, Blocks: blocks})
+		}
+
+		writer := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(writer, "%s %s [P%d] %s\n", statusSymbol(issue.Status), issue.ID, issue.Priority, issue.Title)
+		_, _ = fmt.Fprintln(writer, "Blocked by:")
+		printDepTree(writer, blockedBy, "  ")
+		_, _ = fmt.Fprintln(writer, "Blocks:")
+		printDepTree(writer, blocks, "  ")
+		return nil
+	},
+}
+
+// buildDepTree expands edges from id recursively, marking cycles and issues already shown elsewhere.
+func buildDepTree(next func(string) ([]model.Issue, error), id string, path, expanded map[string]bool) ([]depTreeNode, error) {
+	issues, err := next(id)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]depTreeNode, 0, len(issues))
+	for _, issue := range issues {
+		node := depTreeNode{Issue: issue, Nodes: []depTreeNode{}}
+		switch {
+		case path[issue.ID]:
+			node.Cycle = true
+		case expanded[issue.ID]:
+			node.Repeated = true
+		default:
+			expanded[issue.ID] = true
+			path[issue.ID] = true
+			node.Nodes, err = buildDepTree(next, issue.ID, path, expanded)
+			delete(path, issue.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// printDepTree draws tree nodes with box-drawing connectors under a prefix.
+func printDepTree(writer io.Writer, nodes []depTreeNode, prefix string) {
+	if len(nodes) == 0 {
+		_, _ = fmt.Fprintf(writer, "%snone\n", prefix)
+		return
+	}
+	for i, node := range nodes {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+		suffix := ""
+		if node.Cycle {
+			suffix = " (cycle)"
+		} else if node.Repeated {
+			suffix = " (see above)"
+		}
+		_, _ = fmt.Fprintf(writer, "%s%s%s %s [P%d] %s%s\n", prefix, connector, statusSymbol(node.Issue.Status), node.Issue.ID, node.Issue.Priority, node.Issue.Title, suffix)
+		if len(node.Nodes) > 0 {
+			printDepTree(writer, node.Nodes, childPrefix)
+		}
+	}
+}
+
+// init registers the dependency tree command.
+func init() {
+	depCmd.AddCommand(depTreeCmd)
+}
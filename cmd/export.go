@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/jsonl"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// defaultExportFile is the JSONL file export and import use without a path.
+const defaultExportFile = ".faz-issues.jsonl"
+
+var (
+	exportPath      string
+	importOverwrite bool
+	importDryRun    bool
+)
+
+// autoExportState remembers the configured auto-export file and the change-log
+// position it last reflects. Long-running commands update it from a goroutine.
+var autoExportState struct {
+	sync.Mutex
+	path     string
+	baseline int64
+	armed    bool
+}
+
+// exportResult is the JSON payload for `faz export`.
+type exportResult struct {
+	Path         string `json:"path"`
+	Issues       int    `json:"issues"`
+	Dependencies int    `json:"dependencies"`
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write issues and dependencies as sorted JSONL for Git",
+	Long:  "Export writes one JSON line per issue and per dependency, sorted by ID with UTC timestamps, so the file diffs and merges cleanly in Git. Without --jsonl the file is .faz-issues.jsonl at the repository root; use --jsonl - for stdout.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		data, err := svc.Export()
+		if err != nil {
+			return err
+		}
+		if exportPath == "-" {
+			return jsonl.Write(cmd.OutOrStdout(), data)
+		}
+		path, err := exchangePath(exportPath)
+		if err != nil {
+			return err
+		}
+		if err := jsonl.WriteFile(path, data); err != nil {
+			return err
+		}
+
+		result := exportResult{Path: path, Issues: len(data.Issues), Dependencies: len(data.Dependencies)}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "export", result)
+		}
+		stdoutPrintf(cmd, "Exported %d issues and %d dependencies to %s\n", result.Issues, result.Dependencies, path)
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [path]",
+	Short: "Upsert issues and dependencies from a JSONL export",
+	Long:  "Import matches issues by public ID: new issues are created, and existing ones are replaced when the imported copy has a newer updated_at. Tombstones delete local issues the same way. Local issues that changed more recently are kept and listed as conflicts unless --overwrite is set. Dependencies are only added, never removed. Without a path the file is .faz-issues.jsonl at the repository root.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := ""
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		path, err := exchangePath(ref)
+		if err != nil {
+			return err
+		}
+		data, err := jsonl.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		if !importDryRun {
+			if err := snapshotBefore(sqlDB, "import"); err != nil {
+				return err
+			}
+		}
+		report, err := svc.Import(data, importOverwrite, importDryRun)
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "import", report)
+		}
+		printImportReport(cmd, path, report)
+		return nil
+	},
+}
+
+// exchangePath resolves an export file argument; empty means the default file at the worktree root.
+func exchangePath(ref string) (string, error) {
+	if ref != "" {
+		return ref, nil
+	}
+	worktreeDir, err := currentWorktreeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(worktreeDir, defaultExportFile), nil
+}
+
+// printImportReport writes the import summary followed by a conflict table.
+func printImportReport(cmd *cobra.Command, path string, report model.ImportReport) {
+	verb := "Imported"
+	if report.DryRun {
+		verb = "Dry run of"
+	}
+	stdoutPrintf(cmd, "%s %s\n", verb, path)
+	stdoutPrintf(cmd, "  Created: %d\n", len(report.Created))
+	stdoutPrintf(cmd, "  Updated: %d\n", len(report.Updated))
+	stdoutPrintf(cmd, "  Deleted: %d\n", len(report.Deleted))
+	stdoutPrintf(cmd, "  Unchanged: %d\n", report.Unchanged)
+	stdoutPrintf(cmd, "  Dependencies added: %d\n", report.DependenciesAdded)
+	if len(report.Conflicts) == 0 {
+		return
+	}
+
+	stdoutPrintf(cmd, "  Conflicts: %d\n", len(report.Conflicts))
+	tableWriter := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tableWriter, "ISSUE\tREASON\tDETAIL")
+	for _, conflict := range report.Conflicts {
+		issue := conflict.IssueID
+		if conflict.DependsOnID != "" {
+			issue += " -> " + conflict.DependsOnID
+		}
+		_, _ = fmt.Fprintf(tableWriter, "%s\t%s\t%s\n", issue, conflict.Reason, conflict.Detail)
+	}
+	_ = tableWriter.Flush()
+}
+
+// armAutoExport records the change-log position when export.auto_path is set.
+// Only the first store opened by a command sets the baseline. Relative paths
+// resolve against the current worktree, where the file is committed.
+func armAutoExport(worktreeDir, autoPath string, latest func() (int64, error)) error {
+	autoExportState.Lock()
+	defer autoExportState.Unlock()
+	if autoPath == "" || autoExportState.armed {
+		return nil
+	}
+	baseline, err := latest()
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(autoPath) {
+		autoPath = filepath.Join(worktreeDir, autoPath)
+	}
+	autoExportState.path = autoPath
+	autoExportState.baseline = baseline
+	autoExportState.armed = true
+	return nil
+}
+
+// autoExportArmed reports whether export.auto_path is set for this command.
+func autoExportArmed() bool {
+	autoExportState.Lock()
+	defer autoExportState.Unlock()
+	return autoExportState.armed
+}
+
+// runAutoExport rewrites the auto-export file when the command changed the store.
+// Execute calls it after failed commands too, since they may have written first.
+func runAutoExport() error {
+	if !autoExportArmed() {
+		return nil
+	}
+	defer func() {
+		autoExportState.Lock()
+		autoExportState.armed = false
+		autoExportState.Unlock()
+	}()
+
+	// openService sees the export still armed and keeps the baseline.
+	svc, sqlDB, err := openService()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sqlDB.Close() }()
+	return exportIfChanged(svc)
+}
+
+// autoExportWhileRunning keeps the auto-export file current for serve, mcp and
+// kanban by rewriting it whenever the change log moves, until ctx ends. Failed
+// writes are retried on the next poll.
+func autoExportWhileRunning(ctx context.Context, svc *service.IssueService) {
+	if !autoExportArmed() {
+		return
+	}
+	ticker := time.NewTicker(model.ChangePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = exportIfChanged(svc)
+		}
+	}
+}
+
+// exportIfChanged writes the auto-export file when the change log moved since it was last written.
+func exportIfChanged(svc *service.IssueService) error {
+	autoExportState.Lock()
+	defer autoExportState.Unlock()
+
+	latest, err := svc.LatestChange()
+	if err != nil {
+		return err
+	}
+	if latest == autoExportState.baseline {
+		return nil
+	}
+	data, err := svc.Export()
+	if err != nil {
+		return err
+	}
+	if err := jsonl.WriteFile(autoExportState.path, data); err != nil {
+		return fmt.Errorf("auto-export: %w", err)
+	}
+	autoExportState.baseline = latest
+	return nil
+}
+
+// init registers export and import commands.
+func init() {
+	exportCmd.Flags().StringVar(&exportPath, "jsonl", "", "JSONL file to write (default .faz-issues.jsonl at the repository root; - for stdout)")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace local issues even when they changed more recently")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Report what would change without writing")
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
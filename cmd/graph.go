@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var graphEpic string
+
+// issueGraph is the JSON payload for `faz graph`.
+type issueGraph struct {
+	Issues       []model.Issue      `json:"issues"`
+	Dependencies []model.Dependency `json:"dependencies"`
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the issue, parent, and dependency graph as DOT or Mermaid",
+	Long:  "Graph prints every issue with its parent and dependency edges. The global --format picks the output: dot (the default), mermaid, or json|ndjson for the raw graph.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := currentOutputFormat()
+		if format == formatTable || format == formatPlain {
+			return usageErrorf("invalid output format %q for graph (expected dot|mermaid|json|ndjson)", format)
+		}
+
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		issues, err := svc.List(model.ListFilter{All: true})
+		if err != nil {
+			return err
+		}
+		edges, err := svc.DependencyEdges()
+		if err != nil {
+			return err
+		}
+
+		graph := issueGraph{Issues: issues, Dependencies: edges}
+		if cmd.Flags().Changed("epic") {
+			ids, err := parseIDs([]string{graphEpic})
+			if err != nil {
+				return err
+			}
+			epic, err := svc.Get(ids[0])
+			if err != nil {
+				return err
+			}
+			if epic.Type != "epic" {
+				return fmt.Errorf("issue %s is a %s, not an epic", epic.ID, epic.Type)
+			}
+			graph = scopeGraphToEpic(graph, epic.ID)
+		}
+
+		switch format {
+		case formatJSON, formatNDJSON:
+			return writeStructured(cmd.OutOrStdout(), "graph", graph)
+		case formatMermaid:
+			renderMermaidGraph(cmd.OutOrStdout(), graph)
+		default:
+			renderDOTGraph(cmd.OutOrStdout(), graph)
+		}
+		return nil
+	},
+}
+
+// scopeGraphToEpic keeps an epic, its children, and any issue directly linked to them by a dependency.
+func scopeGraphToEpic(graph issueGraph, epicID string) issueGraph {
+	inScope := map[string]bool{epicID: true}
+	for _, issue := range graph.Issues {
+		if issue.ParentID != nil && *issue.ParentID == epicID {
+			inScope[issue.ID] = true
+		}
+	}
+
+	keep := make(map[string]bool, len(inScope))
+	for id := range inScope {
+		keep[id] = true
+	}
+	edges := make([]model.Dependency, 0)
+	for _, edge := range graph.Dependencies {
+		if !inScope[edge.IssueID] && !inScope[edge.DependsOnID] {
+			continue
+		}
+		keep[edge.IssueID] = true
+		keep[edge.DependsOnID] = true
+		edges = append(edges, edge)
+	}
+
+	issues := make([]model.Issue, 0, len(keep))
+	for _, issue := range graph.Issues {
+		if keep[issue.ID] {
+			issues = append(issues, issue)
+		}
+	}
+	return issueGraph{Issues: issues, Dependencies: edges}
+}
+
+// renderDOTGraph writes a Graphviz digraph with dashed parent links and solid "blocks" edges.
+func renderDOTGraph(writer io.Writer, graph issueGraph) {
+	_, _ = fmt.Fprintln(writer, "digraph faz {")
+	_, _ = fmt.Fprintln(writer, "  rankdir=LR;")
+	_, _ = fmt.Fprintln(writer, `  node [shape=box, style="rounded,filled", fillcolor=white, fontname="Helvetica"];`)
+	for _, issue := range graph.Issues {
+		lines := graphNodeLabel(issue)
+		for i, line := range lines {
+			lines[i] = dotEscape(line)
+		}
+		attrs := []string{fmt.Sprintf("label=\"%s\"", strings.Join(lines, `\n`))}
+		if issue.Type == "epic" {
+			attrs = append(attrs, "shape=folder")
+		}
+		switch issue.Status {
+		case "closed":
+			attrs = append(attrs, "fillcolor=gray90", "fontcolor=gray45")
+		case "in_progress":
+			attrs = append(attrs, "fillcolor=lightgoldenrod1")
+		}
+		_, _ = fmt.Fprintf(writer, "  %s [%s];\n", dotQuote(issue.ID), strings.Join(attrs, ", "))
+	}
+	present := graphIssueIDs(graph)
+	for _, issue := range graph.Issues {
+		if issue.ParentID != nil && present[*issue.ParentID] {
+			_, _ = fmt.Fprintf(writer, "  %s -> %s [style=dashed, arrowhead=none, color=gray60];\n", dotQuote(*issue.ParentID), dotQuote(issue.ID))
+		}
+	}
+	for _, edge := range graph.Dependencies {
+		_, _ = fmt.Fprintf(writer, "  %s -> %s [label=\"blocks\"];\n", dotQuote(edge.DependsOnID), dotQuote(edge.IssueID))
+	}
+	_, _ = fmt.Fprintln(writer, "}")
+}
+
+// renderMermaidGraph writes a Mermaid flowchart with dotted parent links and solid "blocks" edges.
+func renderMermaidGraph(writer io.Writer, graph issueGraph) {
+	_, _ = fmt.Fprintln(writer, "flowchart LR")
+	nodeIDs := make(map[string]string, len(graph.Issues))
+	for i, issue := range graph.Issues {
+		nodeIDs[issue.ID] = fmt.Sprintf("n%d", i)
+	}
+	for _, issue := range graph.Issues {
+		lines := graphNodeLabel(issue)
+		for i, line := range lines {
+			lines[i] = mermaidEscape(line)
+		}
+		label := strings.Join(lines, "<br/>")
+		if issue.Type == "epic" {
+			_, _ = fmt.Fprintf(writer, "  %s[[\"%s\"]]\n", nodeIDs[issue.ID], label)
+			continue
+		}
+		_, _ = fmt.Fprintf(writer, "  %s[\"%s\"]\n", nodeIDs[issue.ID], label)
+	}
+	for _, issue := range graph.Issues {
+		if issue.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodeIDs[*issue.ParentID]; ok {
+			_, _ = fmt.Fprintf(writer, "  %s -.- %s\n", parent, nodeIDs[issue.ID])
+		}
+	}
+	for _, edge := range graph.Dependencies {
+		_, _ = fmt.Fprintf(writer, "  %s -->|blocks| %s\n", nodeIDs[edge.DependsOnID], nodeIDs[edge.IssueID])
+	}
+	_, _ = fmt.Fprintln(writer, "  classDef closed fill:#eeeeee,color:#777777")
+	_, _ = fmt.Fprintln(writer, "  classDef in_progress fill:#fff3b0")
+	for _, issue := range graph.Issues {
+		if issue.Status == "closed" || issue.Status == "in_progress" {
+			_, _ = fmt.Fprintf(writer, "  class %s %s\n", nodeIDs[issue.ID], issue.Status)
+		}
+	}
+}
+
+// graphNodeLabel returns the ID, title, and type/priority/status lines of one node.
+func graphNodeLabel(issue model.Issue) []string {
+	return []string{
+		issue.ID,
+		issue.Title,
+		fmt.Sprintf("%s · P%d · %s", issue.Type, issue.Priority, issue.Status),
+	}
+}
+
+// graphIssueIDs indexes the issue IDs present in a graph.
+func graphIssueIDs(graph issueGraph) map[string]bool {
+	present := make(map[string]bool, len(graph.Issues))
+	for _, issue := range graph.Issues {
+		present[issue.ID] = true
+	}
+	return present
+}
+
+// dotQuote wraps a value as a DOT string literal.
+func dotQuote(value string) string {
+	return `"` + dotEscape(value) + `"`
+}
+
+// dotEscape escapes backslashes and quotes for a DOT string literal.
+func dotEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// mermaidEscape replaces characters that break quoted Mermaid labels.
+func mermaidEscape(value string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(value)
+}
+
+// init wires command flags and registration.
+func init() {
+	graphCmd.Flags().StringVar(&graphEpic, "epic", "", "Only export one epic, its children, and their direct dependencies")
+	rootCmd.AddCommand(graphCmd)
+}
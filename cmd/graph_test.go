@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// TestGraphRenderersEmitParentAndDependencyEdges verifies DOT and Mermaid exports share one graph shape.
+func TestGraphRenderersEmitParentAndDependencyEdges(t *testing.T) {
+	epicID := "faz-ab12"
+	graph := issueGraph{
+		Issues: []model.Issue{
+			{ID: epicID, Title: "Checkout", Type: "epic", Priority: 1, Status: "open"},
+			{ID: "faz-ab12.0", Title: `Say "hi"`, Type: "task", Priority: 1, Status: "closed", ParentID: &epicID},
+			{ID: "faz-ab12.1", Title: "Ship", Type: "task", Priority: 2, Status: "open", ParentID: &epicID},
+		},
+		Dependencies: []model.Dependency{{IssueID: "faz-ab12.1", DependsOnID: "faz-ab12.0"}},
+	}
+
+	var dot bytes.Buffer
+	renderDOTGraph(&dot, graph)
+	for _, want := range []string{
+		`"faz-ab12" -> "faz-ab12.0" [style=dashed`,
+		`"faz-ab12.0" -> "faz-ab12.1" [label="blocks"]`,
+		`Say \"hi\"`,
+		"shape=folder",
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Fatalf("expected %q in DOT output:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	renderMermaidGraph(&mermaid, graph)
+	for _, want := range []string{
+		"flowchart LR",
+		"n0 -.- n1",
+		"n1 -->|blocks| n2",
+		"Say #quot;hi#quot;",
+		"class n1 closed",
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Fatalf("expected %q in Mermaid output:\n%s", want, mermaid.String())
+		}
+	}
+}
+
+// TestScopeGraphToEpicKeepsDirectExternalBlockers verifies epic export keeps linked outside issues only.
+func TestScopeGraphToEpicKeepsDirectExternalBlockers(t *testing.T) {
+	epicID := "faz-ab12"
+	graph := issueGraph{
+		Issues: []model.Issue{
+			{ID: epicID, Type: "epic"},
+			{ID: "faz-ab12.0", Type: "task", ParentID: &epicID},
+			{ID: "faz-cd34", Type: "task"},
+			{ID: "faz-ef56", Type: "task"},
+		},
+		Dependencies: []model.Dependency{
+			{IssueID: "faz-ab12.0", DependsOnID: "faz-cd34"},
+			{IssueID: "faz-ef56", DependsOnID: "faz-cd34"},
+		},
+	}
+
+	scoped := scopeGraphToEpic(graph, epicID)
+	if len(scoped.Issues) != 3 || len(scoped.Dependencies) != 1 {
+		t.Fatalf("unexpected scoped graph: %+v", scoped)
+	}
+	for _, issue := range scoped.Issues {
+		if issue.ID == "faz-ef56" {
+			t.Fatalf("unrelated issue leaked into epic graph")
+		}
+	}
+}
+
+// TestBuildDepTreeMarksCyclesAndRepeats verifies transitive expansion stops on revisits.
+func TestBuildDepTreeMarksCyclesAndRepeats(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"a"},
+	}
+	next := func(id string) ([]model.Issue, error) {
+		issues := make([]model.Issue, 0)
+		for _, target := range edges[id] {
+			issues = append(issues, model.Issue{ID: target, Title: target, Status: "open"})
+		}
+		return issues, nil
+	}
+
+	nodes, err := buildDepTree(next, "a", map[string]bool{"a": true}, map[string]bool{})
+	if err != nil {
+		t.Fatalf("build tree: %v", err)
+	}
+
+	var out bytes.Buffer
+	printDepTree(&out, nodes, "")
+	got := out.String()
+	want := strings.Join([]string{
+		"├── ○ b [P0] b",
+		"│   └── ○ d [P0] d",
+		"│       └── ○ a [P0] a (cycle)",
+		"└── ○ c [P0] c",
+		"    └── ○ d [P0] d (see above)",
+	}, "\n") + "\n"
+	if got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestGraphTakesItsSyntaxFromTheGlobalFormatFlag verifies --format picks DOT or Mermaid for graph only.
+func TestGraphTakesItsSyntaxFromTheGlobalFormatFlag(t *testing.T) {
+	root := initGitRepo(t)
+	restore := chdir(t, root)
+	defer restore()
+	resetOutputFlags(t)
+
+	runInitForTest(t)
+
+	stdout, _, err := executeRootCommand(t, "graph", "--format", "mermaid")
+	if err != nil || !strings.HasPrefix(stdout, "flowchart") {
+		t.Fatalf("graph --format mermaid = %q, %v", stdout, err)
+	}
+	stdout, _, err = executeRootCommand(t, "graph", "--format", "dot")
+	if err != nil || !strings.HasPrefix(stdout, "digraph") {
+		t.Fatalf("graph --format dot = %q, %v", stdout, err)
+	}
+	if _, _, err := executeRootCommand(t, "list", "--format", "mermaid"); err == nil {
+		t.Fatal("expected list --format mermaid to be rejected")
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -9,16 +11,23 @@ import (
 	"path/filepath"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/rpcarvs/faz/internal/config"
 	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/hooks"
 	"github.com/rpcarvs/faz/internal/model"
 	"github.com/rpcarvs/faz/internal/repo"
 	"github.com/rpcarvs/faz/internal/service"
 	"github.com/spf13/cobra"
 )
 
+// leaseSweepInterval is how often serve, mcp and kanban check for lapsed claims.
+const leaseSweepInterval = time.Minute
+
 const (
 	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
 	ansiGray   = "\033[90m"
 	ansiGreen  = "\033[32m"
 	ansiRed    = "\033[31m"
@@ -27,8 +36,31 @@ const (
 	ansiYellow = "\033[33m"
 )
 
-// currentProjectDir resolves the Git repository root for project-scoped commands.
+// currentProjectDir resolves the directory holding the task store for project-scoped commands.
+// In a linked Git worktree this is the main checkout, so every worktree shares one store,
+// unless worktree.share_store is false in the main checkout's or the global config.
 func currentProjectDir() (string, error) {
+	worktreeDir, err := currentWorktreeDir()
+	if err != nil {
+		return "", err
+	}
+	mainDir, ok := gitMainRootDir(worktreeDir)
+	if !ok || sameDir(mainDir, worktreeDir) {
+		return worktreeDir, nil
+	}
+	effective, err := projectConfig(mainDir)
+	if err != nil {
+		return "", err
+	}
+	if !effective.Config.SharesWorktreeStore() {
+		return worktreeDir, nil
+	}
+	return mainDir, nil
+}
+
+// currentWorktreeDir resolves the top-level directory of the checked-out worktree.
+// Files committed to Git, such as the JSONL export, live here.
+func currentWorktreeDir() (string, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("resolve working directory: %w", err)
@@ -53,6 +85,44 @@ func gitRootDir(dir string) (string, error) {
 	return root, nil
 }
 
+// gitMainRootDir returns the main checkout that owns the repository's shared Git directory.
+// It reports false for bare repositories and submodules, whose common directory is not a .git folder.
+func gitMainRootDir(dir string) (string, bool) {
+	cmd := exec.Command("git", "rev-parse", "--path-format=absolute", "--git-common-dir")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	commonDir := filepath.Clean(strings.TrimSpace(string(output)))
+	if filepath.Base(commonDir) != ".git" {
+		return "", false
+	}
+	return filepath.Dir(commonDir), true
+}
+
+// gitBranch returns the branch checked out in dir, or "" when HEAD is detached.
+func gitBranch(dir string) string {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// sameDir reports whether two paths name the same directory after resolving symlinks.
+func sameDir(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
 // openService opens the initialized project DB and builds the issue service.
 func openService() (*service.IssueService, *sql.DB, error) {
 	projectDir, err := currentProjectDir()
@@ -62,15 +132,107 @@ func openService() (*service.IssueService, *sql.DB, error) {
 
 	sqlDB, _, err := db.OpenProjectDB(projectDir)
 	if err != nil {
-		if err == db.ErrNotInitialized {
-			return nil, nil, fmt.Errorf("faz is not initialized for this Git repository. Run `faz init`")
+		switch {
+		case errors.Is(err, db.ErrNotInitialized):
+			return nil, nil, friendlyError{msg: "faz is not initialized for this Git repository. Run `faz init`", err: err}
+		case errors.Is(err, db.ErrSchemaOutdated):
+			return nil, nil, friendlyError{msg: err.Error() + ". Run `faz db migrate`", err: err}
+		case errors.Is(err, db.ErrSchemaTooNew):
+			return nil, nil, friendlyError{msg: err.Error() + ". Upgrade faz to use this task store", err: err}
 		}
 		return nil, nil, err
 	}
 
+	effective, err := projectConfig(projectDir)
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, err
+	}
+
 	projectName := filepath.Base(projectDir)
 	issueRepo := repo.NewIssueRepo(sqlDB)
-	return service.NewIssueService(issueRepo, projectName), sqlDB, nil
+	issueRepo.SetActor(resolveActor())
+	worktreeDir, err := currentWorktreeDir()
+	if err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, err
+	}
+	issueRepo.SetWorkspace(worktreeDir, gitBranch(worktreeDir))
+	svc := service.NewIssueService(issueRepo, projectName)
+	if err := svc.Configure(effective.Config.Settings()); err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if runner := projectHooks(effective.Config.Hooks, projectDir, os.Stderr); runner != nil {
+		svc.SetHooks(runner)
+	}
+	if err := armAutoExport(worktreeDir, effective.Config.Export.AutoPath, svc.LatestChange); err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, err
+	}
+	return svc, sqlDB, nil
+}
+
+// sweepLeasesEvery records lapsed claims and fires their on_lease_expired hooks
+// now and then on a timer until ctx ends, so long-running commands report them
+// without waiting for a new claim. Failed sweeps are retried on the next tick.
+func sweepLeasesEvery(ctx context.Context, svc *service.IssueService) {
+	_ = svc.SweepExpiredLeases()
+	ticker := time.NewTicker(leaseSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = svc.SweepExpiredLeases()
+		}
+	}
+}
+
+// projectConfig merges the global config file with .faz/config.toml.
+func projectConfig(projectDir string) (config.Effective, error) {
+	globalPath, err := config.GlobalPath()
+	if err != nil {
+		return config.Effective{}, err
+	}
+	return config.LoadEffective(globalPath, filepath.Join(projectDir, db.DirName, config.FileName))
+}
+
+// projectHooks builds the lifecycle hook runner for the configured hooks, writing
+// hook output to output. It returns nil when hooksEnabled reports false.
+func projectHooks(configured config.Hooks, projectDir string, output io.Writer) *hooks.Runner {
+	if !hooksEnabled(configured) {
+		return nil
+	}
+	return hooks.NewRunner(configured, projectDir, output)
+}
+
+// hooksEnabled reports whether hooks are configured and faz is not itself running inside a hook.
+func hooksEnabled(configured config.Hooks) bool {
+	return os.Getenv(hooks.EnvHookDepth) == "" && !configured.Empty()
+}
+
+// resolveActor picks the identity recorded for claims, comments and changes.
+func resolveActor() string {
+	if actor := strings.TrimSpace(actorFlag); actor != "" {
+		return actor
+	}
+	if actor := strings.TrimSpace(os.Getenv("FAZ_AGENT")); actor != "" {
+		return actor
+	}
+	if session := strings.TrimSpace(os.Getenv(envSessionID)); session != "" {
+		return "session:" + session
+	}
+	if output, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if actor := strings.TrimSpace(string(output)); actor != "" {
+			return actor
+		}
+	}
+	if actor := strings.TrimSpace(os.Getenv("USER")); actor != "" {
+		return actor
+	}
+	return "unknown"
 }
 
 // stdoutPrintln writes line-oriented command output to stdout.
@@ -91,9 +253,13 @@ func printIssueTable(writer io.Writer, issues []model.Issue) {
 	}
 
 	tableWriter := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tableWriter, "ID\tTYPE\tPRIORITY\tSTATUS\tTITLE")
+	_, _ = fmt.Fprintln(tableWriter, "ID\tTYPE\tPRIORITY\tSTATUS\tOWNER\tTITLE")
 	for _, issue := range issues {
-		_, _ = fmt.Fprintf(tableWriter, "%s\t%s\tP%d\t%s\t%s\n", issue.ID, issue.Type, issue.Priority, issue.Status, issue.Title)
+		owner := "-"
+		if issue.Status == "in_progress" && issue.ClaimedBy != nil {
+			owner = *issue.ClaimedBy
+		}
+		_, _ = fmt.Fprintf(tableWriter, "%s\t%s\tP%d\t%s\t%s\t%s\n", issue.ID, issue.Type, issue.Priority, issue.Status, owner, issue.Title)
 	}
 	_ = tableWriter.Flush()
 }
@@ -120,10 +286,32 @@ func printIssueList(writer io.Writer, issues []model.Issue) {
 			title = colorizeEpic(title)
 		}
 
-		_, _ = fmt.Fprintf(writer, "%s %s %s %s - %s\n", symbol, issue.ID, priority, typeLabel, title)
+		_, _ = fmt.Fprintf(writer, "%s %s %s %s - %s%s\n", symbol, issue.ID, priority, typeLabel, title, ownerSuffix(issue))
 	}
 }
 
+// printPlainIssueList writes issue lines with symbols but without ANSI colors.
+func printPlainIssueList(writer io.Writer, issues []model.Issue) {
+	if len(issues) == 0 {
+		_, _ = fmt.Fprintln(writer, "No issues found")
+		return
+	}
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(writer, "%s %s [P%d] [%s] - %s%s\n", statusSymbol(issue.Status), issue.ID, issue.Priority, issue.Type, issue.Title, ownerSuffix(issue))
+	}
+}
+
+// ownerSuffix renders the claimant of an in-progress issue for list output.
+func ownerSuffix(issue model.Issue) string {
+	if issue.Status != "in_progress" || issue.ClaimedBy == nil {
+		return ""
+	}
+	if issue.ClaimBranch != nil {
+		return " (@" + *issue.ClaimedBy + " on " + *issue.ClaimBranch + ")"
+	}
+	return " (@" + *issue.ClaimedBy + ")"
+}
+
 // printIssueReminder writes a compact issue summary for immediate execution context.
 func printIssueReminder(writer io.Writer, issue model.Issue) {
 	_, _ = fmt.Fprintf(writer, "  Title: %s\n", issue.Title)
@@ -131,6 +319,9 @@ func printIssueReminder(writer io.Writer, issue model.Issue) {
 	if issue.ParentID != nil {
 		_, _ = fmt.Fprintf(writer, "  Parent: %s\n", *issue.ParentID)
 	}
+	if len(issue.Labels) > 0 {
+		_, _ = fmt.Fprintf(writer, "  Labels: %s\n", strings.Join(issue.Labels, ", "))
+	}
 	if description := strings.TrimSpace(issue.Description); description != "" {
 		_, _ = fmt.Fprintln(writer, "  Description:")
 		for _, line := range strings.Split(description, "\n") {
@@ -139,6 +330,16 @@ func printIssueReminder(writer io.Writer, issue model.Issue) {
 	}
 }
 
+// printComments writes work-log notes with timestamps and authors under an indent.
+func printComments(writer io.Writer, indent string, comments []model.Comment) {
+	for _, comment := range comments {
+		_, _ = fmt.Fprintf(writer, "%s[%s] %s:\n", indent, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Author)
+		for _, line := range strings.Split(comment.Body, "\n") {
+			_, _ = fmt.Fprintf(writer, "%s  %s\n", indent, line)
+		}
+	}
+}
+
 // statusSymbol maps issue status values to list glyphs.
 func statusSymbol(status string) string {
 	switch status {
@@ -186,6 +387,12 @@ func parseIDs(args []string) ([]string, error) {
 	return ids, nil
 }
 
+// addLabelFilterFlags registers the shared --label and --label-match filter flags.
+func addLabelFilterFlags(cmd *cobra.Command, labels *[]string, match *string) {
+	cmd.Flags().StringSliceVar(labels, "label", nil, "Filter by label (repeatable or comma-separated)")
+	cmd.Flags().StringVar(match, "label-match", model.LabelMatchAny, "How --label values combine (any|all|none)")
+}
+
 // defaultDescription trims user-provided description text.
 func defaultDescription(input string) string {
 	return strings.TrimSpace(input)
@@ -27,6 +27,56 @@ func TestCurrentProjectDirUsesGitRootFromSubdir(t *testing.T) {
 	}
 }
 
+// TestCurrentProjectDirSharesMainCheckoutAcrossWorktrees verifies linked worktrees use one store.
+func TestCurrentProjectDirSharesMainCheckoutAcrossWorktrees(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	root := initGitRepo(t)
+	worktree := filepath.Join(t.TempDir(), "agent-1")
+	for _, args := range [][]string{
+		{"-c", "user.name=faz", "-c", "user.email=faz@example.com", "commit", "--allow-empty", "-m", "init"},
+		{"worktree", "add", "-b", "agent-1", worktree},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	worktree, err := filepath.EvalSymlinks(worktree)
+	if err != nil {
+		t.Fatalf("resolve worktree path: %v", err)
+	}
+
+	restore := chdir(t, worktree)
+	defer restore()
+
+	projectDir, err := currentProjectDir()
+	if err != nil {
+		t.Fatalf("resolve project dir: %v", err)
+	}
+	if projectDir != root {
+		t.Fatalf("project dir = %q, want main checkout %q", projectDir, root)
+	}
+	if branch := gitBranch(worktree); branch != "agent-1" {
+		t.Fatalf("branch = %q, want agent-1", branch)
+	}
+
+	configDir := filepath.Join(root, ".faz")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte("[worktree]\nshare_store = false\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	projectDir, err = currentProjectDir()
+	if err != nil {
+		t.Fatalf("resolve project dir without sharing: %v", err)
+	}
+	if projectDir != worktree {
+		t.Fatalf("project dir = %q, want worktree %q", projectDir, worktree)
+	}
+}
+
 // initGitRepo creates a temporary Git repository for command path tests.
 func initGitRepo(t *testing.T) string {
 	t.Helper()
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// eventValueWidth caps how much of a changed value history lines show.
+const eventValueWidth = 60
+
+var logSince string
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the audit trail of an issue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+
+		events, err := svc.History(ids[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "event_list", events)
+		}
+		stdoutPrintf(cmd, "History for %s:\n", ids[0])
+		if len(events) == 0 {
+			stdoutPrintln(cmd, "  none")
+			return nil
+		}
+		printEvents(cmd.OutOrStdout(), events, false)
+		return nil
+	},
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recent changes across all issues",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseSince(logSince, time.Now())
+		if err != nil {
+			return err
+		}
+
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		events, err := svc.Log(since)
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "event_list", events)
+		}
+		stdoutPrintf(cmd, "Changes since %s:\n", since.Local().Format("2006-01-02 15:04"))
+		if len(events) == 0 {
+			stdoutPrintln(cmd, "  none")
+			return nil
+		}
+		printEvents(cmd.OutOrStdout(), events, true)
+		return nil
+	},
+}
+
+// parseSince accepts a lookback duration (90m, 2h, 3d) or an absolute date/time.
+func parseSince(raw string, now time.Time) (time.Time, error) {
+	value := strings.TrimSpace(raw)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, usageErrorf("invalid --since value %q (use a duration like 2h or 3d, or a date like 2006-01-02)", raw)
+}
+
+// printEvents writes audit events one per line, optionally prefixed by issue ID.
+func printEvents(writer io.Writer, events []model.IssueEvent, withIssue bool) {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	for _, event := range events {
+		prefix := "  " + event.CreatedAt.Local().Format("2006-01-02 15:04:05")
+		if withIssue {
+			prefix += "\t" + event.IssueID
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", prefix, event.Actor, event.Action, describeEventChange(event))
+	}
+	_ = table.Flush()
+}
+
+// describeEventChange renders the field transition of one event.
+func describeEventChange(event model.IssueEvent) string {
+	switch {
+	case event.Field == "":
+		return ""
+	case event.OldValue == nil && event.NewValue == nil:
+		return event.Field
+	case event.OldValue == nil:
+		return fmt.Sprintf("%s: %s", event.Field, eventValue(event.NewValue))
+	case event.NewValue == nil:
+		return fmt.Sprintf("%s: %s (removed)", event.Field, eventValue(event.OldValue))
+	default:
+		return fmt.Sprintf("%s: %s -> %s", event.Field, eventValue(event.OldValue), eventValue(event.NewValue))
+	}
+}
+
+// eventValue flattens and truncates a stored event value for one-line display.
+func eventValue(value *string) string {
+	if value == nil {
+		return "none"
+	}
+	text := strings.Join(strings.Fields(*value), " ")
+	if text == "" {
+		return `""`
+	}
+	if runes := []rune(text); len(runes) > eventValueWidth {
+		return string(runes[:eventValueWidth-3]) + "..."
+	}
+	return text
+}
+
+// init wires command flags and registration.
+func init() {
+	logCmd.Flags().StringVar(&logSince, "since", "24h", "Show changes newer than a duration (2h, 3d) or a date")
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(logCmd)
+}
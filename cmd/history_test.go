@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseSinceAcceptsDurationsDaysAndDates verifies the accepted --since forms.
+func TestParseSinceAcceptsDurationsDaysAndDates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		raw  string
+		want time.Time
+	}{
+		{raw: "2h", want: now.Add(-2 * time.Hour)},
+		{raw: "3d", want: now.AddDate(0, 0, -3)},
+		{raw: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tc := range tests {
+		got, err := parseSince(tc.raw, now)
+		if err != nil {
+			t.Fatalf("parseSince(%q): %v", tc.raw, err)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("parseSince(%q) = %v, want %v", tc.raw, got, tc.want)
+		}
+	}
+	if _, err := parseSince("yesterday", now); err == nil {
+		t.Fatalf("expected error for unsupported --since value")
+	}
+}
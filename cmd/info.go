@@ -1,6 +1,15 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// infoSummary is the JSON payload for `faz info`.
+type infoSummary struct {
+	OpenCount       int64         `json:"open_count"`
+	RecentCompleted []model.Issue `json:"recent_completed"`
+}
 
 var infoCmd = &cobra.Command{
 	Use:   "info",
@@ -17,6 +26,13 @@ var infoCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "info", infoSummary{
+				OpenCount:       openCount,
+				RecentCompleted: completed,
+			})
+		}
+
 		stdoutPrintf(cmd, "Open issues: %d\n", openCount)
 		stdoutPrintln(cmd)
 		stdoutPrintln(cmd, "Latest completed (max 5):")
@@ -11,6 +11,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// initResult is the JSON payload for `faz init`.
+type initResult struct {
+	AlreadyInitialized bool   `json:"already_initialized"`
+	Directory          string `json:"directory"`
+	Database           string `json:"database"`
+	GitIgnoreAdded     bool   `json:"gitignore_added"`
+}
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize faz in the current project",
@@ -46,6 +54,15 @@ var initCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "init", initResult{
+				AlreadyInitialized: alreadyInitialized,
+				Directory:          fazDir,
+				Database:           dbPath,
+				GitIgnoreAdded:     addedGitIgnore,
+			})
+		}
+
 		if alreadyInitialized {
 			stdoutPrintln(cmd, "faz is already initialized")
 		} else {
@@ -4,5 +4,7 @@ import "github.com/rpcarvs/faz/cmd/installskills"
 
 // init wires command flags and registration.
 func init() {
-	rootCmd.AddCommand(installskills.NewCommand(currentProjectDir))
+	installCmd := installskills.NewCommand(currentWorktreeDir)
+	installCmd.AddCommand(installMergeDriverCmd)
+	rootCmd.AddCommand(installCmd)
 }
@@ -9,7 +9,7 @@ type ProjectRootFunc func() (string, error)
 func NewCommand(projectRoot ProjectRootFunc) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install",
-		Short: "Install built-in agent integration for Codex or Claude",
+		Short: "Install built-in agent integration for Codex or Claude, or the Git merge driver",
 	}
 
 	cmd.AddCommand(newProviderCommand("codex", projectRoot))
@@ -12,6 +12,7 @@ import (
 func newProviderCommand(name string, projectRoot ProjectRootFunc) *cobra.Command {
 	var local bool
 	var force bool
+	var mcp bool
 
 	cmd := &cobra.Command{
 		Use:   name,
@@ -22,13 +23,15 @@ This installs the task-management-with-faz skill, the managed task context block
 and a SessionStart hook that runs faz init and faz onboard.
 
 Use --local to install into the current Git repository instead of the global
-%[1]s configuration.`, providerLabel(name)),
+%[1]s configuration. Use --mcp to also register the faz MCP server
+(faz mcp) in the %[1]s MCP configuration.`, providerLabel(name)),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options := skillinstaller.InstallOptions{
 				Provider: skillinstaller.Provider(name),
 				Local:    local,
 				Force:    force,
+				MCP:      mcp,
 			}
 			if local {
 				if projectRoot == nil {
@@ -52,6 +55,7 @@ Use --local to install into the current Git repository instead of the global
 
 	cmd.Flags().BoolVar(&local, "local", false, "Install into the current Git repository")
 	cmd.Flags().BoolVar(&force, "force", false, "Replace existing skill directory before installing")
+	cmd.Flags().BoolVar(&mcp, "mcp", false, "Also register the faz MCP server")
 	return cmd
 }
 
@@ -69,6 +73,9 @@ func printInstallResult(cmd *cobra.Command, provider string, local bool, result
 	if result.ClaudePointerPath != "" {
 		_, _ = fmt.Fprintf(out, "  Claude pointer (%s): %s\n", result.ClaudePointerAction, result.ClaudePointerPath)
 	}
+	if result.MCPPath != "" {
+		_, _ = fmt.Fprintf(out, "  MCP server (%s): %s\n", result.MCPAction, result.MCPPath)
+	}
 }
 
 // providerLabel returns a user-facing provider name.
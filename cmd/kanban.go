@@ -1,22 +1,28 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/fsnotify/fsnotify"
+	"github.com/rpcarvs/faz/internal/config"
 	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/service"
 	"github.com/rpcarvs/faz/internal/tui/kanban"
 	"github.com/spf13/cobra"
 )
 
+// kanbanHookLogName is the file under .faz that collects hook output while the board runs.
+const kanbanHookLogName = "hooks.log"
+
 var kanbanPickEpic bool
 
 var kanbanCmd = &cobra.Command{
 	Use:   "kanban",
-	Short: "Open a read-only kanban TUI for tasks",
-	Long:  "Kanban opens a read-only terminal view of faz tasks grouped into TO DO, CLAIMED, and DONE columns across all epics or a selected epic scope.",
+	Short: "Open a kanban TUI for tasks",
+	Long:  "Kanban opens a terminal board of faz tasks grouped into TO DO, CLAIMED, and DONE columns across all epics or a selected epic scope. The [kanban] config section sets extra BLOCKED and READY columns, swimlanes by priority, type or label, the color theme and the key bindings. Tasks can be created and edited, and the selected card can be claimed, released, closed, reopened and reprioritized from the board. Click a card to select it, click it again to open it, and use the wheel to scroll.",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		svc, sqlDB, err := openService()
@@ -25,36 +31,85 @@ var kanbanCmd = &cobra.Command{
 		}
 		defer func() { _ = sqlDB.Close() }()
 
-		var opts []kanban.Option
-		if kanbanPickEpic {
-			opts = append(opts, kanban.WithPicker())
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		effective, err := projectConfig(projectDir)
+		if err != nil {
+			return err
 		}
 
-		projectDir, err := currentProjectDir()
+		theme, err := kanban.LoadTheme(effective.Config.KanbanTheme(), effective.Config.Kanban.Palettes)
 		if err != nil {
 			return err
 		}
-		watcher, err := fsnotify.NewWatcher()
+		keymap, err := kanban.NewKeymap(effective.Config.Kanban.Keys)
 		if err != nil {
 			return err
 		}
-		defer func() {
-			if closeErr := watcher.Close(); closeErr != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "close watcher: %v\n", closeErr)
-			}
-		}()
-		if err := watcher.Add(filepath.Join(projectDir, db.DirName)); err != nil {
+
+		var opts []kanban.Option
+		if kanbanPickEpic {
+			opts = append(opts, kanban.WithPicker())
+		}
+		settings := svc.Settings()
+		opts = append(opts,
+			kanban.WithLiveUpdates(),
+			kanban.WithClaimLease(settings.DefaultClaimTTL),
+			kanban.WithIssueDefaults(settings.DefaultType, settings.DefaultPriority),
+			kanban.WithLayout(effective.Config.KanbanColumns(), effective.Config.KanbanSwimlanes()),
+			kanban.WithTheme(theme),
+			kanban.WithKeymap(keymap),
+		)
+
+		warnings, closeLog, err := boardHooks(svc, effective.Config.Hooks, projectDir)
+		if err != nil {
 			return err
 		}
-		opts = append(opts, kanban.WithWatcher(watcher))
+		defer closeLog()
+		if warnings != nil {
+			opts = append(opts, kanban.WithHookWarnings(warnings))
+		}
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		go sweepLeasesEvery(ctx, svc)
+		go autoExportWhileRunning(ctx, svc)
 
 		model := kanban.NewModel(svc, opts...)
-		program := tea.NewProgram(model, tea.WithAltScreen())
+		program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 		_, err = program.Run()
 		return err
 	},
 }
 
+// boardHooks gives the service a hook runner that suits the alt screen: hook
+// output is appended to .faz/hooks.log and failed notification hooks arrive on
+// the returned channel for the board to show. The returned func closes the log.
+func boardHooks(svc *service.IssueService, configured config.Hooks, projectDir string) (<-chan string, func(), error) {
+	if !hooksEnabled(configured) {
+		return nil, func() {}, nil
+	}
+	logPath := filepath.Join(projectDir, db.DirName, kanbanHookLogName)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open hook log: %w", err)
+	}
+
+	warnings := make(chan string, 16)
+	runner := projectHooks(configured, projectDir, logFile)
+	runner.OnWarning(func(message string) {
+		select {
+		case warnings <- message:
+		default:
+			// The board is behind on toasts; the warning is still in the log.
+		}
+	})
+	svc.SetHooks(runner)
+	return warnings, func() { _ = logFile.Close() }, nil
+}
+
 // init wires command flags and registration.
 func init() {
 	kanbanCmd.Flags().BoolVarP(&kanbanPickEpic, "epic", "e", false, "Open directly to the scope/epic picker")
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// labelChange is the JSON payload for label mutations.
+type labelChange struct {
+	IssueID string   `json:"issue_id"`
+	Labels  []string `json:"labels"`
+	Action  string   `json:"action"`
+}
+
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Manage issue labels",
+}
+
+var labelAddCmd = &cobra.Command{
+	Use:   "add <id> <label> [label...]",
+	Short: "Attach labels to an issue",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+		if err := svc.AddLabels(ids[0], args[1:]); err != nil {
+			return err
+		}
+		issue, err := svc.Get(ids[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "label", labelChange{IssueID: issue.ID, Labels: issue.Labels, Action: "added"})
+		}
+		stdoutPrintf(cmd, "Labeled issue: %s\n", issue.ID)
+		stdoutPrintf(cmd, "  Labels: %s\n", strings.Join(issue.Labels, ", "))
+		return nil
+	},
+}
+
+var labelRemoveCmd = &cobra.Command{
+	Use:   "remove <id> <label> [label...]",
+	Short: "Detach labels from an issue",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args[:1])
+		if err != nil {
+			return err
+		}
+		if err := svc.RemoveLabels(ids[0], args[1:]); err != nil {
+			return err
+		}
+		issue, err := svc.Get(ids[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "label", labelChange{IssueID: issue.ID, Labels: issue.Labels, Action: "removed"})
+		}
+		stdoutPrintf(cmd, "Unlabeled issue: %s\n", issue.ID)
+		if len(issue.Labels) == 0 {
+			stdoutPrintln(cmd, "  Labels: none")
+		} else {
+			stdoutPrintf(cmd, "  Labels: %s\n", strings.Join(issue.Labels, ", "))
+		}
+		return nil
+	},
+}
+
+var labelListCmd = &cobra.Command{
+	Use:   "list [id]",
+	Short: "List labels in use, or the labels on one issue",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		if len(args) == 1 {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+			issue, err := svc.Get(ids[0])
+			if err != nil {
+				return err
+			}
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), "label", labelChange{IssueID: issue.ID, Labels: issue.Labels, Action: "listed"})
+			}
+			stdoutPrintf(cmd, "Labels for %s:\n", issue.ID)
+			if len(issue.Labels) == 0 {
+				stdoutPrintln(cmd, "  none")
+			}
+			for _, label := range issue.Labels {
+				stdoutPrintf(cmd, "  %s\n", label)
+			}
+			return nil
+		}
+
+		labels, err := svc.Labels()
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "label_list", labels)
+		}
+		printLabelCounts(cmd, labels)
+		return nil
+	},
+}
+
+// printLabelCounts writes label usage rows.
+func printLabelCounts(cmd *cobra.Command, labels []model.LabelCount) {
+	if len(labels) == 0 {
+		stdoutPrintln(cmd, "No labels found")
+		return
+	}
+	for _, label := range labels {
+		stdoutPrintf(cmd, "  %s (%d open, %d total)\n", label.Name, label.Open, label.Issues)
+	}
+}
+
+// init registers label commands.
+func init() {
+	labelCmd.AddCommand(labelAddCmd)
+	labelCmd.AddCommand(labelRemoveCmd)
+	labelCmd.AddCommand(labelListCmd)
+	rootCmd.AddCommand(labelCmd)
+}
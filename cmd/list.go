@@ -11,6 +11,8 @@ var (
 	listPriority int
 	listParent   string
 	listAll      bool
+	listLabels   []string
+	listMatch    string
 )
 
 var listCmd = &cobra.Command{
@@ -24,9 +26,11 @@ var listCmd = &cobra.Command{
 		defer func() { _ = sqlDB.Close() }()
 
 		filter := model.ListFilter{
-			Type:   listType,
-			Status: listStatus,
-			All:    listAll,
+			Type:       listType,
+			Status:     listStatus,
+			All:        listAll,
+			Labels:     listLabels,
+			LabelMatch: listMatch,
 		}
 		if cmd.Flags().Changed("priority") {
 			filter.Priority = &listPriority
@@ -43,8 +47,7 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		printIssueList(cmd.OutOrStdout(), issues)
-		return nil
+		return writeIssues(cmd, "issue_list", issues)
 	},
 }
 
@@ -55,5 +58,6 @@ func init() {
 	listCmd.Flags().IntVar(&listPriority, "priority", 2, "Filter by priority (0-3)")
 	listCmd.Flags().StringVar(&listParent, "parent", "", "Filter by parent ID")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "Include closed issues")
+	addLabelFilterFlags(listCmd, &listLabels, &listMatch)
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/rpcarvs/faz/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve faz tools to agents over MCP (JSON-RPC on stdin/stdout)",
+	Long:  "Mcp runs a Model Context Protocol server on stdin/stdout exposing faz_ready, faz_claim, faz_create, faz_close, faz_show, faz_dep_add and faz_comment. Register it with `faz install claude --mcp` or `faz install codex --mcp`.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Each MCP client runs its own server process; give it its own identity.
+		if err := ensureSessionID("mcp"); err != nil {
+			return err
+		}
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+		go sweepLeasesEvery(ctx, svc)
+		go autoExportWhileRunning(ctx, svc)
+
+		server := mcp.NewServer(svc, resolveActor(), buildVersion)
+		return server.Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+// init wires command flags and registration.
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
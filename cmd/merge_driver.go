@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/jsonl"
+	"github.com/spf13/cobra"
+)
+
+// mergeDriverName is the merge driver key written to .git/config and .gitattributes.
+const mergeDriverName = "faz"
+
+var mergeDriverFile string
+
+// mergeDriverInstallResult is the JSON payload for `faz install git-merge-driver`.
+type mergeDriverInstallResult struct {
+	Driver         string `json:"driver"`
+	File           string `json:"file"`
+	GitAttributes  string `json:"gitattributes"`
+	AttributesLine string `json:"attributes_line"`
+	Action         string `json:"action"`
+}
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs>",
+	Short: "Three-way merge exported issue files (Git merge driver)",
+	Long:  "merge-driver merges the JSONL issue export record by record, keyed on issue ID, and writes the result over <ours>. Git runs it as `faz merge-driver %O %A %B` once `faz install git-merge-driver` has registered it. Field changes made on one side are kept; fields changed on both sides take the later updated_at. Labels and dependencies merge as sets, and deletions are kept as tombstones.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, err := jsonl.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		ours, err := jsonl.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		theirs, err := jsonl.ReadFile(args[2])
+		if err != nil {
+			return err
+		}
+		return jsonl.WriteFile(args[1], jsonl.Merge(base, ours, theirs))
+	},
+}
+
+var installMergeDriverCmd = &cobra.Command{
+	Use:   "git-merge-driver",
+	Short: "Register faz merge-driver for the exported issue file",
+	Long:  "Registers `faz merge-driver` as the `faz` merge driver in .git/config and routes the exported issue file to it in .gitattributes. The file defaults to export.auto_path, or .faz-issues.jsonl when that is unset.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		worktreeDir, err := currentWorktreeDir()
+		if err != nil {
+			return err
+		}
+		file, err := mergeDriverTarget(projectDir, worktreeDir)
+		if err != nil {
+			return err
+		}
+
+		settings := [][2]string{
+			{"merge." + mergeDriverName + ".name", "faz issue export merge"},
+			{"merge." + mergeDriverName + ".driver", "faz merge-driver %O %A %B"},
+		}
+		for _, setting := range settings {
+			gitCmd := exec.Command("git", "config", "--local", setting[0], setting[1])
+			gitCmd.Dir = worktreeDir
+			if output, err := gitCmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("git config %s: %s", setting[0], strings.TrimSpace(string(output)))
+			}
+		}
+
+		result := mergeDriverInstallResult{
+			Driver:         mergeDriverName,
+			File:           file,
+			GitAttributes:  filepath.Join(worktreeDir, ".gitattributes"),
+			AttributesLine: file + " merge=" + mergeDriverName,
+		}
+		result.Action, err = ensureLine(result.GitAttributes, result.AttributesLine)
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "merge_driver_install", result)
+		}
+		stdoutPrintln(cmd, "Installed faz Git merge driver:")
+		stdoutPrintf(cmd, "  Driver: merge.%s in .git/config\n", mergeDriverName)
+		stdoutPrintf(cmd, "  Attributes (%s): %s\n", result.Action, result.GitAttributes)
+		stdoutPrintf(cmd, "  File: %s\n", file)
+		return nil
+	},
+}
+
+// mergeDriverTarget picks the worktree-relative export file routed to the driver.
+func mergeDriverTarget(projectDir, worktreeDir string) (string, error) {
+	file := strings.TrimSpace(mergeDriverFile)
+	if file == "" {
+		effective, err := projectConfig(projectDir)
+		if err != nil {
+			return "", err
+		}
+		file = effective.Config.Export.AutoPath
+	}
+	if file == "" {
+		file = defaultExportFile
+	}
+	if filepath.IsAbs(file) {
+		rel, err := filepath.Rel(worktreeDir, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return "", fmt.Errorf("export file %s is outside the repository", file)
+		}
+		file = rel
+	}
+	return filepath.ToSlash(filepath.Clean(file)), nil
+}
+
+// ensureLine appends line to path unless an identical line is already there.
+func ensureLine(path, line string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	for _, existing := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(existing) == line {
+			return "unchanged", nil
+		}
+	}
+
+	action := "updated"
+	if len(content) == 0 {
+		action = "created"
+	}
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		content = append(content, '\n')
+	}
+	content = append(content, line+"\n"...)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+	return action, nil
+}
+
+// init wires command flags and registration.
+func init() {
+	installMergeDriverCmd.Flags().StringVar(&mergeDriverFile, "file", "", "Export file to route to the driver (default export.auto_path or .faz-issues.jsonl)")
+	rootCmd.AddCommand(mergeDriverCmd)
+}
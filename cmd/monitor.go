@@ -2,27 +2,30 @@ package cmd
 
 import (
 	"fmt"
-	"path/filepath"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/fsnotify/fsnotify"
-	"github.com/rpcarvs/faz/internal/db"
 	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
 	"github.com/spf13/cobra"
 )
 
 var (
 	monitorAll     bool
 	monitorClaimed bool
+	monitorLabels  []string
+	monitorMatch   string
 )
 
 var monitorCmd = &cobra.Command{
 	Use:   "monitor",
 	Short: "Continuously refresh task list output",
-	Long:  "Monitor watches the task database and refreshes the list whenever it changes.",
+	Long:  "Monitor follows the change log and refreshes the list only when an issue actually changes.",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if monitorAll && monitorClaimed {
-			return fmt.Errorf("--all and --claimed cannot be used together")
+			return usageErrorf("--all and --claimed cannot be used together")
 		}
 
 		svc, sqlDB, err := openService()
@@ -31,64 +34,56 @@ var monitorCmd = &cobra.Command{
 		}
 		defer func() { _ = sqlDB.Close() }()
 
-		projectDir, err := currentProjectDir()
+		cursor, err := svc.LatestChange()
 		if err != nil {
 			return err
 		}
-		watcher, err := fsnotify.NewWatcher()
-		if err != nil {
-			return err
-		}
-		defer func() {
-			if closeErr := watcher.Close(); closeErr != nil {
-				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "close watcher: %v\n", closeErr)
-			}
-		}()
-		if err := watcher.Add(filepath.Join(projectDir, db.DirName)); err != nil {
-			return err
-		}
 
-		filter := model.ListFilter{All: monitorAll}
+		filter := model.ListFilter{All: monitorAll, Labels: monitorLabels, LabelMatch: monitorMatch}
 		if monitorClaimed {
 			filter.Status = "in_progress"
 		}
 
-		render := func() error {
-			_, _ = fmt.Fprint(cmd.OutOrStdout(), "\033[H\033[2J")
-			issues, err := svc.List(filter)
-			if err != nil {
-				return err
+		issues, err := svc.List(filter)
+		if err != nil {
+			return err
+		}
+		render := func(events []model.IssueEvent) error {
+			if len(events) > 0 {
+				issues, err = patchIssues(svc, filter, issues, events)
+				if err != nil {
+					return err
+				}
 			}
+			if !structuredOutput() {
+				_, _ = fmt.Fprint(cmd.OutOrStdout(), "\033[H\033[2J")
+			}
+			shown := issues
 			if monitorClaimed {
-				issues, err = claimedIssuesWithParents(svc, issues)
+				shown, err = claimedIssuesWithParents(svc, issues)
 				if err != nil {
 					return err
 				}
 			}
-			printIssueList(cmd.OutOrStdout(), issues)
+			if err := writeIssues(cmd, "issue_list", shown); err != nil {
+				return err
+			}
+			if len(events) > 0 && !structuredOutput() {
+				last := events[len(events)-1]
+				stdoutPrintf(cmd, "\nLast change #%d: %s %s by %s\n", last.ID, last.IssueID, last.Action, last.Actor)
+			}
 			return nil
 		}
 
-		if err := render(); err != nil {
+		if err := render(nil); err != nil {
 			return err
 		}
 
-		for {
-			select {
-			case _, ok := <-watcher.Events:
-				if !ok {
-					return nil
-				}
-				if err := render(); err != nil {
-					return err
-				}
-			case err, ok := <-watcher.Errors:
-				if !ok {
-					return nil
-				}
-				return err
-			}
-		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		// Sweeping records lapsed leases as changes, so the list refreshes when a claim expires.
+		go sweepLeasesEvery(ctx, svc)
+		return followChanges(ctx, svc, cursor, model.ChangePollInterval, render)
 	},
 }
 
@@ -96,9 +91,48 @@ var monitorCmd = &cobra.Command{
 func init() {
 	monitorCmd.Flags().BoolVar(&monitorAll, "all", false, "Include closed issues")
 	monitorCmd.Flags().BoolVar(&monitorClaimed, "claimed", false, "Show only claimed issues (in_progress)")
+	addLabelFilterFlags(monitorCmd, &monitorLabels, &monitorMatch)
 	rootCmd.AddCommand(monitorCmd)
 }
 
+// patchIssues refetches only the issues named in events and patches them into issues.
+// Issues that no longer match filter drop out; one that newly matches triggers a full
+// reload, since the list order depends on its epic.
+func patchIssues(svc *service.IssueService, filter model.ListFilter, issues []model.Issue, events []model.IssueEvent) ([]model.Issue, error) {
+	touched := make(map[string]struct{}, len(events))
+	for _, event := range events {
+		if _, ok := touched[event.IssueID]; !ok {
+			touched[event.IssueID] = struct{}{}
+			filter.IDs = append(filter.IDs, event.IssueID)
+		}
+	}
+	matched, err := svc.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	updated := make(map[string]model.Issue, len(matched))
+	for _, issue := range matched {
+		updated[issue.ID] = issue
+	}
+
+	patched := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if _, ok := touched[issue.ID]; !ok {
+			patched = append(patched, issue)
+			continue
+		}
+		if replacement, ok := updated[issue.ID]; ok {
+			patched = append(patched, replacement)
+			delete(updated, issue.ID)
+		}
+	}
+	if len(updated) > 0 {
+		filter.IDs = nil
+		return svc.List(filter)
+	}
+	return patched, nil
+}
+
 // claimedIssuesWithParents prepends parent epics for claimed tasks to keep context visible.
 func claimedIssuesWithParents(svc interface {
 	Get(string) (model.Issue, error)
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// TestPatchIssuesFollowsTouchedIssuesInAndOutOfTheFilter verifies monitor patches only changed issues.
+func TestPatchIssuesFollowsTouchedIssuesInAndOutOfTheFilter(t *testing.T) {
+	root := initGitRepo(t)
+	restore := chdir(t, root)
+	defer restore()
+	resetOutputFlags(t)
+
+	runInitForTest(t)
+
+	svc, sqlDB, err := openService()
+	if err != nil {
+		t.Fatalf("open service: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	firstID, err := svc.Create(model.Issue{Title: "First", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create first: %v", err)
+	}
+	secondID, err := svc.Create(model.Issue{Title: "Second", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create second: %v", err)
+	}
+	if err := svc.Claim(firstID, time.Minute); err != nil {
+		t.Fatalf("claim first: %v", err)
+	}
+
+	filter := model.ListFilter{Status: "in_progress"}
+	issues, err := svc.List(filter)
+	if err != nil {
+		t.Fatalf("list claimed: %v", err)
+	}
+
+	patch := func(change func()) []model.Issue {
+		t.Helper()
+		cursor, err := svc.LatestChange()
+		if err != nil {
+			t.Fatalf("latest change: %v", err)
+		}
+		change()
+		events, err := svc.Changes(cursor, model.ChangeBatchSize)
+		if err != nil {
+			t.Fatalf("read changes: %v", err)
+		}
+		patched, err := patchIssues(svc, filter, issues, events)
+		if err != nil {
+			t.Fatalf("patch issues: %v", err)
+		}
+		return patched
+	}
+	full := func() []model.Issue {
+		t.Helper()
+		issues, err := svc.List(filter)
+		if err != nil {
+			t.Fatalf("list claimed: %v", err)
+		}
+		return issues
+	}
+	summary := func(issues []model.Issue) []string {
+		out := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			out = append(out, issue.ID+" "+issue.Title)
+		}
+		return out
+	}
+
+	issues = patch(func() {
+		if err := svc.Update(firstID, map[string]any{"title": "First renamed"}); err != nil {
+			t.Fatalf("rename first: %v", err)
+		}
+		if err := svc.Claim(secondID, time.Minute); err != nil {
+			t.Fatalf("claim second: %v", err)
+		}
+	})
+	if got, want := summary(issues), summary(full()); len(got) != 2 || !reflect.DeepEqual(got, want) || !slices.Contains(got, firstID+" First renamed") {
+		t.Fatalf("after rename and claim = %v, want %v", got, want)
+	}
+
+	issues = patch(func() {
+		if err := svc.Release(firstID); err != nil {
+			t.Fatalf("release first: %v", err)
+		}
+	})
+	if got, want := summary(issues), []string{secondID + " Second"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after release = %v, want %v", got, want)
+	}
+}
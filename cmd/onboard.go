@@ -18,6 +18,7 @@ var onboardCmd = &cobra.Command{
 		stdoutPrintln(cmd, "- `faz create \"Title\" --type task --priority 2` - Create issue")
 		stdoutPrintln(cmd, "- `faz dep add <task-id> <blocker-id>` - Mark blocked work")
 		stdoutPrintln(cmd, "- `faz claim <id>` - Claim work and mark in_progress")
+		stdoutPrintln(cmd, "- `faz claim --next` - Atomically claim the highest-priority ready issue")
 		stdoutPrintln(cmd, "- `faz show <id>` - Read details, children, and dependencies")
 		stdoutPrintln(cmd, "- `faz close <id>` - Complete work")
 		stdoutPrintln(cmd)
@@ -32,6 +33,7 @@ var onboardCmd = &cobra.Command{
 		stdoutPrintln(cmd, "- Claim exactly one non-epic issue before coding")
 		stdoutPrintln(cmd, "- `in_progress` is set by `faz claim`, not by `faz update --status`")
 		stdoutPrintln(cmd, "- If `faz claim` says the issue is already claimed, pick another ready issue")
+		stdoutPrintln(cmd, "- Keep long work alive with `faz heartbeat <id>`; hand it back with `faz release <id>`")
 	},
 }
 
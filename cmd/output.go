@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+	"github.com/rpcarvs/faz/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// jsonSchemaVersion is bumped whenever the JSON envelope or payload shapes change incompatibly.
+const jsonSchemaVersion = 1
+
+const (
+	formatDefault = ""
+	formatJSON    = "json"
+	formatNDJSON  = "ndjson"
+	formatTable   = "table"
+	formatPlain   = "plain"
+	formatDOT     = "dot"
+	formatMermaid = "mermaid"
+)
+
+const (
+	exitGeneric        = 1
+	exitUsage          = 2
+	exitNotFound       = 3
+	exitConflict       = 4
+	exitNotInitialized = 5
+)
+
+var (
+	outputJSON   bool
+	outputFormat string
+)
+
+// jsonEnvelope wraps every machine-readable payload with a schema version and kind.
+type jsonEnvelope struct {
+	SchemaVersion int        `json:"schema_version"`
+	Kind          string     `json:"kind"`
+	Data          any        `json:"data,omitempty"`
+	Error         *jsonError `json:"error,omitempty"`
+}
+
+// jsonError describes a failed command for machine consumers.
+type jsonError struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// issueDetail is the JSON payload for `faz show`.
+type issueDetail struct {
+	Issue        model.Issue     `json:"issue"`
+	Children     []model.Issue   `json:"children"`
+	Dependencies []model.Issue   `json:"dependencies"`
+	Dependents   []model.Issue   `json:"dependents"`
+	Comments     []model.Comment `json:"comments"`
+}
+
+// friendlyError keeps a user-facing message while preserving the wrapped sentinel.
+type friendlyError struct {
+	msg string
+	err error
+}
+
+// Error returns the user-facing message.
+func (e friendlyError) Error() string { return e.msg }
+
+// Unwrap exposes the underlying sentinel for classification.
+func (e friendlyError) Unwrap() error { return e.err }
+
+// usageError marks a command-line mistake so it classifies like service.ErrInvalidInput.
+type usageError struct {
+	err error
+}
+
+// Error returns the underlying message unchanged.
+func (e usageError) Error() string { return e.err.Error() }
+
+// Unwrap exposes the underlying error.
+func (e usageError) Unwrap() error { return e.err }
+
+// Is reports usage errors as invalid input.
+func (e usageError) Is(target error) bool { return target == service.ErrInvalidInput }
+
+// usageErrorf formats a command-line mistake as a usage error.
+func usageErrorf(format string, args ...any) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
+
+// markUsageErrors makes the argument validators of cmd and its subcommands return usage errors.
+func markUsageErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return usageError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markUsageErrors(child)
+	}
+}
+
+// resolveOutputFormat validates global output flags and returns the effective format.
+func resolveOutputFormat() (string, error) {
+	switch outputFormat {
+	case formatDefault, formatJSON, formatNDJSON, formatTable, formatPlain, formatDOT, formatMermaid:
+	default:
+		return "", usageErrorf("invalid output format %q (expected json|ndjson|table|plain, or dot|mermaid for graph)", outputFormat)
+	}
+	if outputJSON {
+		if outputFormat != formatDefault && outputFormat != formatJSON {
+			return "", usageErrorf("--json cannot be combined with --format %s", outputFormat)
+		}
+		return formatJSON, nil
+	}
+	return outputFormat, nil
+}
+
+// currentOutputFormat returns the effective format, falling back to the default on invalid flags.
+func currentOutputFormat() string {
+	format, err := resolveOutputFormat()
+	if err != nil {
+		return formatDefault
+	}
+	return format
+}
+
+// structuredOutput reports whether commands should emit JSON instead of prose.
+func structuredOutput() bool {
+	format := currentOutputFormat()
+	return format == formatJSON || format == formatNDJSON
+}
+
+// writeStructured emits a payload as a versioned JSON envelope.
+func writeStructured(writer io.Writer, kind string, data any) error {
+	if currentOutputFormat() == formatNDJSON {
+		if issues, ok := data.([]model.Issue); ok {
+			for _, issue := range issues {
+				if err := encodeEnvelope(writer, jsonEnvelope{SchemaVersion: jsonSchemaVersion, Kind: "issue", Data: issue}, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return encodeEnvelope(writer, jsonEnvelope{SchemaVersion: jsonSchemaVersion, Kind: kind, Data: data}, false)
+	}
+	return encodeEnvelope(writer, jsonEnvelope{SchemaVersion: jsonSchemaVersion, Kind: kind, Data: data}, true)
+}
+
+// writeStructuredError emits a failed command as a JSON error envelope.
+func writeStructuredError(writer io.Writer, err error) {
+	code, exitCode := classifyError(err)
+	_ = encodeEnvelope(writer, jsonEnvelope{
+		SchemaVersion: jsonSchemaVersion,
+		Kind:          "error",
+		Error: &jsonError{
+			Code:     code,
+			Message:  err.Error(),
+			ExitCode: exitCode,
+		},
+	}, currentOutputFormat() != formatNDJSON)
+}
+
+// encodeEnvelope writes one envelope, indented or as a single line.
+func encodeEnvelope(writer io.Writer, envelope jsonEnvelope, indent bool) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(envelope); err != nil {
+		return fmt.Errorf("encode json output: %w", err)
+	}
+	return nil
+}
+
+// writeIssues renders an issue list in the selected output format.
+func writeIssues(cmd *cobra.Command, kind string, issues []model.Issue) error {
+	writer := cmd.OutOrStdout()
+	switch currentOutputFormat() {
+	case formatJSON, formatNDJSON:
+		return writeStructured(writer, kind, issues)
+	case formatTable:
+		printIssueTable(writer, issues)
+	case formatPlain:
+		printPlainIssueList(writer, issues)
+	default:
+		printIssueList(writer, issues)
+	}
+	return nil
+}
+
+// classifyError maps known failures to stable error codes and process exit codes.
+func classifyError(err error) (string, int) {
+	switch {
+	case errors.Is(err, repo.ErrIssueAlreadyClaimed):
+		return "already_claimed", exitConflict
+	case errors.Is(err, repo.ErrIssueTypeNotClaimable):
+		return "not_claimable", exitConflict
+	case errors.Is(err, repo.ErrIssueNotClaimed):
+		return "not_claimed", exitConflict
+	case errors.Is(err, repo.ErrNotClaimOwner):
+		return "not_owner", exitConflict
+	case errors.Is(err, repo.ErrDependencyCycle):
+		return "dependency_cycle", exitConflict
+	case errors.Is(err, service.ErrHookVetoed):
+		return "vetoed", exitConflict
+	case errors.Is(err, repo.ErrNoReadyIssue):
+		return "no_ready_issue", exitNotFound
+	case errors.Is(err, repo.ErrIssueNotFound):
+		return "not_found", exitNotFound
+	case errors.Is(err, db.ErrNotInitialized):
+		return "not_initialized", exitNotInitialized
+	case errors.Is(err, db.ErrSchemaOutdated):
+		return "schema_outdated", exitNotInitialized
+	case errors.Is(err, db.ErrSchemaTooNew):
+		return "schema_too_new", exitConflict
+	case errors.Is(err, service.ErrInvalidInput):
+		return "usage", exitUsage
+	default:
+		return "error", exitGeneric
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// TestShowJSONIncludesRelations verifies show emits a versioned detail envelope.
+func TestShowJSONIncludesRelations(t *testing.T) {
+	root := initGitRepo(t)
+	restore := chdir(t, root)
+	defer restore()
+	resetOutputFlags(t)
+
+	runInitForTest(t)
+
+	svc, sqlDB, err := openService()
+	if err != nil {
+		t.Fatalf("open service: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	epicID, err := svc.Create(model.Issue{Title: "Epic", Type: "epic", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create epic: %v", err)
+	}
+	childID, err := svc.Create(model.Issue{Title: "Child", Type: "task", Priority: 1, Status: "open", ParentID: &epicID})
+	if err != nil {
+		t.Fatalf("create child: %v", err)
+	}
+	blockerID, err := svc.Create(model.Issue{Title: "Blocker", Type: "task", Priority: 2, Status: "open"})
+	if err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+	if err := svc.AddDependency(childID, blockerID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	stdout, _, err := executeRootCommand(t, "show", childID, "--json")
+	if err != nil {
+		t.Fatalf("show --json: %v", err)
+	}
+
+	var envelope struct {
+		SchemaVersion int    `json:"schema_version"`
+		Kind          string `json:"kind"`
+		Data          struct {
+			Issue        model.Issue   `json:"issue"`
+			Children     []model.Issue `json:"children"`
+			Dependencies []model.Issue `json:"dependencies"`
+			Dependents   []model.Issue `json:"dependents"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("decode show output %q: %v", stdout, err)
+	}
+	if envelope.SchemaVersion != jsonSchemaVersion || envelope.Kind != "issue_detail" {
+		t.Fatalf("unexpected envelope header: %+v", envelope)
+	}
+	if envelope.Data.Issue.ID != childID || envelope.Data.Issue.ParentID == nil || *envelope.Data.Issue.ParentID != epicID {
+		t.Fatalf("unexpected issue payload: %+v", envelope.Data.Issue)
+	}
+	if len(envelope.Data.Dependencies) != 1 || envelope.Data.Dependencies[0].ID != blockerID {
+		t.Fatalf("unexpected dependencies: %+v", envelope.Data.Dependencies)
+	}
+	if envelope.Data.Children == nil || envelope.Data.Dependents == nil {
+		t.Fatalf("expected empty relation arrays instead of null")
+	}
+}
+
+// TestReadyNDJSONEmitsOneIssuePerLine verifies ndjson streams one envelope per issue.
+func TestReadyNDJSONEmitsOneIssuePerLine(t *testing.T) {
+	root := initGitRepo(t)
+	restore := chdir(t, root)
+	defer restore()
+	resetOutputFlags(t)
+
+	runInitForTest(t)
+
+	svc, sqlDB, err := openService()
+	if err != nil {
+		t.Fatalf("open service: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Create(model.Issue{Title: fmt.Sprintf("Task %d", i), Type: "task", Priority: 2, Status: "open"}); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+
+	stdout, _, err := executeRootCommand(t, "ready", "--format", "ndjson")
+	if err != nil {
+		t.Fatalf("ready --format ndjson: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 ndjson lines, got %d: %q", len(lines), stdout)
+	}
+	for _, line := range lines {
+		var envelope jsonEnvelope
+		if err := json.Unmarshal([]byte(line), &envelope); err != nil {
+			t.Fatalf("decode line %q: %v", line, err)
+		}
+		if envelope.Kind != "issue" {
+			t.Fatalf("line kind = %q, want issue", envelope.Kind)
+		}
+	}
+}
+
+// TestClassifyErrorMapsSentinels verifies stable error codes and exit codes.
+func TestClassifyErrorMapsSentinels(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     string
+		exitCode int
+	}{
+		{name: "claimed", err: friendlyError{msg: "taken", err: repo.ErrIssueAlreadyClaimed}, code: "already_claimed", exitCode: exitConflict},
+		{name: "not found", err: fmt.Errorf("issue %q %w", "faz-ab12", repo.ErrIssueNotFound), code: "not_found", exitCode: exitNotFound},
+		{name: "not initialized", err: friendlyError{msg: "run init", err: db.ErrNotInitialized}, code: "not_initialized", exitCode: exitNotInitialized},
+		{name: "schema outdated", err: friendlyError{msg: "migrate", err: db.ErrSchemaOutdated}, code: "schema_outdated", exitCode: exitNotInitialized},
+		{name: "usage", err: usageError{err: fmt.Errorf("unknown flag: --nope")}, code: "usage", exitCode: exitUsage},
+		{name: "invalid input", err: fmt.Errorf("search query cannot be empty: %w", service.ErrInvalidInput), code: "usage", exitCode: exitUsage},
+		{name: "generic", err: fmt.Errorf("boom"), code: "error", exitCode: exitGeneric},
+		{name: "generic dashed", err: fmt.Errorf("--stdin read failed"), code: "error", exitCode: exitGeneric},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			code, exitCode := classifyError(tc.err)
+			if code != tc.code || exitCode != tc.exitCode {
+				t.Fatalf("classifyError() = (%q, %d), want (%q, %d)", code, exitCode, tc.code, tc.exitCode)
+			}
+		})
+	}
+}
+
+// TestResolveOutputFormatRejectsConflicts verifies --json and --format must agree.
+func TestResolveOutputFormatRejectsConflicts(t *testing.T) {
+	resetOutputFlags(t)
+
+	outputJSON = true
+	outputFormat = formatTable
+	if _, err := resolveOutputFormat(); err == nil {
+		t.Fatalf("expected conflict error for --json with --format table")
+	}
+
+	outputJSON = false
+	outputFormat = "yaml"
+	if _, err := resolveOutputFormat(); err == nil {
+		t.Fatalf("expected invalid format error")
+	}
+}
+
+// resetOutputFlags restores global output flags after a test mutates them.
+func resetOutputFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		outputJSON = false
+		outputFormat = formatDefault
+	})
+}
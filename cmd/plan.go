@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <epic-id>",
+	Short: "Order an epic's open children into parallel waves and show the critical path",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+
+		plan, err := svc.Plan(ids[0])
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "plan", plan)
+		}
+
+		stdoutPrintf(cmd, "Plan for %s [%s]\n", plan.Epic.ID, plan.Epic.Title)
+		if len(plan.Waves) == 0 {
+			stdoutPrintln(cmd, "  No open children")
+		}
+		for _, wave := range plan.Waves {
+			stdoutPrintf(cmd, "Wave %d:\n", wave.Number)
+			for _, item := range wave.Items {
+				marker := " "
+				if item.Critical {
+					marker = "*"
+				}
+				line := fmt.Sprintf("  %s %s %s [P%d] %s (weight %d, start %d)", marker, statusSymbol(item.Issue.Status), item.Issue.ID, item.Issue.Priority, item.Issue.Title, item.Weight, item.EarliestStart)
+				if len(item.BlockedBy) > 0 {
+					line += " after " + strings.Join(item.BlockedBy, ", ")
+				}
+				stdoutPrintln(cmd, line)
+				if len(item.ExternalBlockers) > 0 {
+					stdoutPrintf(cmd, "      waiting on outside blockers: %s\n", strings.Join(item.ExternalBlockers, ", "))
+				}
+			}
+		}
+		if len(plan.CriticalPath) > 0 {
+			stdoutPrintf(cmd, "Critical path (weight %d, marked *): %s\n", plan.CriticalWeight, strings.Join(plan.CriticalPath, " -> "))
+		}
+		if len(plan.Done) > 0 {
+			stdoutPrintf(cmd, "Done: %d closed children\n", len(plan.Done))
+		}
+		return nil
+	},
+}
+
+// init wires command flags and registration.
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
@@ -1,6 +1,14 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	readyLabels     []string
+	readyLabelMatch string
+)
 
 var readyCmd = &cobra.Command{
 	Use:   "ready",
@@ -12,21 +20,21 @@ var readyCmd = &cobra.Command{
 		}
 		defer func() { _ = sqlDB.Close() }()
 
-		issues, err := svc.Ready()
+		issues, err := svc.Ready(model.ListFilter{Labels: readyLabels, LabelMatch: readyLabelMatch})
 		if err != nil {
 			return err
 		}
 
-		if len(issues) == 0 {
+		if len(issues) == 0 && !structuredOutput() {
 			stdoutPrintln(cmd, "No ready work")
 			return nil
 		}
-		printIssueList(cmd.OutOrStdout(), issues)
-		return nil
+		return writeIssues(cmd, "issue_list", issues)
 	},
 }
 
 // init wires command flags and registration.
 func init() {
+	addLabelFilterFlags(readyCmd, &readyLabels, &readyLabelMatch)
 	rootCmd.AddCommand(readyCmd)
 }
@@ -32,8 +32,11 @@ var recapCmd = &cobra.Command{
 		stdoutPrintln(cmd, "  onboard  Quick intro")
 		stdoutPrintln(cmd, "  info     Open count and latest 5 completed")
 		stdoutPrintln(cmd, "  create   Add issue")
-		stdoutPrintln(cmd, "  claim    Claim issue and set in_progress with lease")
+		stdoutPrintln(cmd, "  claim    Claim issue and set in_progress with lease (--next picks one atomically)")
+		stdoutPrintln(cmd, "  heartbeat Extend your lease (same as claim --renew)")
+		stdoutPrintln(cmd, "  release  Return your claimed issue to open")
 		stdoutPrintln(cmd, "  list     List issues with filters")
+		stdoutPrintln(cmd, "  search   Full-text search titles, descriptions and notes")
 		stdoutPrintln(cmd, "  children List direct child issues for a parent")
 		stdoutPrintln(cmd, "  ready    Show unblocked open work")
 		stdoutPrintln(cmd, "  show     Inspect issue with children and dependencies")
@@ -41,7 +44,15 @@ var recapCmd = &cobra.Command{
 		stdoutPrintln(cmd, "  close    Mark issues closed")
 		stdoutPrintln(cmd, "  reopen   Reopen closed issues")
 		stdoutPrintln(cmd, "  delete   Permanently remove issues")
-		stdoutPrintln(cmd, "  dep      Manage dependencies")
+		stdoutPrintln(cmd, "  dep      Manage dependencies (tree shows transitive blockers)")
+		stdoutPrintln(cmd, "  graph    Export the issue graph as DOT or Mermaid")
+		stdoutPrintln(cmd, "  plan     Order an epic's children into waves and show the critical path")
+		stdoutPrintln(cmd, "  label    Tag issues by area and filter with --label")
+		stdoutPrintln(cmd, "  comment  Leave a work-log note (read them with comments)")
+		stdoutPrintln(cmd, "  history  Show who changed an issue and how (log for all issues)")
+		stdoutPrintln(cmd, "  watch    Stream change events as they happen (--since <seq> resumes)")
+		stdoutPrintln(cmd, "  serve    Run a local HTTP JSON API for dashboards and plugins")
+		stdoutPrintln(cmd, "  mcp      Serve faz tools to agents over MCP stdio")
 		stdoutPrintln(cmd, "  install  Install Codex or Claude integration")
 		stdoutPrintln(cmd, "  completion Generate shell completions")
 		stdoutPrintln(cmd)
@@ -1,6 +1,9 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
 
 var reopenCmd = &cobra.Command{
 	Use:   "reopen <id> [id...]",
@@ -18,14 +21,26 @@ var reopenCmd = &cobra.Command{
 			return err
 		}
 
+		reopened := make([]model.Issue, 0, len(ids))
 		for _, id := range ids {
 			if err := svc.Reopen(id); err != nil {
 				return err
 			}
+			if structuredOutput() {
+				issue, err := svc.Get(id)
+				if err != nil {
+					return err
+				}
+				reopened = append(reopened, issue)
+				continue
+			}
 			stdoutPrintf(cmd, "Reopened issue: %s\n", id)
 			stdoutPrintf(cmd, "  Status: open\n")
 		}
 
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "issue_list", reopened)
+		}
 		return nil
 	},
 }
@@ -2,30 +2,81 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/fang"
 	"github.com/spf13/cobra"
 )
 
+var actorFlag string
+
+// buildVersion is the release version passed to Execute, reported by long-running servers.
+var buildVersion string
+
 var rootCmd = &cobra.Command{
 	Use:   "faz",
 	Short: "Simple local task tracking for AI agent workflows",
 	Long: `faz is a lightweight task tracker.
 It stores tasks in a project-local SQLite database and keeps epics, tasks,
 and dependencies in a simple graph model without external integrations.`,
+	SuggestionsMinimumDistance: 2,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return nil
+		}
+		message := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+		if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+			message += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t") + "\n"
+		}
+		return usageErrorf("%s", message)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveOutputFormat()
+		if err != nil {
+			return err
+		}
+		if (format == formatDOT || format == formatMermaid) && cmd != graphCmd {
+			return usageErrorf("invalid output format %q for %s (dot and mermaid only apply to faz graph)", format, cmd.CommandPath())
+		}
+		return nil
+	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		return runAutoExport()
+	},
 }
 
 // Execute runs the root command with the provided version string.
 func Execute(version string) {
-	options := []fang.Option{fang.WithoutManpage()}
+	buildVersion = version
+	options := []fang.Option{fang.WithoutManpage(), fang.WithErrorHandler(handleError)}
 	if version != "" {
 		options = append(options, fang.WithVersion(version))
 	}
 
+	markUsageErrors(rootCmd)
 	if err := fang.Execute(context.Background(), rootCmd, options...); err != nil {
-		os.Exit(1)
+		// PersistentPostRunE is skipped on failure, but the command may have written before it failed.
+		if exportErr := runAutoExport(); exportErr != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %v\n", exportErr)
+		}
+		_, exitCode := classifyError(err)
+		os.Exit(exitCode)
+	}
+}
+
+// handleError prints command failures as JSON in structured mode and styled text otherwise.
+func handleError(w io.Writer, styles fang.Styles, err error) {
+	if structuredOutput() {
+		writeStructuredError(rootCmd.OutOrStdout(), err)
+		return
 	}
+	fang.DefaultErrorHandler(w, styles, err)
 }
 
 // init wires command flags and registration.
@@ -33,4 +84,10 @@ func init() {
 	rootCmd.SetOut(os.Stdout)
 	rootCmd.SetErr(os.Stderr)
 	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError{err: err}
+	})
+	rootCmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "Emit machine-readable JSON (same as --format json)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "", "Output format (json|ndjson|table|plain; dot|mermaid for graph)")
+	rootCmd.PersistentFlags().StringVar(&actorFlag, "as", "", "Actor identity recorded for claims, notes and changes (default: $FAZ_AGENT, then session:$FAZ_SESSION_ID, then git user.name, then $USER)")
 }
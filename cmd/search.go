@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchType     string
+	searchStatus   string
+	searchPriority int
+	searchParent   string
+	searchAll      bool
+	searchLabels   []string
+	searchMatch    string
+	searchLimit    int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search \"query\"",
+	Short: "Full-text search over titles, descriptions and notes",
+	Long:  "Search ranks issues whose title, description or work-log notes contain every query term (terms match as prefixes). Closed issues are skipped unless --all or --status is set.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		filter := model.ListFilter{
+			Type:       searchType,
+			Status:     searchStatus,
+			All:        searchAll,
+			Labels:     searchLabels,
+			LabelMatch: searchMatch,
+		}
+		if cmd.Flags().Changed("priority") {
+			filter.Priority = &searchPriority
+		}
+		if cmd.Flags().Changed("parent") {
+			normalized, err := parseIDs([]string{searchParent})
+			if err != nil {
+				return err
+			}
+			filter.ParentID = normalized[0]
+		}
+
+		results, err := svc.Search(strings.Join(args, " "), filter, searchLimit)
+		if err != nil {
+			return err
+		}
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "search_results", results)
+		}
+		printSearchResults(cmd.OutOrStdout(), results, currentOutputFormat() == formatDefault)
+		return nil
+	},
+}
+
+// printSearchResults writes ranked matches with their excerpts, highlighting hits when color is on.
+func printSearchResults(writer io.Writer, results []model.SearchResult, color bool) {
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(writer, "No matches found")
+		return
+	}
+	for _, result := range results {
+		issue := result.Issue
+		_, _ = fmt.Fprintf(writer, "%s %s [P%d] [%s] - %s%s\n", statusSymbol(issue.Status), issue.ID, issue.Priority, issue.Type, issue.Title, ownerSuffix(issue))
+		snippet := strings.Join(strings.Fields(result.Snippet), " ")
+		if snippet == "" {
+			continue
+		}
+		if color {
+			snippet = highlightSnippet(snippet)
+		}
+		_, _ = fmt.Fprintf(writer, "    %s\n", snippet)
+	}
+}
+
+// highlightSnippet swaps search highlight markers for ANSI emphasis.
+func highlightSnippet(snippet string) string {
+	var b strings.Builder
+	open := false
+	for {
+		marker := model.SearchHighlightStart
+		if open {
+			marker = model.SearchHighlightEnd
+		}
+		index := strings.Index(snippet, marker)
+		if index < 0 {
+			b.WriteString(snippet)
+			break
+		}
+		b.WriteString(snippet[:index])
+		if open {
+			b.WriteString(ansiReset)
+		} else {
+			b.WriteString(ansiBold + ansiYellow)
+		}
+		open = !open
+		snippet = snippet[index+len(marker):]
+	}
+	if open {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
+
+// init wires command flags and registration.
+func init() {
+	searchCmd.Flags().StringVar(&searchType, "type", "", "Filter by issue type")
+	searchCmd.Flags().StringVar(&searchStatus, "status", "", "Filter by issue status")
+	searchCmd.Flags().IntVar(&searchPriority, "priority", 2, "Filter by priority (0-3)")
+	searchCmd.Flags().StringVar(&searchParent, "parent", "", "Filter by parent ID")
+	searchCmd.Flags().BoolVar(&searchAll, "all", false, "Include closed issues")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Maximum number of matches (0 shows all)")
+	addLabelFilterFlags(searchCmd, &searchLabels, &searchMatch)
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/api"
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the task store as a local HTTP JSON API",
+	Long:  "Serve exposes list, show, create, update, close, reopen, claim, dependency, children and ready operations as REST endpoints under /v1. When .faz/serve.json sets a token, every request must send it as a bearer token.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		projectDir, err := currentProjectDir()
+		if err != nil {
+			return err
+		}
+		config, err := api.LoadConfig(filepath.Join(projectDir, db.DirName, api.ConfigFileName))
+		if err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		effective, err := projectConfig(projectDir)
+		if err != nil {
+			return err
+		}
+		apiServer := api.NewServer(sqlDB, filepath.Base(projectDir), resolveActor(), config.Token)
+		apiServer.SetSettings(svc.Settings())
+		if host, _, err := net.SplitHostPort(serveAddr); err == nil && host != "" {
+			apiServer.AllowHost(host)
+		}
+		if runner := projectHooks(effective.Config.Hooks, projectDir, os.Stderr); runner != nil {
+			apiServer.SetHooks(runner)
+		}
+		server := &http.Server{
+			Handler:           apiServer.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		stdoutPrintf(cmd, "Serving faz API on http://%s/v1\n", listener.Addr())
+		if config.Token == "" {
+			stdoutPrintf(cmd, "  Auth: none (set \"token\" in %s to require a bearer token)\n", filepath.Join(db.DirName, api.ConfigFileName))
+		} else {
+			stdoutPrintln(cmd, "  Auth: bearer token")
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go sweepLeasesEvery(ctx, svc)
+		go autoExportWhileRunning(ctx, svc)
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// init wires command flags and registration.
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7878", "Listen address")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// envSessionID names the variable that gives one agent session its own actor identity.
+const envSessionID = "FAZ_SESSION_ID"
+
+// envClaudeEnvFile is the file Claude Code sources before each command of a session.
+const envClaudeEnvFile = "CLAUDE_ENV_FILE"
+
+var sessionIDRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+
+var sessionStartCmd = &cobra.Command{
+	Use:   "session-start",
+	Short: "Give the agent session its own FAZ_SESSION_ID (SessionStart hook)",
+	Long:  "Session-start reads the SessionStart hook payload on stdin and, when the agent provides CLAUDE_ENV_FILE, exports the payload's session_id as FAZ_SESSION_ID for every later command of that session. Parallel agents then claim, renew and release as distinct actors instead of sharing git user.name. `faz install` registers it; without CLAUDE_ENV_FILE it does nothing.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		envFile := os.Getenv(envClaudeEnvFile)
+		if envFile == "" {
+			return nil
+		}
+		return writeSessionEnv(envFile, cmd.InOrStdin())
+	},
+}
+
+// writeSessionEnv appends an export of the payload's session_id to envFile.
+// A payload without a usable session_id is ignored so session start never fails on it.
+func writeSessionEnv(envFile string, payload io.Reader) error {
+	var hook struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.NewDecoder(payload).Decode(&hook); err != nil {
+		return nil
+	}
+	sessionID := strings.TrimSpace(hook.SessionID)
+	if !sessionIDRegex.MatchString(sessionID) {
+		return nil
+	}
+
+	file, err := os.OpenFile(envFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", envClaudeEnvFile, err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := fmt.Fprintf(file, "export %s=%s\n", envSessionID, sessionID); err != nil {
+		return fmt.Errorf("write %s: %w", envClaudeEnvFile, err)
+	}
+	return nil
+}
+
+// ensureSessionID gives this process its own FAZ_SESSION_ID when no identity was
+// set, so agents served by separate long-running processes do not share the
+// git user.name fallback. Hooks started by the process inherit it.
+func ensureSessionID(prefix string) error {
+	if strings.TrimSpace(actorFlag) != "" || strings.TrimSpace(os.Getenv("FAZ_AGENT")) != "" || strings.TrimSpace(os.Getenv(envSessionID)) != "" {
+		return nil
+	}
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("generate session id: %w", err)
+	}
+	return os.Setenv(envSessionID, prefix+"-"+hex.EncodeToString(suffix))
+}
+
+// init wires command registration.
+func init() {
+	rootCmd.AddCommand(sessionStartCmd)
+}
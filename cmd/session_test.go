@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWriteSessionEnvExportsSessionID verifies the SessionStart hook hands each session its own identity.
+func TestWriteSessionEnvExportsSessionID(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), "env.sh")
+	if err := writeSessionEnv(envFile, strings.NewReader(`{"session_id":"4f1c-9a2b","hook_event_name":"SessionStart"}`)); err != nil {
+		t.Fatalf("write session env: %v", err)
+	}
+	for _, payload := range []string{`not json`, `{"session_id":"a b; rm -rf /"}`, `{}`} {
+		if err := writeSessionEnv(envFile, strings.NewReader(payload)); err != nil {
+			t.Fatalf("payload %q should be ignored, got %v", payload, err)
+		}
+	}
+
+	data, err := os.ReadFile(envFile)
+	if err != nil {
+		t.Fatalf("read env file: %v", err)
+	}
+	if string(data) != "export FAZ_SESSION_ID=4f1c-9a2b\n" {
+		t.Fatalf("unexpected env file: %q", data)
+	}
+}
+
+// TestEnsureSessionIDKeepsExplicitIdentity verifies long-running servers only invent an identity when none is set.
+func TestEnsureSessionIDKeepsExplicitIdentity(t *testing.T) {
+	t.Setenv("FAZ_AGENT", "")
+	t.Setenv(envSessionID, "")
+	if err := ensureSessionID("mcp"); err != nil {
+		t.Fatalf("ensure session id: %v", err)
+	}
+	first := os.Getenv(envSessionID)
+	if !strings.HasPrefix(first, "mcp-") {
+		t.Fatalf("expected a generated mcp session id, got %q", first)
+	}
+	if err := ensureSessionID("mcp"); err != nil {
+		t.Fatalf("ensure session id again: %v", err)
+	}
+	if got := os.Getenv(envSessionID); got != first {
+		t.Fatalf("session id changed from %q to %q", first, got)
+	}
+	if actor := resolveActor(); actor != "session:"+first {
+		t.Fatalf("actor = %q, want session:%s", actor, first)
+	}
+}
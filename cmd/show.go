@@ -27,11 +27,53 @@ var showCmd = &cobra.Command{
 			return err
 		}
 
+		children, err := svc.Children(issue.ID)
+		if err != nil {
+			return err
+		}
+		deps, err := svc.Dependencies(issue.ID)
+		if err != nil {
+			return err
+		}
+		dependents, err := svc.Dependents(issue.ID)
+		if err != nil {
+			return err
+		}
+		comments, err := svc.Comments(issue.ID, showCommentLimit)
+		if err != nil {
+			return err
+		}
+
+		if structuredOutput() {
+			return writeStructured(cmd.OutOrStdout(), "issue_detail", issueDetail{
+				Issue:        issue,
+				Children:     children,
+				Dependencies: deps,
+				Dependents:   dependents,
+				Comments:     comments,
+			})
+		}
+
 		stdoutPrintf(cmd, "ID: %s\n", issue.ID)
 		stdoutPrintf(cmd, "Title: %s\n", issue.Title)
 		stdoutPrintf(cmd, "Type: %s\n", issue.Type)
 		stdoutPrintf(cmd, "Priority: P%d\n", issue.Priority)
 		stdoutPrintf(cmd, "Status: %s\n", issue.Status)
+		if issue.Estimate != nil {
+			stdoutPrintf(cmd, "Estimate: %d\n", *issue.Estimate)
+		}
+		if len(issue.Labels) > 0 {
+			stdoutPrintf(cmd, "Labels: %s\n", strings.Join(issue.Labels, ", "))
+		}
+		if issue.ClaimedBy != nil {
+			stdoutPrintf(cmd, "Claimed by: %s\n", *issue.ClaimedBy)
+		}
+		if issue.ClaimBranch != nil {
+			stdoutPrintf(cmd, "Claim branch: %s\n", *issue.ClaimBranch)
+		}
+		if issue.ClaimWorktree != nil {
+			stdoutPrintf(cmd, "Claim worktree: %s\n", *issue.ClaimWorktree)
+		}
 		if issue.ClaimedAt != nil {
 			stdoutPrintf(cmd, "Claimed at: %s\n", issue.ClaimedAt.Format("2006-01-02 15:04:05"))
 		}
@@ -52,19 +94,6 @@ var showCmd = &cobra.Command{
 			stdoutPrintln(cmd, issue.Description)
 		}
 
-		children, err := svc.Children(issue.ID)
-		if err != nil {
-			return err
-		}
-		deps, err := svc.Dependencies(issue.ID)
-		if err != nil {
-			return err
-		}
-		dependents, err := svc.Dependents(issue.ID)
-		if err != nil {
-			return err
-		}
-
 		stdoutPrintln(cmd)
 		stdoutPrintln(cmd, "Children:")
 		if len(children) == 0 {
@@ -93,6 +122,13 @@ var showCmd = &cobra.Command{
 			}
 		}
 
+		stdoutPrintf(cmd, "Notes (latest %d):\n", showCommentLimit)
+		if len(comments) == 0 {
+			stdoutPrintln(cmd, "  none")
+		} else {
+			printComments(cmd.OutOrStdout(), "  ", comments)
+		}
+
 		return nil
 	},
 }
@@ -14,6 +14,8 @@ var (
 	updateStatus      string
 	updateParent      string
 	clearParent       bool
+	updateEstimate    int
+	clearEstimate     bool
 )
 
 var updateCmd = &cobra.Command{
@@ -48,6 +50,11 @@ var updateCmd = &cobra.Command{
 		if cmd.Flags().Changed("status") {
 			fields["status"] = updateStatus
 		}
+		if clearEstimate {
+			fields["estimate"] = (*int)(nil)
+		} else if cmd.Flags().Changed("estimate") {
+			fields["estimate"] = &updateEstimate
+		}
 		if clearParent {
 			fields["parent_public_id"] = (*string)(nil)
 		} else if strings.TrimSpace(updateParent) != "" {
@@ -62,6 +69,14 @@ var updateCmd = &cobra.Command{
 			return err
 		}
 
+		if structuredOutput() {
+			issue, err := svc.Get(ids[0])
+			if err != nil {
+				return err
+			}
+			return writeStructured(cmd.OutOrStdout(), "issue", issue)
+		}
+
 		stdoutPrintf(cmd, "Updated issue: %s\n", ids[0])
 		stdoutPrintf(cmd, "  Status: updated\n")
 		return nil
@@ -77,5 +92,7 @@ func init() {
 	updateCmd.Flags().StringVar(&updateStatus, "status", "", "Updated status (open|closed). Use faz claim for in_progress")
 	updateCmd.Flags().StringVar(&updateParent, "parent", "", "Updated parent issue ID")
 	updateCmd.Flags().BoolVar(&clearParent, "clear-parent", false, "Remove parent link")
+	updateCmd.Flags().IntVar(&updateEstimate, "estimate", 0, "Updated effort estimate")
+	updateCmd.Flags().BoolVar(&clearEstimate, "clear-estimate", false, "Remove the effort estimate")
 	rootCmd.AddCommand(updateCmd)
 }
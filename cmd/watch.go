@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchSince  int64
+	watchNDJSON bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream change-log events as issues change",
+	Long:  "Watch tails the change log and prints one event per mutation (created, updated, claimed, released, closed, reopened, deleted, dependency and label changes, comments). Each event's id is its sequence number; pass the last one seen to --since to resume without gaps. Without --since only changes made after watch starts are printed.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, sqlDB, err := openService()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sqlDB.Close() }()
+
+		cursor := watchSince
+		if !cmd.Flags().Changed("since") {
+			cursor, err = svc.LatestChange()
+			if err != nil {
+				return err
+			}
+		}
+
+		ndjson := watchNDJSON || structuredOutput()
+		out := cmd.OutOrStdout()
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return followChanges(ctx, svc, cursor, model.ChangePollInterval, func(events []model.IssueEvent) error {
+			for _, event := range events {
+				if ndjson {
+					if err := encodeEnvelope(out, jsonEnvelope{SchemaVersion: jsonSchemaVersion, Kind: "event", Data: event}, false); err != nil {
+						return err
+					}
+					continue
+				}
+				printChangeEvent(out, event)
+			}
+			return nil
+		})
+	},
+}
+
+// followChanges polls the change log after cursor and hands each new batch to apply until ctx ends.
+func followChanges(ctx context.Context, svc *service.IssueService, cursor int64, interval time.Duration, apply func([]model.IssueEvent) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		events, err := svc.Changes(cursor, model.ChangeBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			if err := apply(events); err != nil {
+				return err
+			}
+			cursor = events[len(events)-1].ID
+			if len(events) == model.ChangeBatchSize {
+				continue
+			}
+		} else if latest, err := svc.LatestChange(); err == nil && latest < cursor {
+			// A restore rewound the change log; follow it from its new end.
+			cursor = latest
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printChangeEvent writes one change-log event as a single human-readable line.
+func printChangeEvent(writer io.Writer, event model.IssueEvent) {
+	line := fmt.Sprintf("#%d  %s  %s  %s  %s", event.ID, event.CreatedAt.Local().Format("2006-01-02 15:04:05"), event.IssueID, event.Actor, event.Action)
+	if change := describeEventChange(event); change != "" {
+		line += "  " + change
+	}
+	_, _ = fmt.Fprintln(writer, line)
+}
+
+// init wires command flags and registration.
+func init() {
+	watchCmd.Flags().Int64Var(&watchSince, "since", 0, "Replay events after this sequence number before following (default: only new events)")
+	watchCmd.Flags().BoolVar(&watchNDJSON, "ndjson", false, "Emit one JSON event envelope per line")
+	rootCmd.AddCommand(watchCmd)
+}
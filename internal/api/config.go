@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConfigFileName is the optional server config stored next to the task database.
+const ConfigFileName = "serve.json"
+
+// Config holds optional settings for `faz serve`.
+type Config struct {
+	Token string `json:"token"`
+}
+
+// LoadConfig reads the server config at path; a missing file yields an empty config.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("read server config: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("parse server config %s: %w", path, err)
+	}
+	config.Token = strings.TrimSpace(config.Token)
+	return config, nil
+}
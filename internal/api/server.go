@@ -0,0 +1,524 @@
+package api
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// ActorHeader lets a client name the actor recorded for its claims and changes.
+const ActorHeader = "X-Faz-Actor"
+
+// maxBodyBytes caps request bodies; issue payloads are small.
+const maxBodyBytes = 1 << 20
+
+// Server exposes the issue service as a local JSON HTTP API.
+type Server struct {
+	db          *sql.DB
+	projectName string
+	actor       string
+	token       string
+	hooks       service.HookRunner
+	settings    service.Settings
+	hosts       map[string]struct{}
+}
+
+// errorBody is the JSON payload returned for failed requests.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+// errorDetail mirrors the CLI error codes so clients can share handling.
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// createRequest is the body accepted by POST /v1/issues.
+type createRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Priority    *int     `json:"priority"`
+	ParentID    *string  `json:"parent_id"`
+	Labels      []string `json:"labels"`
+	Estimate    *int     `json:"estimate"`
+}
+
+// updateRequest is the body accepted by PATCH /v1/issues/{id}.
+// Nullable fields stay raw so an explicit null can clear them.
+type updateRequest struct {
+	Title       *string         `json:"title"`
+	Description *string         `json:"description"`
+	Type        *string         `json:"type"`
+	Priority    *int            `json:"priority"`
+	Status      *string         `json:"status"`
+	ParentID    json.RawMessage `json:"parent_id"`
+	Estimate    json.RawMessage `json:"estimate"`
+}
+
+// claimRequest is the optional body accepted by POST /v1/issues/{id}/claim.
+type claimRequest struct {
+	TTL string `json:"ttl"`
+}
+
+// NewServer builds an API server over an open project database.
+// Requests without an actor header are recorded as actor; an empty token disables auth.
+func NewServer(sqlDB *sql.DB, projectName, actor, token string) *Server {
+	return &Server{db: sqlDB, projectName: projectName, actor: actor, token: token, settings: service.DefaultSettings()}
+}
+
+// SetSettings applies project defaults, ID prefix and extra types to every request.
+// The settings must already be validated, as done by IssueService.Configure.
+func (s *Server) SetSettings(settings service.Settings) {
+	s.settings = settings
+}
+
+// AllowHost accepts requests whose Host header names host, such as the
+// hostname the server was bound to. IP literals and localhost are always accepted.
+func (s *Server) AllowHost(host string) {
+	if s.hosts == nil {
+		s.hosts = make(map[string]struct{})
+	}
+	s.hosts[strings.ToLower(host)] = struct{}{}
+}
+
+// SetHooks runs lifecycle hooks for mutations made through the API.
+func (s *Server) SetHooks(runner service.HookRunner) {
+	s.hooks = runner
+}
+
+// Handler returns the routed HTTP handler for the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/issues", s.handleList)
+	mux.HandleFunc("POST /v1/issues", s.handleCreate)
+	mux.HandleFunc("GET /v1/issues/{id}", s.handleGet)
+	mux.HandleFunc("PATCH /v1/issues/{id}", s.handleUpdate)
+	mux.HandleFunc("POST /v1/issues/{id}/close", s.handleClose)
+	mux.HandleFunc("POST /v1/issues/{id}/reopen", s.handleReopen)
+	mux.HandleFunc("POST /v1/issues/{id}/claim", s.handleClaim)
+	mux.HandleFunc("GET /v1/issues/{id}/dependencies", s.handleDependencies)
+	mux.HandleFunc("GET /v1/issues/{id}/dependents", s.handleDependents)
+	mux.HandleFunc("GET /v1/issues/{id}/children", s.handleChildren)
+	mux.HandleFunc("GET /v1/ready", s.handleReady)
+	return s.guard(s.authenticate(mux))
+}
+
+// guard rejects requests a web page could forge against a local server: a
+// Host header naming another site (DNS rebinding) and mutations without a
+// JSON content type (cross-origin "simple" requests skip CORS preflight).
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, "forbidden_host", fmt.Sprintf("host %q is not allowed", r.Host))
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "requests that change issues must send Content-Type: application/json")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether a Host header names this server rather than a
+// name an attacker could point at it.
+func (s *Server) allowedHost(hostport string) bool {
+	host := hostport
+	if split, _, err := net.SplitHostPort(hostport); err == nil {
+		host = split
+	}
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
+	if host == "" {
+		return false
+	}
+	if host == "localhost" || net.ParseIP(host) != nil {
+		return true
+	}
+	_, ok := s.hosts[host]
+	return ok
+}
+
+// authenticate rejects requests without the configured bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if s.token == "" {
+		return next
+	}
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="faz"`)
+			writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// service builds a request-scoped issue service so each client records its own actor.
+func (s *Server) service(r *http.Request) *service.IssueService {
+	issueRepo := repo.NewIssueRepo(s.db)
+	actor := strings.TrimSpace(r.Header.Get(ActorHeader))
+	if actor == "" {
+		actor = s.actor
+	}
+	issueRepo.SetActor(actor)
+	svc := service.NewIssueService(issueRepo, s.projectName)
+	_ = svc.Configure(s.settings)
+	if s.hooks != nil {
+		svc.SetHooks(s.hooks)
+	}
+	return svc
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	issues, err := s.service(r).List(filter)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	issues, err := s.service(r).Ready(filter)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var body createRequest
+	if !decodeBody(w, r, &body, false) {
+		return
+	}
+	issue := model.Issue{
+		Title:       body.Title,
+		Description: strings.TrimSpace(body.Description),
+		Type:        body.Type,
+		Priority:    s.settings.DefaultPriority,
+		Status:      "open",
+		Labels:      body.Labels,
+		Estimate:    body.Estimate,
+	}
+	if issue.Type == "" {
+		issue.Type = s.settings.DefaultType
+	}
+	if body.Priority != nil {
+		issue.Priority = *body.Priority
+	}
+	if body.ParentID != nil && strings.TrimSpace(*body.ParentID) != "" {
+		parentID, err := service.NormalizeIssueID(*body.ParentID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		issue.ParentID = &parentID
+	}
+
+	svc := s.service(r)
+	id, err := svc.Create(issue)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeIssue(w, svc, id, http.StatusCreated)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	s.writeIssue(w, s.service(r), id, http.StatusOK)
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var body updateRequest
+	if !decodeBody(w, r, &body, false) {
+		return
+	}
+	fields, err := body.fields()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	svc := s.service(r)
+	if err := svc.Update(id, fields); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeIssue(w, svc, id, http.StatusOK)
+}
+
+func (s *Server) handleClose(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	svc := s.service(r)
+	if err := svc.Close(id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeIssue(w, svc, id, http.StatusOK)
+}
+
+func (s *Server) handleReopen(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	svc := s.service(r)
+	if err := svc.Reopen(id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeIssue(w, svc, id, http.StatusOK)
+}
+
+func (s *Server) handleClaim(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var body claimRequest
+	if !decodeBody(w, r, &body, true) {
+		return
+	}
+	ttl := s.settings.DefaultClaimTTL
+	if body.TTL != "" {
+		parsed, err := time.ParseDuration(body.TTL)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid ttl %q", body.TTL))
+			return
+		}
+		ttl = parsed
+	}
+
+	svc := s.service(r)
+	if err := svc.Claim(id, ttl); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	s.writeIssue(w, svc, id, http.StatusOK)
+}
+
+func (s *Server) handleDependencies(w http.ResponseWriter, r *http.Request) {
+	s.writeRelated(w, r, (*service.IssueService).Dependencies)
+}
+
+func (s *Server) handleDependents(w http.ResponseWriter, r *http.Request) {
+	s.writeRelated(w, r, (*service.IssueService).Dependents)
+}
+
+func (s *Server) handleChildren(w http.ResponseWriter, r *http.Request) {
+	s.writeRelated(w, r, (*service.IssueService).Children)
+}
+
+// writeRelated serves an issue list derived from the path issue, 404ing when the issue is missing.
+func (s *Server) writeRelated(w http.ResponseWriter, r *http.Request, load func(*service.IssueService, string) ([]model.Issue, error)) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	svc := s.service(r)
+	if _, err := svc.Get(id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	issues, err := load(svc, id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+// writeIssue reloads an issue after a change and writes it with status.
+func (s *Server) writeIssue(w http.ResponseWriter, svc *service.IssueService, id string, status int) {
+	issue, err := svc.Get(id)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, status, issue)
+}
+
+// fields converts a PATCH body into the map accepted by IssueService.Update.
+func (u updateRequest) fields() (map[string]any, error) {
+	fields := make(map[string]any)
+	if u.Title != nil {
+		fields["title"] = *u.Title
+	}
+	if u.Description != nil {
+		fields["description"] = strings.TrimSpace(*u.Description)
+	}
+	if u.Type != nil {
+		fields["type"] = *u.Type
+	}
+	if u.Priority != nil {
+		fields["priority"] = *u.Priority
+	}
+	if u.Status != nil {
+		fields["status"] = *u.Status
+	}
+	if len(u.ParentID) > 0 {
+		var parent *string
+		if err := json.Unmarshal(u.ParentID, &parent); err != nil {
+			return nil, fmt.Errorf("parent_id must be a string or null")
+		}
+		if parent != nil {
+			normalized, err := service.NormalizeIssueID(*parent)
+			if err != nil {
+				return nil, err
+			}
+			parent = &normalized
+		}
+		fields["parent_public_id"] = parent
+	}
+	if len(u.Estimate) > 0 {
+		var estimate *int
+		if err := json.Unmarshal(u.Estimate, &estimate); err != nil {
+			return nil, fmt.Errorf("estimate must be an integer or null")
+		}
+		fields["estimate"] = estimate
+	}
+	return fields, nil
+}
+
+// parseListFilter reads list and ready filters from query parameters.
+func parseListFilter(r *http.Request) (model.ListFilter, error) {
+	query := r.URL.Query()
+	filter := model.ListFilter{
+		Type:       query.Get("type"),
+		Status:     query.Get("status"),
+		LabelMatch: query.Get("label_match"),
+	}
+	if raw := query.Get("all"); raw != "" {
+		all, err := strconv.ParseBool(raw)
+		if err != nil {
+			return model.ListFilter{}, fmt.Errorf("invalid all value %q", raw)
+		}
+		filter.All = all
+	}
+	if raw := query.Get("priority"); raw != "" {
+		priority, err := strconv.Atoi(raw)
+		if err != nil {
+			return model.ListFilter{}, fmt.Errorf("invalid priority %q", raw)
+		}
+		filter.Priority = &priority
+	}
+	if raw := query.Get("parent"); raw != "" {
+		parentID, err := service.NormalizeIssueID(raw)
+		if err != nil {
+			return model.ListFilter{}, err
+		}
+		filter.ParentID = parentID
+	}
+	for _, value := range query["label"] {
+		filter.Labels = append(filter.Labels, strings.Split(value, ",")...)
+	}
+	return filter, nil
+}
+
+// pathID normalizes the {id} path segment, writing a 400 when it is malformed.
+func pathID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id, err := service.NormalizeIssueID(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return "", false
+	}
+	return id, true
+}
+
+// decodeBody parses a JSON request body into target, optionally allowing it to be empty.
+func decodeBody(w http.ResponseWriter, r *http.Request, target any, optional bool) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		if optional && errors.Is(err, io.EOF) {
+			return true
+		}
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+// writeServiceError maps service and repository failures onto HTTP status codes.
+// Unrecognized errors are storage or I/O failures, reported as 500.
+func writeServiceError(w http.ResponseWriter, err error) {
+	status, code := http.StatusInternalServerError, "internal_error"
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		status, code = http.StatusBadRequest, "invalid_request"
+	case errors.Is(err, repo.ErrIssueAlreadyClaimed):
+		status, code = http.StatusConflict, "already_claimed"
+	case errors.Is(err, repo.ErrIssueTypeNotClaimable):
+		status, code = http.StatusUnprocessableEntity, "not_claimable"
+	case errors.Is(err, repo.ErrIssueNotClaimed):
+		status, code = http.StatusConflict, "not_claimed"
+	case errors.Is(err, repo.ErrNotClaimOwner):
+		status, code = http.StatusConflict, "not_owner"
+	case errors.Is(err, repo.ErrIssueClosed):
+		status, code = http.StatusConflict, "closed"
+	case errors.Is(err, repo.ErrDependencyCycle):
+		status, code = http.StatusConflict, "dependency_cycle"
+	case errors.Is(err, service.ErrHookVetoed):
+		status, code = http.StatusConflict, "vetoed"
+	case errors.Is(err, repo.ErrNoReadyIssue):
+		status, code = http.StatusNotFound, "no_ready_issue"
+	case errors.Is(err, repo.ErrIssueNotFound):
+		status, code = http.StatusNotFound, "not_found"
+	}
+	writeError(w, status, code, err.Error())
+}
+
+// writeError writes a JSON error body with status.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, errorBody{Error: errorDetail{Code: code, Message: message}})
+}
+
+// writeJSON encodes payload as the response body with status.
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(payload)
+}
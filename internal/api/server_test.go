@@ -0,0 +1,242 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// newTestServer opens a migrated project database and serves the API over it.
+func newTestServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	dbPath, err := db.EnsureProjectFiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	server := httptest.NewServer(NewServer(sqlDB, "faz", "server", token).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// doJSON sends a request and decodes the JSON response into out when it is non-nil.
+func doJSON(t *testing.T, method, url, body string, headers map[string]string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s %s response: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerIssueLifecycle(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var epic model.Issue
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"Checkout","type":"epic"}`, nil, &epic); status != http.StatusCreated {
+		t.Fatalf("create epic status = %d", status)
+	}
+	var task model.Issue
+	body := `{"title":"Address validation","parent_id":"` + epic.ID + `","labels":["frontend"],"priority":1}`
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", body, nil, &task); status != http.StatusCreated {
+		t.Fatalf("create task status = %d", status)
+	}
+	if task.Type != "task" || task.ParentID == nil || *task.ParentID != epic.ID || task.Priority != 1 {
+		t.Fatalf("unexpected created task: %+v", task)
+	}
+
+	var children []model.Issue
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues/"+epic.ID+"/children", "", nil, &children); status != http.StatusOK || len(children) != 1 {
+		t.Fatalf("children status = %d, children = %+v", status, children)
+	}
+
+	var updated model.Issue
+	if status := doJSON(t, http.MethodPatch, server.URL+"/v1/issues/"+task.ID, `{"title":"Address checks","estimate":3}`, nil, &updated); status != http.StatusOK {
+		t.Fatalf("update status = %d", status)
+	}
+	if updated.Title != "Address checks" || updated.Estimate == nil || *updated.Estimate != 3 {
+		t.Fatalf("unexpected updated task: %+v", updated)
+	}
+	if status := doJSON(t, http.MethodPatch, server.URL+"/v1/issues/"+task.ID, `{"estimate":null}`, nil, &updated); status != http.StatusOK || updated.Estimate != nil {
+		t.Fatalf("clear estimate status = %d, issue = %+v", status, updated)
+	}
+
+	var ready []model.Issue
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/ready?label=frontend", "", nil, &ready); status != http.StatusOK || len(ready) != 1 {
+		t.Fatalf("ready status = %d, ready = %+v", status, ready)
+	}
+
+	var claimed model.Issue
+	headers := map[string]string{ActorHeader: "agent-7"}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/"+task.ID+"/claim", `{"ttl":"30m"}`, headers, &claimed); status != http.StatusOK {
+		t.Fatalf("claim status = %d", status)
+	}
+	if claimed.Status != "in_progress" || claimed.ClaimedBy == nil || *claimed.ClaimedBy != "agent-7" {
+		t.Fatalf("unexpected claimed issue: %+v", claimed)
+	}
+
+	var failure errorBody
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/"+task.ID+"/claim", "", nil, &failure); status != http.StatusConflict || failure.Error.Code != "already_claimed" {
+		t.Fatalf("second claim status = %d, error = %+v", status, failure)
+	}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/"+epic.ID+"/claim", "", nil, &failure); status != http.StatusUnprocessableEntity || failure.Error.Code != "not_claimable" {
+		t.Fatalf("epic claim status = %d, error = %+v", status, failure)
+	}
+
+	var closed model.Issue
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/"+task.ID+"/close", "", nil, &closed); status != http.StatusOK || closed.Status != "closed" {
+		t.Fatalf("close status = %d, issue = %+v", status, closed)
+	}
+	var reopened model.Issue
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/"+task.ID+"/reopen", "", nil, &reopened); status != http.StatusOK || reopened.Status != "open" {
+		t.Fatalf("reopen status = %d, issue = %+v", status, reopened)
+	}
+}
+
+func TestServerReportsRequestErrors(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var failure errorBody
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues/faz-zz99", "", nil, &failure); status != http.StatusNotFound || failure.Error.Code != "not_found" {
+		t.Fatalf("missing issue status = %d, error = %+v", status, failure)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues/not-an-id!", "", nil, &failure); status != http.StatusBadRequest {
+		t.Fatalf("malformed ID status = %d", status)
+	}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"x","type":"saga"}`, nil, &failure); status != http.StatusBadRequest || !strings.Contains(failure.Error.Message, "invalid type") {
+		t.Fatalf("invalid type status = %d, error = %+v", status, failure)
+	}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"x","colour":"red"}`, nil, &failure); status != http.StatusBadRequest {
+		t.Fatalf("unknown field status = %d", status)
+	}
+}
+
+func TestServerRequiresConfiguredBearerToken(t *testing.T) {
+	server := newTestServer(t, "s3cret")
+
+	var failure errorBody
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues", "", nil, &failure); status != http.StatusUnauthorized || failure.Error.Code != "unauthorized" {
+		t.Fatalf("unauthenticated status = %d, error = %+v", status, failure)
+	}
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues", "", map[string]string{"Authorization": "Bearer wrong"}, nil); status != http.StatusUnauthorized {
+		t.Fatalf("wrong token status = %d", status)
+	}
+	var issues []model.Issue
+	if status := doJSON(t, http.MethodGet, server.URL+"/v1/issues", "", map[string]string{"Authorization": "Bearer s3cret"}, &issues); status != http.StatusOK {
+		t.Fatalf("authenticated status = %d", status)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadConfig(filepath.Join(dir, ConfigFileName))
+	if err != nil || config.Token != "" {
+		t.Fatalf("missing config = %+v, %v", config, err)
+	}
+
+	path := filepath.Join(dir, ConfigFileName)
+	if err := os.WriteFile(path, []byte(`{"token": " abc123 "}`), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	config, err = LoadConfig(path)
+	if err != nil || config.Token != "abc123" {
+		t.Fatalf("loaded config = %+v, %v", config, err)
+	}
+}
+
+func TestServerRejectsForgeableRequests(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var failure errorBody
+	headers := map[string]string{"Content-Type": "text/plain"}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"x"}`, headers, &failure); status != http.StatusUnsupportedMediaType || failure.Error.Code != "unsupported_media_type" {
+		t.Fatalf("text/plain create status = %d, error = %+v", status, failure)
+	}
+	headers = map[string]string{"Content-Type": ""}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues/faz-ab12/close", "", headers, &failure); status != http.StatusUnsupportedMediaType {
+		t.Fatalf("close without content type status = %d", status)
+	}
+	headers = map[string]string{"Content-Type": "application/json; charset=utf-8"}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"x"}`, headers, nil); status != http.StatusCreated {
+		t.Fatalf("json with charset status = %d", status)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/ready", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	req.Host = "attacker.example:7878"
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("rebound request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("rebound host status = %d", resp.StatusCode)
+	}
+	req.Host = "localhost:7878"
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("localhost request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("localhost host status = %d", resp.StatusCode)
+	}
+}
+
+func TestServerReportsStorageFailuresAsInternal(t *testing.T) {
+	dbPath, err := db.EnsureProjectFiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+	server := httptest.NewServer(NewServer(sqlDB, "faz", "server", "").Handler())
+	t.Cleanup(server.Close)
+	_ = sqlDB.Close()
+
+	var failure errorBody
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":"x"}`, nil, &failure); status != http.StatusInternalServerError || failure.Error.Code != "internal_error" {
+		t.Fatalf("closed database status = %d, error = %+v", status, failure)
+	}
+	if status := doJSON(t, http.MethodPost, server.URL+"/v1/issues", `{"title":" "}`, nil, &failure); status != http.StatusBadRequest || failure.Error.Code != "invalid_request" {
+		t.Fatalf("empty title status = %d, error = %+v", status, failure)
+	}
+}
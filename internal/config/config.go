@@ -0,0 +1,328 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// FileName is the project configuration file stored in the .faz directory.
+const FileName = "config.toml"
+
+// DefaultHookTimeout bounds a hook that sets no timeout of its own.
+const DefaultHookTimeout = 30 * time.Second
+
+// Config is the configuration read from .faz/config.toml and the global config file.
+type Config struct {
+	Project   Project   `toml:"project"`
+	Defaults  Defaults  `toml:"defaults"`
+	Types     Types     `toml:"types"`
+	Snapshots Snapshots `toml:"snapshots"`
+	Export    Export    `toml:"export"`
+	Worktree  Worktree  `toml:"worktree"`
+	Kanban    Kanban    `toml:"kanban"`
+	Hooks     Hooks     `toml:"hooks"`
+}
+
+// Project holds identity settings for the task store.
+type Project struct {
+	IDPrefix string `toml:"id_prefix"`
+}
+
+// Defaults overrides the values used when commands omit a flag.
+type Defaults struct {
+	Type     string   `toml:"type"`
+	Priority *int     `toml:"priority"`
+	ClaimTTL Duration `toml:"claim_ttl"`
+}
+
+// Types extends the built-in issue type vocabulary.
+type Types struct {
+	Extra []string `toml:"extra"`
+}
+
+// Snapshots controls the automatic copies taken before destructive commands.
+type Snapshots struct {
+	Keep *int `toml:"keep"`
+}
+
+// SnapshotKeep returns how many automatic snapshots to keep; zero disables them.
+func (c Config) SnapshotKeep() int {
+	if c.Snapshots.Keep == nil {
+		return db.DefaultSnapshotKeep
+	}
+	return *c.Snapshots.Keep
+}
+
+// Export controls the JSONL file kept in sync with the task store.
+type Export struct {
+	AutoPath string `toml:"auto_path"`
+}
+
+// Worktree controls how linked Git worktrees find the task store.
+type Worktree struct {
+	ShareStore *bool `toml:"share_store"`
+}
+
+// SharesWorktreeStore reports whether linked worktrees use the main checkout's store.
+func (c Config) SharesWorktreeStore() bool {
+	return c.Worktree.ShareStore == nil || *c.Worktree.ShareStore
+}
+
+// Kanban controls the layout, colors and key bindings of `faz kanban`.
+type Kanban struct {
+	Columns   []string `toml:"columns"`
+	Swimlanes string   `toml:"swimlanes"`
+	Theme     string   `toml:"theme"`
+	// Palettes defines named themes as color overrides; the "base" entry names
+	// the built-in theme a palette starts from.
+	Palettes map[string]map[string]string `toml:"palettes"`
+	// Keys maps board actions to the keys that trigger them.
+	Keys map[string][]string `toml:"keys"`
+}
+
+// KanbanColumns returns the configured board columns, or the default three.
+func (c Config) KanbanColumns() []string {
+	if len(c.Kanban.Columns) == 0 {
+		return model.DefaultKanbanColumns
+	}
+	return c.Kanban.Columns
+}
+
+// KanbanSwimlanes returns the configured swimlane grouping, or none.
+func (c Config) KanbanSwimlanes() string {
+	if c.Kanban.Swimlanes == "" {
+		return model.KanbanSwimlanesNone
+	}
+	return c.Kanban.Swimlanes
+}
+
+// KanbanTheme returns the configured theme name, or the dark theme.
+func (c Config) KanbanTheme() string {
+	if c.Kanban.Theme == "" {
+		return model.KanbanThemeDark
+	}
+	return c.Kanban.Theme
+}
+
+// validate rejects unknown or repeated columns, column sets that leave open
+// issues off the board, unknown swimlane groupings and palettes based on an
+// unknown theme. Color and key names are checked by the board.
+func (k Kanban) validate() error {
+	seen := make(map[string]struct{}, len(k.Columns))
+	for _, column := range k.Columns {
+		if !containsString(model.KanbanColumns, column) {
+			return fmt.Errorf("kanban.columns: unknown column %q (expected %s)", column, strings.Join(model.KanbanColumns, ", "))
+		}
+		if _, ok := seen[column]; ok {
+			return fmt.Errorf("kanban.columns: column %q is listed twice", column)
+		}
+		seen[column] = struct{}{}
+	}
+	// Open issues fall back to todo when blocked or ready is not shown.
+	_, todo := seen[model.KanbanColumnTodo]
+	_, blocked := seen[model.KanbanColumnBlocked]
+	_, ready := seen[model.KanbanColumnReady]
+	if len(k.Columns) > 0 && !todo && !(blocked && ready) {
+		return fmt.Errorf("kanban.columns: open issues have no column (include %q, or both %q and %q)",
+			model.KanbanColumnTodo, model.KanbanColumnBlocked, model.KanbanColumnReady)
+	}
+	if k.Swimlanes != "" && !containsString(model.KanbanSwimlanes, k.Swimlanes) {
+		return fmt.Errorf("kanban.swimlanes: unknown grouping %q (expected %s)", k.Swimlanes, strings.Join(model.KanbanSwimlanes, ", "))
+	}
+	for name, palette := range k.Palettes {
+		if base, ok := palette["base"]; ok && !containsString(model.KanbanThemes, base) {
+			return fmt.Errorf("kanban.palettes.%s: unknown base theme %q (expected %s)", name, base, strings.Join(model.KanbanThemes, ", "))
+		}
+	}
+	return nil
+}
+
+// Hooks lists user commands to run around issue lifecycle transitions.
+type Hooks struct {
+	Timeout        Duration `toml:"timeout"`
+	OnCreate       []Hook   `toml:"on_create"`
+	OnClaim        []Hook   `toml:"on_claim"`
+	PreClose       []Hook   `toml:"pre_close"`
+	OnClose        []Hook   `toml:"on_close"`
+	OnReopen       []Hook   `toml:"on_reopen"`
+	OnLeaseExpired []Hook   `toml:"on_lease_expired"`
+	OnDepAdded     []Hook   `toml:"on_dep_added"`
+}
+
+// Hook is one shell command, optionally limited to some issue types and priorities.
+type Hook struct {
+	Command    string   `toml:"command"`
+	Timeout    Duration `toml:"timeout"`
+	Types      []string `toml:"types"`
+	Priorities []int    `toml:"priorities"`
+}
+
+// Duration is a time.Duration written as a Go duration string such as "90s" or "5m".
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalText parses a duration string.
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(string(text)))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", string(text))
+	}
+	if parsed <= 0 {
+		return fmt.Errorf("duration %q must be greater than zero", string(text))
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// MarshalText writes the duration in Go duration syntax.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Load reads the config at path; a missing file yields an empty config.
+// Unknown keys are rejected so typos in hook names do not silently disable a hook.
+func Load(path string) (Config, error) {
+	config, _, err := load(path)
+	return config, err
+}
+
+// load reads one config file and reports which keys it defined.
+func load(path string) (Config, toml.MetaData, error) {
+	var config Config
+	meta, err := toml.DecodeFile(path, &config)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, toml.MetaData{}, nil
+	}
+	if err != nil {
+		return Config{}, toml.MetaData{}, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return Config{}, toml.MetaData{}, fmt.Errorf("parse config %s: unknown key %q", path, undecoded[0].String())
+	}
+	if err := config.Hooks.validate(); err != nil {
+		return Config{}, toml.MetaData{}, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if err := config.Kanban.validate(); err != nil {
+		return Config{}, toml.MetaData{}, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if config.Snapshots.Keep != nil && *config.Snapshots.Keep < 0 {
+		return Config{}, toml.MetaData{}, fmt.Errorf("parse config %s: snapshots.keep cannot be negative", path)
+	}
+	return config, meta, nil
+}
+
+// For returns the hooks registered for one hook event.
+func (h Hooks) For(event string) []Hook {
+	switch event {
+	case model.HookOnCreate:
+		return h.OnCreate
+	case model.HookOnClaim:
+		return h.OnClaim
+	case model.HookPreClose:
+		return h.PreClose
+	case model.HookOnClose:
+		return h.OnClose
+	case model.HookOnReopen:
+		return h.OnReopen
+	case model.HookOnLeaseExpired:
+		return h.OnLeaseExpired
+	case model.HookOnDepAdded:
+		return h.OnDepAdded
+	default:
+		return nil
+	}
+}
+
+// Empty reports whether no hook of any kind is configured.
+func (h Hooks) Empty() bool {
+	for _, event := range model.HookEvents {
+		if len(h.For(event)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TimeoutFor returns the effective timeout of one hook.
+func (h Hooks) TimeoutFor(hook Hook) time.Duration {
+	switch {
+	case hook.Timeout.Duration > 0:
+		return hook.Timeout.Duration
+	case h.Timeout.Duration > 0:
+		return h.Timeout.Duration
+	default:
+		return DefaultHookTimeout
+	}
+}
+
+// Matches reports whether a hook's type and priority filters accept issue.
+func (hook Hook) Matches(issue model.Issue) bool {
+	if len(hook.Types) > 0 && !containsString(hook.Types, issue.Type) {
+		return false
+	}
+	if len(hook.Priorities) > 0 && !containsInt(hook.Priorities, issue.Priority) {
+		return false
+	}
+	return true
+}
+
+// append adds hooks to the list for event.
+func (h *Hooks) append(event string, hooks []Hook) {
+	if len(hooks) == 0 {
+		return
+	}
+	switch event {
+	case model.HookOnCreate:
+		h.OnCreate = append(h.OnCreate, hooks...)
+	case model.HookOnClaim:
+		h.OnClaim = append(h.OnClaim, hooks...)
+	case model.HookPreClose:
+		h.PreClose = append(h.PreClose, hooks...)
+	case model.HookOnClose:
+		h.OnClose = append(h.OnClose, hooks...)
+	case model.HookOnReopen:
+		h.OnReopen = append(h.OnReopen, hooks...)
+	case model.HookOnLeaseExpired:
+		h.OnLeaseExpired = append(h.OnLeaseExpired, hooks...)
+	case model.HookOnDepAdded:
+		h.OnDepAdded = append(h.OnDepAdded, hooks...)
+	}
+}
+
+// validate rejects hooks without a command.
+func (h Hooks) validate() error {
+	for _, event := range model.HookEvents {
+		for i, hook := range h.For(event) {
+			if strings.TrimSpace(hook.Command) == "" {
+				return fmt.Errorf("hooks.%s[%d]: command is required", event, i)
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, target int) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+func TestLoadParsesHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	content := `
+[hooks]
+timeout = "1m"
+
+[[hooks.pre_close]]
+command = "go test ./..."
+timeout = "5m"
+
+[[hooks.on_create]]
+command = "./notify.sh"
+types = ["bug"]
+priorities = [0]
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	preClose := config.Hooks.For(model.HookPreClose)
+	if len(preClose) != 1 || config.Hooks.TimeoutFor(preClose[0]) != 5*time.Minute {
+		t.Fatalf("unexpected pre_close hooks: %+v", preClose)
+	}
+	onCreate := config.Hooks.For(model.HookOnCreate)
+	if len(onCreate) != 1 || config.Hooks.TimeoutFor(onCreate[0]) != time.Minute {
+		t.Fatalf("unexpected on_create hooks: %+v", onCreate)
+	}
+	if onCreate[0].Matches(model.Issue{Type: "bug", Priority: 1}) || !onCreate[0].Matches(model.Issue{Type: "bug", Priority: 0}) {
+		t.Fatal("expected on_create to match only P0 bugs")
+	}
+	if config.Hooks.Empty() {
+		t.Fatal("expected configured hooks")
+	}
+}
+
+func TestLoadRejectsUnknownKeysAndMissingCommands(t *testing.T) {
+	dir := t.TempDir()
+	if config, err := Load(filepath.Join(dir, FileName)); err != nil || !config.Hooks.Empty() {
+		t.Fatalf("missing config = %+v, %v", config, err)
+	}
+
+	for name, content := range map[string]string{
+		"typo":    "[[hooks.on_closed]]\ncommand = \"true\"\n",
+		"command": "[[hooks.on_close]]\ntimeout = \"1s\"\n",
+		"timeout": "[hooks]\ntimeout = \"soon\"\n",
+		"column":  "[kanban]\ncolumns = [\"todo\", \"later\"]\n",
+		"repeat":  "[kanban]\ncolumns = [\"done\", \"done\"]\n",
+		"lanes":   "[kanban]\nswimlanes = \"owner\"\n",
+		"palette": "[kanban.palettes.solar]\nbase = \"sepia\"\n",
+	} {
+		path := filepath.Join(dir, name+".toml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s config: %v", name, err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "parse config") {
+			t.Fatalf("%s: expected parse error, got %v", name, err)
+		}
+	}
+}
+
+func TestKanbanLayoutDefaultsAndSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	globalPath := filepath.Join(t.TempDir(), "global", FileName)
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("load missing config: %v", err)
+	}
+	if got := strings.Join(config.KanbanColumns(), ","); got != "todo,claimed,done" || config.KanbanSwimlanes() != "none" {
+		t.Fatalf("unexpected default layout: %s / %s", got, config.KanbanSwimlanes())
+	}
+
+	if err := Set(globalPath, path, false, "kanban.columns", "Blocked, ready,claimed,done"); err != nil {
+		t.Fatalf("set kanban.columns: %v", err)
+	}
+	if err := Set(globalPath, path, false, "kanban.swimlanes", "label"); err != nil {
+		t.Fatalf("set kanban.swimlanes: %v", err)
+	}
+	if err := Set(globalPath, path, false, "kanban.columns", "todo,backlog"); err == nil {
+		t.Fatal("expected unknown column to be rejected")
+	}
+	for _, columns := range []string{"blocked,claimed,done", "claimed,done"} {
+		if err := Set(globalPath, path, false, "kanban.columns", columns); err == nil || !strings.Contains(err.Error(), "open issues have no column") {
+			t.Fatalf("expected %q to be rejected for hiding open issues, got %v", columns, err)
+		}
+	}
+	config, err = Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := strings.Join(config.KanbanColumns(), ","); got != "blocked,ready,claimed,done" || config.KanbanSwimlanes() != "label" {
+		t.Fatalf("unexpected layout: %s / %s", got, config.KanbanSwimlanes())
+	}
+}
+
+func TestLoadEffectiveMergesGlobalAndProject(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "global.toml")
+	projectPath := filepath.Join(dir, "project.toml")
+	global := "[defaults]\npriority = 1\nclaim_ttl = \"30m\"\n\n[kanban.keys]\nclaim = [\"C\"]\nquit = [\"Q\"]\n\n[kanban.palettes.solar]\nbase = \"light\"\n\n[[hooks.on_close]]\ncommand = \"./global.sh\"\n"
+	project := "[defaults]\npriority = 0\n\n[types]\nextra = [\"spike\"]\n\n[kanban]\ntheme = \"solar\"\n\n[kanban.keys]\nquit = [\"x\"]\n\n[[hooks.on_close]]\ncommand = \"./project.sh\"\n"
+	if err := os.WriteFile(globalPath, []byte(global), 0o644); err != nil {
+		t.Fatalf("write global config: %v", err)
+	}
+	if err := os.WriteFile(projectPath, []byte(project), 0o644); err != nil {
+		t.Fatalf("write project config: %v", err)
+	}
+
+	effective, err := LoadEffective(globalPath, projectPath)
+	if err != nil {
+		t.Fatalf("load effective config: %v", err)
+	}
+	settings := effective.Config.Settings()
+	if settings.DefaultPriority != 0 || settings.DefaultClaimTTL != 30*time.Minute || settings.DefaultType != "task" {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	for key, want := range map[string]string{
+		"defaults.priority":  SourceProject,
+		"defaults.claim_ttl": SourceGlobal,
+		"defaults.type":      SourceDefault,
+		"types.extra":        SourceProject,
+	} {
+		if got := effective.Sources[key]; got != want {
+			t.Fatalf("source of %s = %q, want %q", key, got, want)
+		}
+	}
+	if value, err := effective.Get("defaults.type"); err != nil || value != "task" {
+		t.Fatalf("defaults.type = %q, %v", value, err)
+	}
+	onClose := effective.Config.Hooks.For(model.HookOnClose)
+	if len(onClose) != 2 || onClose[0].Command != "./global.sh" || onClose[1].Command != "./project.sh" {
+		t.Fatalf("unexpected merged hooks: %+v", onClose)
+	}
+	kanban := effective.Config.Kanban
+	if effective.Config.KanbanTheme() != "solar" || kanban.Palettes["solar"]["base"] != "light" {
+		t.Fatalf("unexpected kanban theme: %q %+v", effective.Config.KanbanTheme(), kanban.Palettes)
+	}
+	if len(kanban.Keys) != 2 || kanban.Keys["claim"][0] != "C" || kanban.Keys["quit"][0] != "x" {
+		t.Fatalf("unexpected merged kanban keys: %+v", kanban.Keys)
+	}
+}
+
+func TestSetWritesKeysAndRejectsInvalidValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "faz", FileName)
+	globalPath := filepath.Join(t.TempDir(), "global", FileName)
+	if err := Set(globalPath, path, false, "defaults.claim_ttl", "45m"); err != nil {
+		t.Fatalf("set claim_ttl: %v", err)
+	}
+	if err := Set(globalPath, path, false, "types.extra", "spike, research"); err != nil {
+		t.Fatalf("set types.extra: %v", err)
+	}
+	if err := Set(globalPath, path, false, "defaults.type", "spike"); err != nil {
+		t.Fatalf("set defaults.type: %v", err)
+	}
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	settings := config.Settings()
+	if settings.DefaultClaimTTL != 45*time.Minute || settings.DefaultType != "spike" || len(settings.ExtraTypes) != 2 {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for key, value := range map[string]string{
+		"defaults.priority":  "9",
+		"defaults.type":      "unknown",
+		"project.id_prefix":  "Bad Prefix",
+		"defaults.claim_ttl": "soon",
+		"hooks.on_close":     "true",
+	} {
+		if err := Set(globalPath, path, false, key, value); err == nil {
+			t.Fatalf("%s=%s: expected error", key, value)
+		}
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Fatalf("rejected values changed the file:\n%s", after)
+	}
+}
+
+func TestSetValidatesMergedGlobalAndProject(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "global", FileName)
+	projectPath := filepath.Join(dir, "project", FileName)
+	if err := Set(globalPath, projectPath, true, "types.extra", "spike"); err != nil {
+		t.Fatalf("set global types.extra: %v", err)
+	}
+	if err := Set(globalPath, projectPath, true, "defaults.type", "spike"); err != nil {
+		t.Fatalf("set global defaults.type: %v", err)
+	}
+
+	// The project list replaces the global one, which would orphan the global default type.
+	if err := Set(globalPath, projectPath, false, "types.extra", "research"); err == nil || !strings.Contains(err.Error(), "spike") {
+		t.Fatalf("expected the merged default type to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(projectPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the rejected project file to be removed, stat = %v", err)
+	}
+	effective, err := LoadEffective(globalPath, projectPath)
+	if err != nil {
+		t.Fatalf("load effective: %v", err)
+	}
+	if err := effective.Config.Settings().Validate(); err != nil {
+		t.Fatalf("effective config no longer validates: %v", err)
+	}
+}
+
+func TestSetKeepsCommentsAndLayout(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "global", FileName)
+	path := filepath.Join(dir, FileName)
+	content := `# Team settings; see README.
+[types]
+extra = [
+  "spike", # time-boxed research
+  "research",
+]
+
+[defaults]
+type = "task" # most work is tasks
+priority = 2
+
+[[hooks.pre_close]]
+command = "go test ./..."
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if err := Set(globalPath, path, false, "types.extra", "spike,research,ops"); err != nil {
+		t.Fatalf("set types.extra: %v", err)
+	}
+	if err := Set(globalPath, path, false, "defaults.type", "ops"); err != nil {
+		t.Fatalf("set defaults.type: %v", err)
+	}
+	if err := Set(globalPath, path, false, "defaults.claim_ttl", "45m"); err != nil {
+		t.Fatalf("set defaults.claim_ttl: %v", err)
+	}
+	if err := Set(globalPath, path, false, "snapshots.keep", "3"); err != nil {
+		t.Fatalf("set snapshots.keep: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	want := `# Team settings; see README.
+[types]
+extra = ["spike", "research", "ops"]
+
+[defaults]
+claim_ttl = "45m0s"
+type = "ops" # most work is tasks
+priority = 2
+
+[[hooks.pre_close]]
+command = "go test ./..."
+
+[snapshots]
+keep = 3
+`
+	if string(got) != want {
+		t.Fatalf("unexpected config file:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := Load(path); err != nil {
+		t.Fatalf("load edited config: %v", err)
+	}
+}
+
+func TestSetRefusesKeysItCannotEditInPlace(t *testing.T) {
+	dir := t.TempDir()
+	globalPath := filepath.Join(dir, "global", FileName)
+	path := filepath.Join(dir, FileName)
+	content := "defaults.type = \"task\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := Set(globalPath, path, false, "defaults.type", "bug"); err == nil || !strings.Contains(err.Error(), "edit the file by hand") {
+		t.Fatalf("expected a dotted key to be refused, got %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != content {
+		t.Fatalf("refused edit changed the file:\n%s", got)
+	}
+}
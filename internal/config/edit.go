@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// setValue returns document with section.name set to value. Only that key is
+// rewritten, so comments, ordering and the rest of a hand-written file survive.
+// A missing key is added under its [section] header, and a missing section is
+// appended to the end of the file.
+func setValue(document []byte, section, name string, value any) ([]byte, error) {
+	var encoded bytes.Buffer
+	if err := toml.NewEncoder(&encoded).Encode(map[string]any{name: value}); err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	assignment := strings.TrimSpace(encoded.String())
+	_, literal, _ := strings.Cut(assignment, "=")
+	literal = strings.TrimSpace(literal)
+
+	text := string(document)
+	current, headerEnd := "", -1
+	for pos := 0; pos < len(text); {
+		lineEnd := lineEndAt(text, pos)
+		trimmed := strings.TrimSpace(text[pos:lineEnd])
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "[["):
+			current = ""
+		case strings.HasPrefix(trimmed, "["):
+			current = tableName(trimmed)
+			if current == section && headerEnd < 0 {
+				headerEnd = min(lineEnd+1, len(text))
+			}
+		default:
+			key, valueStart, ok := keyAt(text, pos, lineEnd)
+			if !ok {
+				break
+			}
+			valueEnd := valueEndAt(text, valueStart)
+			if current == section && key == name {
+				return []byte(text[:valueStart] + literal + text[valueEnd:]), nil
+			}
+			lineEnd = lineEndAt(text, valueEnd)
+		}
+		pos = lineEnd + 1
+	}
+
+	if headerEnd >= 0 {
+		prefix := text[:headerEnd]
+		if !strings.HasSuffix(prefix, "\n") {
+			prefix += "\n"
+		}
+		return []byte(prefix + assignment + "\n" + text[headerEnd:]), nil
+	}
+	if text != "" {
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		text += "\n"
+	}
+	return []byte(text + "[" + section + "]\n" + assignment + "\n"), nil
+}
+
+// lineEndAt returns the offset of the newline ending the line at pos, or len(text).
+func lineEndAt(text string, pos int) int {
+	if end := strings.IndexByte(text[pos:], '\n'); end >= 0 {
+		return pos + end
+	}
+	return len(text)
+}
+
+// tableName returns the name of a [table] header line, without comment or spaces.
+func tableName(header string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(header, "["), "]")
+	return strings.Trim(strings.TrimSpace(name), `"'`)
+}
+
+// keyAt parses the key of a key = value line and returns it with the offset of its value.
+func keyAt(text string, pos, lineEnd int) (string, int, bool) {
+	equals := strings.IndexByte(text[pos:lineEnd], '=')
+	if equals < 0 {
+		return "", 0, false
+	}
+	key := strings.Trim(strings.TrimSpace(text[pos:pos+equals]), `"'`)
+	valueStart := pos + equals + 1
+	for valueStart < len(text) && (text[valueStart] == ' ' || text[valueStart] == '\t') {
+		valueStart++
+	}
+	return key, valueStart, true
+}
+
+// valueEndAt returns the offset just past the TOML value starting at pos,
+// following strings, multi-line strings and nested arrays or inline tables.
+func valueEndAt(text string, pos int) int {
+	switch {
+	case strings.HasPrefix(text[pos:], `"""`), strings.HasPrefix(text[pos:], `'''`):
+		quote := text[pos : pos+3]
+		end := pos + 3
+		for end < len(text) {
+			next := strings.Index(text[end:], quote)
+			if next < 0 {
+				return len(text)
+			}
+			end += next
+			if quote == `"""` && escaped(text, end) {
+				end++
+				continue
+			}
+			end += 3
+			// A closing delimiter may carry up to two extra quotes that belong to the string.
+			for extra := 0; extra < 2 && end < len(text) && text[end] == quote[0]; extra++ {
+				end++
+			}
+			return end
+		}
+		return len(text)
+	case pos < len(text) && (text[pos] == '"' || text[pos] == '\''):
+		return stringEndAt(text, pos)
+	case pos < len(text) && (text[pos] == '[' || text[pos] == '{'):
+		depth := 0
+		for end := pos; end < len(text); end++ {
+			switch text[end] {
+			case '"', '\'':
+				end = valueEndAt(text, end) - 1
+			case '#':
+				end = lineEndAt(text, end)
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+				if depth == 0 {
+					return end + 1
+				}
+			}
+		}
+		return len(text)
+	}
+	end := pos
+	for end < len(text) && text[end] != '#' && text[end] != '\n' {
+		end++
+	}
+	return pos + len(strings.TrimRight(text[pos:end], " \t\r"))
+}
+
+// stringEndAt returns the offset just past the single-line string opening at pos.
+func stringEndAt(text string, pos int) int {
+	quote := text[pos]
+	for end := pos + 1; end < len(text) && text[end] != '\n'; end++ {
+		if text[end] == quote && (quote == '\'' || !escaped(text, end)) {
+			return end + 1
+		}
+	}
+	return lineEndAt(text, pos)
+}
+
+// escaped reports whether the character at pos follows an odd run of backslashes.
+func escaped(text string, pos int) bool {
+	backslashes := 0
+	for i := pos - 1; i >= 0 && text[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
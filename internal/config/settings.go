@@ -0,0 +1,392 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// Setting sources reported by `faz config list`.
+const (
+	SourceDefault = "default"
+	SourceGlobal  = "global"
+	SourceProject = "project"
+)
+
+// Effective is the merged configuration with the source of each scalar setting.
+type Effective struct {
+	Config  Config
+	Sources map[string]string
+}
+
+// setting is one scalar key exposed through `faz config get/set/list`.
+type setting struct {
+	key   string
+	help  string
+	get   func(Config) (string, bool)
+	parse func(raw string) (any, error)
+	copy  func(dst *Config, src Config)
+}
+
+// settings lists the scalar keys in display order.
+var settings = []setting{
+	{
+		key:  "project.id_prefix",
+		help: "Prefix for new root issue IDs (default: repository directory name)",
+		get:  func(c Config) (string, bool) { return c.Project.IDPrefix, c.Project.IDPrefix != "" },
+		parse: func(raw string) (any, error) {
+			return strings.TrimSpace(raw), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Project.IDPrefix = src.Project.IDPrefix },
+	},
+	{
+		key:  "defaults.type",
+		help: "Type used by `faz create` without --type",
+		get:  func(c Config) (string, bool) { return c.Defaults.Type, c.Defaults.Type != "" },
+		parse: func(raw string) (any, error) {
+			return strings.TrimSpace(raw), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Defaults.Type = src.Defaults.Type },
+	},
+	{
+		key:  "defaults.priority",
+		help: "Priority used by `faz create` without --priority",
+		get: func(c Config) (string, bool) {
+			if c.Defaults.Priority == nil {
+				return "", false
+			}
+			return strconv.Itoa(*c.Defaults.Priority), true
+		},
+		parse: func(raw string) (any, error) {
+			priority, err := strconv.Atoi(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority %q", raw)
+			}
+			return int64(priority), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Defaults.Priority = src.Defaults.Priority },
+	},
+	{
+		key:  "defaults.claim_ttl",
+		help: "Lease used by `faz claim` and `faz heartbeat` without --ttl",
+		get: func(c Config) (string, bool) {
+			return c.Defaults.ClaimTTL.String(), c.Defaults.ClaimTTL.Duration > 0
+		},
+		parse: parseDuration,
+		copy:  func(dst *Config, src Config) { dst.Defaults.ClaimTTL = src.Defaults.ClaimTTL },
+	},
+	{
+		key:  "types.extra",
+		help: "Comma-separated issue types accepted in addition to the built-in ones",
+		get:  func(c Config) (string, bool) { return strings.Join(c.Types.Extra, ","), len(c.Types.Extra) > 0 },
+		parse: func(raw string) (any, error) {
+			types := make([]string, 0)
+			for _, part := range strings.Split(raw, ",") {
+				if typ := strings.TrimSpace(part); typ != "" {
+					types = append(types, typ)
+				}
+			}
+			return types, nil
+		},
+		copy: func(dst *Config, src Config) { dst.Types.Extra = src.Types.Extra },
+	},
+	{
+		key:  "snapshots.keep",
+		help: "Automatic snapshots kept before destructive commands (0 disables them)",
+		get: func(c Config) (string, bool) {
+			if c.Snapshots.Keep == nil {
+				return "", false
+			}
+			return strconv.Itoa(*c.Snapshots.Keep), true
+		},
+		parse: func(raw string) (any, error) {
+			keep, err := strconv.Atoi(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("invalid snapshot count %q", raw)
+			}
+			return int64(keep), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Snapshots.Keep = src.Snapshots.Keep },
+	},
+	{
+		key:  "export.auto_path",
+		help: "JSONL file rewritten after every command that changes the store (relative to the repository root)",
+		get:  func(c Config) (string, bool) { return c.Export.AutoPath, c.Export.AutoPath != "" },
+		parse: func(raw string) (any, error) {
+			return strings.TrimSpace(raw), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Export.AutoPath = src.Export.AutoPath },
+	},
+	{
+		key:  "worktree.share_store",
+		help: "Whether linked Git worktrees use the main checkout's task store",
+		get: func(c Config) (string, bool) {
+			if c.Worktree.ShareStore == nil {
+				return "", false
+			}
+			return strconv.FormatBool(*c.Worktree.ShareStore), true
+		},
+		parse: func(raw string) (any, error) {
+			share, err := strconv.ParseBool(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("invalid boolean %q", raw)
+			}
+			return share, nil
+		},
+		copy: func(dst *Config, src Config) { dst.Worktree.ShareStore = src.Worktree.ShareStore },
+	},
+	{
+		key:  "kanban.columns",
+		help: "Comma-separated board columns in display order (todo, blocked, ready, claimed, done)",
+		get: func(c Config) (string, bool) {
+			return strings.Join(c.Kanban.Columns, ","), len(c.Kanban.Columns) > 0
+		},
+		parse: func(raw string) (any, error) {
+			columns := make([]string, 0)
+			for _, part := range strings.Split(raw, ",") {
+				if column := strings.ToLower(strings.TrimSpace(part)); column != "" {
+					columns = append(columns, column)
+				}
+			}
+			return columns, nil
+		},
+		copy: func(dst *Config, src Config) { dst.Kanban.Columns = src.Kanban.Columns },
+	},
+	{
+		key:  "kanban.swimlanes",
+		help: "Horizontal board lanes: none, priority, type or label",
+		get:  func(c Config) (string, bool) { return c.Kanban.Swimlanes, c.Kanban.Swimlanes != "" },
+		parse: func(raw string) (any, error) {
+			return strings.ToLower(strings.TrimSpace(raw)), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Kanban.Swimlanes = src.Kanban.Swimlanes },
+	},
+	{
+		key:  "kanban.theme",
+		help: "Board colors: dark, light, high-contrast or a [kanban.palettes] name",
+		get:  func(c Config) (string, bool) { return c.Kanban.Theme, c.Kanban.Theme != "" },
+		parse: func(raw string) (any, error) {
+			return strings.TrimSpace(raw), nil
+		},
+		copy: func(dst *Config, src Config) { dst.Kanban.Theme = src.Kanban.Theme },
+	},
+	{
+		key:   "hooks.timeout",
+		help:  "Default timeout for hooks that set none",
+		get:   func(c Config) (string, bool) { return c.Hooks.Timeout.String(), c.Hooks.Timeout.Duration > 0 },
+		parse: parseDuration,
+		copy:  func(dst *Config, src Config) { dst.Hooks.Timeout = src.Hooks.Timeout },
+	},
+}
+
+// Keys lists the settable keys in display order.
+func Keys() []string {
+	keys := make([]string, 0, len(settings))
+	for _, s := range settings {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// Describe returns the help text of key.
+func Describe(key string) (string, error) {
+	s, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+	return s.help, nil
+}
+
+// GlobalPath returns the user-wide config file, honoring XDG_CONFIG_HOME.
+func GlobalPath() (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolve home directory: %w", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "faz", FileName), nil
+}
+
+// LoadEffective merges the global and project config files.
+// Project scalars override global ones; hooks from both files run, global first.
+// Kanban palettes and key bindings merge by name, the project winning.
+func LoadEffective(globalPath, projectPath string) (Effective, error) {
+	global, globalMeta, err := load(globalPath)
+	if err != nil {
+		return Effective{}, err
+	}
+	project, projectMeta, err := load(projectPath)
+	if err != nil {
+		return Effective{}, err
+	}
+
+	effective := Effective{Sources: make(map[string]string, len(settings))}
+	for _, s := range settings {
+		path := strings.Split(s.key, ".")
+		switch {
+		case projectMeta.IsDefined(path...):
+			s.copy(&effective.Config, project)
+			effective.Sources[s.key] = SourceProject
+		case globalMeta.IsDefined(path...):
+			s.copy(&effective.Config, global)
+			effective.Sources[s.key] = SourceGlobal
+		default:
+			effective.Sources[s.key] = SourceDefault
+		}
+	}
+	for _, event := range model.HookEvents {
+		effective.Config.Hooks.append(event, global.Hooks.For(event))
+		effective.Config.Hooks.append(event, project.Hooks.For(event))
+	}
+	effective.Config.Kanban.Palettes = mergeByName(global.Kanban.Palettes, project.Kanban.Palettes)
+	effective.Config.Kanban.Keys = mergeByName(global.Kanban.Keys, project.Kanban.Keys)
+	return effective, nil
+}
+
+// Get returns the configured value of key, or the built-in default when unset.
+func (e Effective) Get(key string) (string, error) {
+	s, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+	if value, ok := s.get(e.Config); ok {
+		return value, nil
+	}
+	if value, ok := s.get(builtinConfig()); ok {
+		return value, nil
+	}
+	return "", nil
+}
+
+// Settings converts the configuration into service settings over the built-in defaults.
+func (c Config) Settings() service.Settings {
+	settings := service.DefaultSettings()
+	settings.IDPrefix = c.Project.IDPrefix
+	settings.ExtraTypes = c.Types.Extra
+	if c.Defaults.Type != "" {
+		settings.DefaultType = c.Defaults.Type
+	}
+	if c.Defaults.Priority != nil {
+		settings.DefaultPriority = *c.Defaults.Priority
+	}
+	if c.Defaults.ClaimTTL.Duration > 0 {
+		settings.DefaultClaimTTL = c.Defaults.ClaimTTL.Duration
+	}
+	return settings
+}
+
+// Set writes key = raw into the global or the project config file, creating it
+// when missing. Only that key changes; comments and the rest of the file are
+// kept. The merged result of both files must still validate, otherwise the file
+// is left untouched.
+func Set(globalPath, projectPath string, global bool, key, raw string) error {
+	path := projectPath
+	if global {
+		path = globalPath
+	}
+	s, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	value, err := s.parse(raw)
+	if err != nil {
+		return err
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read config %s: %w", path, err)
+	}
+	if _, err := toml.Decode(string(original), &map[string]any{}); err != nil {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+	section, name, _ := strings.Cut(key, ".")
+	updated, err := setValue(original, section, name, value)
+	if err != nil {
+		return err
+	}
+	if _, err := toml.Decode(string(updated), &map[string]any{}); err != nil {
+		return fmt.Errorf("cannot update %s in %s in place (%v); edit the file by hand", key, path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return fmt.Errorf("write config %s: %w", path, err)
+	}
+
+	merged, err := LoadEffective(globalPath, projectPath)
+	if err == nil {
+		err = merged.Config.Settings().Validate()
+	}
+	if err != nil {
+		if original == nil {
+			_ = os.Remove(path)
+		} else {
+			_ = os.WriteFile(path, original, 0o644)
+		}
+		return err
+	}
+	return nil
+}
+
+// mergeByName combines two tables; entries from override replace those of base.
+func mergeByName[V any](base, override map[string]V) map[string]V {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]V, len(base)+len(override))
+	maps.Copy(merged, base)
+	maps.Copy(merged, override)
+	return merged
+}
+
+// builtinConfig expresses the built-in defaults as a config for display.
+func builtinConfig() Config {
+	defaults := service.DefaultSettings()
+	keep := db.DefaultSnapshotKeep
+	share := true
+	return Config{
+		Defaults: Defaults{
+			Type:     defaults.DefaultType,
+			Priority: &defaults.DefaultPriority,
+			ClaimTTL: Duration{Duration: defaults.DefaultClaimTTL},
+		},
+		Snapshots: Snapshots{Keep: &keep},
+		Worktree:  Worktree{ShareStore: &share},
+		Kanban:    Kanban{Columns: model.DefaultKanbanColumns, Swimlanes: model.KanbanSwimlanesNone, Theme: model.KanbanThemeDark},
+		Hooks:     Hooks{Timeout: Duration{Duration: DefaultHookTimeout}},
+	}
+}
+
+// lookup finds the registered setting for key.
+func lookup(key string) (setting, error) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, nil
+		}
+	}
+	return setting{}, fmt.Errorf("unknown config key %q (expected one of %s)", key, strings.Join(Keys(), ", "))
+}
+
+// parseDuration validates a duration value and keeps its string form for the file.
+func parseDuration(raw string) (any, error) {
+	var d Duration
+	if err := d.UnmarshalText([]byte(raw)); err != nil {
+		return nil, err
+	}
+	return d.String(), nil
+}
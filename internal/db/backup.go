@@ -0,0 +1,307 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+const (
+	// SnapshotDirName is the directory under .faz that holds automatic snapshots.
+	SnapshotDirName = "snapshots"
+	// DefaultSnapshotKeep is how many automatic snapshots are kept when config sets none.
+	DefaultSnapshotKeep = 10
+
+	snapshotExt        = ".db"
+	snapshotTimeLayout = "20060102T150405.000Z"
+)
+
+// Snapshot describes one database copy under .faz/snapshots.
+type Snapshot struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+	SizeBytes  int64     `json:"size_bytes"`
+	IssueCount int64     `json:"issue_count"`
+}
+
+// UnreadableSnapshot names a snapshot file that could not be opened or counted.
+type UnreadableSnapshot struct {
+	Name string
+	Err  error
+}
+
+// Backup writes a consistent copy of the open database to dest using VACUUM INTO.
+// It is safe while other connections write in WAL mode; dest must not exist yet.
+func Backup(db *sql.DB, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("backup destination %s already exists", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("create backup directory %s: %w", filepath.Dir(dest), err)
+	}
+	if _, err := db.Exec(`VACUUM INTO ?`, dest); err != nil {
+		return fmt.Errorf("backup database to %s: %w", dest, err)
+	}
+	return nil
+}
+
+// TakeSnapshot backs up the open project database into .faz/snapshots and
+// prunes the oldest snapshots beyond keep. A keep of zero disables snapshots.
+func TakeSnapshot(db *sql.DB, projectDir, reason string, keep int) (Snapshot, error) {
+	if keep <= 0 {
+		return Snapshot{}, nil
+	}
+	snapshot := NewSnapshot(projectDir, reason)
+	if err := Backup(db, snapshot.Path); err != nil {
+		return Snapshot{}, fmt.Errorf("snapshot before %s: %w", reason, err)
+	}
+	if err := pruneSnapshots(projectDir, keep); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// NewSnapshot names a snapshot for reason taken now, without writing it.
+func NewSnapshot(projectDir, reason string) Snapshot {
+	now := time.Now().UTC()
+	name := now.Format(snapshotTimeLayout) + "-" + snapshotReason(reason) + snapshotExt
+	return Snapshot{
+		Name:      name,
+		Path:      filepath.Join(snapshotDir(projectDir), name),
+		Reason:    snapshotReason(reason),
+		CreatedAt: now,
+	}
+}
+
+// ListSnapshots returns the project snapshots, newest first, with their issue counts.
+// Snapshots that cannot be read are left out of the list and returned as unreadable.
+func ListSnapshots(projectDir string) ([]Snapshot, []UnreadableSnapshot, error) {
+	entries, err := os.ReadDir(snapshotDir(projectDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Snapshot{}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read snapshots: %w", err)
+	}
+
+	snapshots := make([]Snapshot, 0, len(entries))
+	var unreadable []UnreadableSnapshot
+	for _, entry := range entries {
+		snapshot, ok := parseSnapshotName(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		snapshot.Path = filepath.Join(snapshotDir(projectDir), entry.Name())
+		if info, err := entry.Info(); err == nil {
+			snapshot.SizeBytes = info.Size()
+		}
+		count, err := countIssues(snapshot.Path)
+		if err != nil {
+			unreadable = append(unreadable, UnreadableSnapshot{Name: snapshot.Name, Err: err})
+			continue
+		}
+		snapshot.IssueCount = count
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name > snapshots[j].Name })
+	return snapshots, unreadable, nil
+}
+
+// ResolveSnapshot maps a snapshot name (with or without extension) or a file path to a path.
+func ResolveSnapshot(projectDir, ref string) (string, error) {
+	candidates := []string{ref}
+	if !strings.ContainsRune(ref, filepath.Separator) {
+		name := ref
+		if !strings.HasSuffix(name, snapshotExt) {
+			name += snapshotExt
+		}
+		candidates = append([]string{filepath.Join(snapshotDir(projectDir), name)}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("snapshot %q not found (see `faz restore` for the list)", ref)
+}
+
+// Restore replaces the contents of the project database with the copy at source
+// and returns the snapshot taken of the replaced data. The source must be a faz
+// database no newer than this build. Pages are copied into the live database in
+// one write transaction, so processes that hold it open, such as `faz serve`,
+// see the restored issues on their next read and keep writing to the same file.
+func Restore(projectDir, source string, keep int) (Snapshot, error) {
+	dbPath := filepath.Join(projectDir, DirName, DBFileName)
+	staged := dbPath + ".restore"
+	_ = os.Remove(staged)
+	defer func() { _ = os.Remove(staged) }()
+	// Stage the source before snapshotting so pruning cannot remove it mid-restore.
+	if err := stageRestore(source, staged); err != nil {
+		return Snapshot{}, err
+	}
+
+	liveDB, err := Open(dbPath)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer func() { _ = liveDB.Close() }()
+	snapshot, err := TakeSnapshot(liveDB, projectDir, "restore", keep)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if err := restoreInto(liveDB, staged); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// restoreInto overwrites the open database with every page of the database at
+// source using SQLite's online backup API. The copy runs as one write
+// transaction on the live file; the WAL and other connections stay valid.
+func restoreInto(db *sql.DB, source string) error {
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("restore database: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.Raw(func(driverConn any) error {
+		restorer, ok := driverConn.(interface {
+			NewRestore(srcURI string) (*sqlite.Backup, error)
+		})
+		if !ok {
+			return fmt.Errorf("restore database: sqlite driver does not support online restore")
+		}
+		backup, err := restorer.NewRestore(source)
+		if err != nil {
+			return fmt.Errorf("restore database: %w", err)
+		}
+		for {
+			more, err := backup.Step(-1)
+			if err != nil {
+				_ = backup.Finish()
+				return fmt.Errorf("restore database: %w", err)
+			}
+			if !more {
+				break
+			}
+		}
+		if err := backup.Finish(); err != nil {
+			return fmt.Errorf("restore database: %w", err)
+		}
+		return nil
+	})
+}
+
+// stageRestore validates source as a faz database and copies it to staged.
+func stageRestore(source, staged string) error {
+	sourceDB, err := Open(source)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sourceDB.Close() }()
+	if _, err := countIssuesIn(sourceDB); err != nil {
+		return fmt.Errorf("%s is not a faz database: %w", source, err)
+	}
+	version, err := SchemaVersion(sourceDB)
+	if err != nil {
+		return err
+	}
+	if version > LatestVersion() {
+		return fmt.Errorf("%w (snapshot schema version %d, this faz supports up to %d)", ErrSchemaTooNew, version, LatestVersion())
+	}
+	return Backup(sourceDB, staged)
+}
+
+// pruneSnapshots deletes the oldest snapshots beyond keep.
+func pruneSnapshots(projectDir string, keep int) error {
+	entries, err := os.ReadDir(snapshotDir(projectDir))
+	if err != nil {
+		return fmt.Errorf("read snapshots: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := parseSnapshotName(entry.Name()); ok && !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	for _, name := range names[min(keep, len(names)):] {
+		if err := os.Remove(filepath.Join(snapshotDir(projectDir), name)); err != nil {
+			return fmt.Errorf("prune snapshot %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// parseSnapshotName reads the timestamp and reason encoded in a snapshot file name.
+func parseSnapshotName(name string) (Snapshot, bool) {
+	base, ok := strings.CutSuffix(name, snapshotExt)
+	if !ok {
+		return Snapshot{}, false
+	}
+	stamp, reason, ok := strings.Cut(base, "-")
+	if !ok {
+		return Snapshot{}, false
+	}
+	createdAt, err := time.Parse(snapshotTimeLayout, stamp)
+	if err != nil {
+		return Snapshot{}, false
+	}
+	return Snapshot{Name: name, Reason: reason, CreatedAt: createdAt}, true
+}
+
+// snapshotReason keeps a reason safe for use in a file name.
+func snapshotReason(reason string) string {
+	clean := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, reason)
+	if clean == "" {
+		return "manual"
+	}
+	return clean
+}
+
+// snapshotDir returns .faz/snapshots for a project.
+func snapshotDir(projectDir string) string {
+	return filepath.Join(projectDir, DirName, SnapshotDirName)
+}
+
+// countIssues opens a database file and counts its issues.
+func countIssues(path string) (int64, error) {
+	sqlDB, err := Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = sqlDB.Close() }()
+	count, err := countIssuesIn(sqlDB)
+	if err != nil {
+		return 0, fmt.Errorf("count issues in %s: %w", path, err)
+	}
+	return count, nil
+}
+
+// countIssuesIn counts the issues in an open database.
+func countIssuesIn(db *sql.DB) (int64, error) {
+	var count int64
+	if err := db.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openProject initializes a migrated project database with count issues.
+func openProject(t *testing.T, count int) (string, *sql.DB) {
+	t.Helper()
+	projectDir := t.TempDir()
+	dbPath, err := EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+	sqlDB, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+	if _, err := sqlDB.Exec(`PRAGMA journal_mode = WAL;`); err != nil {
+		t.Fatalf("enable wal: %v", err)
+	}
+	for i := 0; i < count; i++ {
+		insertIssue(t, sqlDB)
+	}
+	return projectDir, sqlDB
+}
+
+// insertIssue adds one task row.
+func insertIssue(t *testing.T, sqlDB *sql.DB) {
+	t.Helper()
+	if _, err := sqlDB.Exec(`INSERT INTO issues (title, type) VALUES ('Task', 'task')`); err != nil {
+		t.Fatalf("insert issue: %v", err)
+	}
+}
+
+func TestSnapshotsRotateAndListIssueCounts(t *testing.T) {
+	projectDir, sqlDB := openProject(t, 1)
+
+	for i := 0; i < 3; i++ {
+		if _, err := TakeSnapshot(sqlDB, projectDir, "delete", 2); err != nil {
+			t.Fatalf("snapshot %d: %v", i, err)
+		}
+		insertIssue(t, sqlDB)
+	}
+	snapshots, unreadable, err := ListSnapshots(projectDir)
+	if err != nil || len(unreadable) != 0 {
+		t.Fatalf("list snapshots: %v, unreadable %+v", err, unreadable)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected rotation to keep 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].IssueCount != 3 || snapshots[1].IssueCount != 2 || snapshots[0].Reason != "delete" {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+
+	if snapshot, err := TakeSnapshot(sqlDB, projectDir, "delete", 0); err != nil || snapshot.Path != "" {
+		t.Fatalf("keep 0 should skip snapshots, got %+v, %v", snapshot, err)
+	}
+}
+
+func TestListSnapshotsSkipsUnreadableSnapshots(t *testing.T) {
+	projectDir, sqlDB := openProject(t, 1)
+	if _, err := TakeSnapshot(sqlDB, projectDir, "delete", DefaultSnapshotKeep); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	broken := NewSnapshot(projectDir, "manual")
+	if err := os.WriteFile(broken.Path, []byte("not a database"), 0o644); err != nil {
+		t.Fatalf("write broken snapshot: %v", err)
+	}
+
+	snapshots, unreadable, err := ListSnapshots(projectDir)
+	if err != nil {
+		t.Fatalf("list snapshots: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].IssueCount != 1 {
+		t.Fatalf("expected the readable snapshot only, got %+v", snapshots)
+	}
+	if len(unreadable) != 1 || unreadable[0].Name != broken.Name || unreadable[0].Err == nil {
+		t.Fatalf("expected %s reported as unreadable, got %+v", broken.Name, unreadable)
+	}
+}
+
+func TestRestoreReplacesDatabaseAndSnapshotsCurrent(t *testing.T) {
+	projectDir, sqlDB := openProject(t, 2)
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := Backup(sqlDB, backupPath); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+	if err := Backup(sqlDB, backupPath); err == nil {
+		t.Fatal("expected backup to refuse an existing destination")
+	}
+	insertIssue(t, sqlDB)
+	_ = sqlDB.Close()
+
+	snapshot, err := Restore(projectDir, backupPath, DefaultSnapshotKeep)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if snapshot.Reason != "restore" {
+		t.Fatalf("unexpected pre-restore snapshot: %+v", snapshot)
+	}
+	if count, err := countIssues(snapshot.Path); err != nil || count != 3 {
+		t.Fatalf("pre-restore snapshot issues = %d, %v", count, err)
+	}
+	if count, err := countIssues(filepath.Join(projectDir, DirName, DBFileName)); err != nil || count != 2 {
+		t.Fatalf("restored issues = %d, %v", count, err)
+	}
+
+	notFaz := filepath.Join(t.TempDir(), "other.db")
+	if err := os.WriteFile(notFaz, nil, 0o644); err != nil {
+		t.Fatalf("write empty db: %v", err)
+	}
+	if _, err := Restore(projectDir, notFaz, DefaultSnapshotKeep); err == nil {
+		t.Fatal("expected restore to reject a non-faz database")
+	}
+}
+
+func TestRestoreKeepsOpenConnectionsOnTheLiveDatabase(t *testing.T) {
+	projectDir, sqlDB := openProject(t, 2)
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := Backup(sqlDB, backupPath); err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+	insertIssue(t, sqlDB)
+
+	// sqlDB stays open across the restore, like a running `faz serve`.
+	if _, err := Restore(projectDir, backupPath, DefaultSnapshotKeep); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if count, err := countIssuesIn(sqlDB); err != nil || count != 2 {
+		t.Fatalf("open connection sees %d issues after restore, %v", count, err)
+	}
+	insertIssue(t, sqlDB)
+
+	dbPath := filepath.Join(projectDir, DirName, DBFileName)
+	if count, err := countIssues(dbPath); err != nil || count != 3 {
+		t.Fatalf("write after restore was lost: fresh connection sees %d issues, %v", count, err)
+	}
+	var mode string
+	if err := sqlDB.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil || mode != "wal" {
+		t.Fatalf("journal mode after restore = %q, %v", mode, err)
+	}
+	if _, err := os.Stat(dbPath + ".restore"); !os.IsNotExist(err) {
+		t.Fatalf("expected the staged copy to be removed, stat = %v", err)
+	}
+}
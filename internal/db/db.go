@@ -33,10 +33,11 @@ func EnsureProjectFiles(projectDir string) (string, error) {
 }
 
 // Open opens a SQLite database from the given path.
+// Transactions begin IMMEDIATE so multi-statement writes take the write lock up front.
 func Open(dbPath string) (*sql.DB, error) {
 	var lastRetryErr error
 	for attempt := 0; attempt < maxOpenAttempts; attempt++ {
-		db, err := sql.Open("sqlite", dbPath)
+		db, err := sql.Open("sqlite", dbPath+"?_txlock=immediate")
 		if err != nil {
 			return nil, fmt.Errorf("open sqlite database: %w", err)
 		}
@@ -72,8 +73,23 @@ func Open(dbPath string) (*sql.DB, error) {
 	return nil, fmt.Errorf("open sqlite database failed after %d attempts", maxOpenAttempts)
 }
 
-// OpenProjectDB opens a project database and errors if init has not been run.
+// OpenProjectDB opens a project database and errors if init has not been run
+// or its schema version does not match this build.
 func OpenProjectDB(projectDir string) (*sql.DB, string, error) {
+	db, dbPath, err := OpenProjectDBUnchecked(projectDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := CheckVersion(db); err != nil {
+		_ = db.Close()
+		return nil, "", err
+	}
+	return db, dbPath, nil
+}
+
+// OpenProjectDBUnchecked opens a project database without checking its schema version.
+// Only schema maintenance commands should use it.
+func OpenProjectDBUnchecked(projectDir string) (*sql.DB, string, error) {
 	dbPath := filepath.Join(projectDir, DirName, DBFileName)
 	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
 		return nil, "", ErrNotInitialized
@@ -2,13 +2,163 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
-// Migrate creates and updates required tables for the faz database.
+var (
+	// ErrSchemaTooNew reports a database written by a newer faz release.
+	ErrSchemaTooNew = errors.New("database schema is newer than this faz")
+	// ErrSchemaOutdated reports a database with pending migrations.
+	ErrSchemaOutdated = errors.New("database schema is out of date")
+)
+
+// Migration is one ordered schema step, recorded in PRAGMA user_version once applied.
+type Migration struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	up      func(tx execer) error
+}
+
+// SchemaStatus describes the recorded schema version against this build.
+type SchemaStatus struct {
+	Current int         `json:"current"`
+	Latest  int         `json:"latest"`
+	Pending []Migration `json:"pending"`
+}
+
+// execer is the subset of *sql.DB and *sql.Tx used by migration steps.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// migrations lists every schema step in version order. Append new steps; never edit applied ones.
+// Steps 1 and 2 are idempotent so databases created before versioning (user_version 0) upgrade in place.
+var migrations = []Migration{
+	{Version: 1, Name: "baseline schema", up: migrateBaseline},
+	{Version: 2, Name: "full-text search index", up: ensureSearchIndex},
+	{Version: 3, Name: "keep explicit updated_at", up: migrateExplicitUpdatedAt},
+	{Version: 4, Name: "claim worktree and branch", up: migrateClaimWorkspace},
+}
+
+// LatestVersion returns the schema version this build writes.
+func LatestVersion() int {
+	return latestVersion(migrations)
+}
+
+// SchemaVersion reads the schema version recorded in the database.
+func SchemaVersion(db execer) (int, error) {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Status reports the recorded schema version and the migrations still to apply.
+func Status(db *sql.DB) (SchemaStatus, error) {
+	return status(db, migrations)
+}
+
+// CheckVersion errors unless the database schema matches this build exactly.
+func CheckVersion(db *sql.DB) error {
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	latest := LatestVersion()
+	switch {
+	case current > latest:
+		return fmt.Errorf("%w (schema version %d, this faz supports up to %d)", ErrSchemaTooNew, current, latest)
+	case current < latest:
+		return fmt.Errorf("%w (schema version %d, latest is %d)", ErrSchemaOutdated, current, latest)
+	}
+	return nil
+}
+
+// Migrate applies every pending migration, each in its own transaction.
+// It refuses to touch a database written by a newer faz.
 func Migrate(db *sql.DB) error {
+	return migrate(db, migrations)
+}
+
+// migrate applies steps above the recorded version in order.
+func migrate(db *sql.DB, steps []Migration) error {
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if latest := latestVersion(steps); current > latest {
+		return fmt.Errorf("%w (schema version %d, this faz supports up to %d)", ErrSchemaTooNew, current, latest)
+	}
+	for _, step := range steps {
+		if step.Version <= current {
+			continue
+		}
+		if err := applyMigration(db, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMigration runs one step and records its version atomically.
+// The version is re-read inside the write transaction so concurrent migrators apply each step once.
+func applyMigration(db *sql.DB, step Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin migration %d: %w", step.Version, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := SchemaVersion(tx)
+	if err != nil {
+		return err
+	}
+	if current >= step.Version {
+		return nil
+	}
+	if err := step.up(tx); err != nil {
+		return fmt.Errorf("migration %d (%s): %w", step.Version, step.Name, err)
+	}
+	// PRAGMA does not accept bound parameters; the version is a trusted integer.
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, step.Version)); err != nil {
+		return fmt.Errorf("record schema version %d: %w", step.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit migration %d: %w", step.Version, err)
+	}
+	return nil
+}
+
+// status compares the recorded version against steps.
+func status(db *sql.DB, steps []Migration) (SchemaStatus, error) {
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return SchemaStatus{}, err
+	}
+	result := SchemaStatus{Current: current, Latest: latestVersion(steps), Pending: make([]Migration, 0)}
+	for _, step := range steps {
+		if step.Version > current {
+			result.Pending = append(result.Pending, step)
+		}
+	}
+	return result, nil
+}
+
+// latestVersion returns the highest version in steps.
+func latestVersion(steps []Migration) int {
+	if len(steps) == 0 {
+		return 0
+	}
+	return steps[len(steps)-1].Version
+}
+
+// migrateBaseline creates the schema faz had before versioning and upgrades older layouts.
+func migrateBaseline(db execer) error {
 	statements := []string{
-		`PRAGMA foreign_keys = ON;`,
 		`CREATE TABLE IF NOT EXISTS issues (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			public_id TEXT UNIQUE,
@@ -16,9 +166,11 @@ func Migrate(db *sql.DB) error {
 			description TEXT NOT NULL DEFAULT '',
 			type TEXT NOT NULL,
 			priority INTEGER NOT NULL DEFAULT 2,
+			estimate INTEGER,
 			status TEXT NOT NULL DEFAULT 'open',
 			claimed_at DATETIME,
 			claim_expires_at DATETIME,
+			claimed_by TEXT,
 			parent_id INTEGER,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -33,7 +185,40 @@ func Migrate(db *sql.DB) error {
 			FOREIGN KEY(depends_on_id) REFERENCES issues(id) ON DELETE CASCADE,
 			CHECK (issue_id != depends_on_id)
 		);`,
+		`CREATE TABLE IF NOT EXISTS labels (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE
+		);`,
+		`CREATE TABLE IF NOT EXISTS issue_labels (
+			issue_id INTEGER NOT NULL,
+			label_id INTEGER NOT NULL,
+			PRIMARY KEY (issue_id, label_id),
+			FOREIGN KEY(issue_id) REFERENCES issues(id) ON DELETE CASCADE,
+			FOREIGN KEY(label_id) REFERENCES labels(id) ON DELETE CASCADE
+		);`,
+		`CREATE TABLE IF NOT EXISTS issue_comments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_id INTEGER NOT NULL,
+			author TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY(issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		);`,
+		`CREATE TABLE IF NOT EXISTS issue_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_id TEXT NOT NULL,
+			action TEXT NOT NULL,
+			field TEXT NOT NULL DEFAULT '',
+			old_value TEXT,
+			new_value TEXT,
+			actor TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);`,
 		`CREATE INDEX IF NOT EXISTS idx_issues_status ON issues(status);`,
+		`CREATE INDEX IF NOT EXISTS idx_issue_events_issue ON issue_events(issue_id, id);`,
+		`CREATE INDEX IF NOT EXISTS idx_issue_events_created_at ON issue_events(created_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_issue_comments_issue ON issue_comments(issue_id, id);`,
+		`CREATE INDEX IF NOT EXISTS idx_issue_labels_label ON issue_labels(label_id);`,
 		`CREATE INDEX IF NOT EXISTS idx_issues_parent ON issues(parent_id);`,
 		`CREATE INDEX IF NOT EXISTS idx_issues_closed_at ON issues(closed_at);`,
 		`CREATE TRIGGER IF NOT EXISTS trg_issues_updated_at
@@ -57,12 +242,136 @@ func Migrate(db *sql.DB) error {
 	if _, err := db.Exec(`UPDATE issues SET public_id = 'legacy-' || id WHERE public_id IS NULL OR public_id = ''`); err != nil {
 		return fmt.Errorf("backfill public IDs: %w", err)
 	}
+	return nil
+}
 
+// migrateExplicitUpdatedAt limits the updated_at trigger to updates that leave the column alone,
+// so imports can store the timestamp recorded on the machine that made the change.
+func migrateExplicitUpdatedAt(db execer) error {
+	statements := []string{
+		`DROP TRIGGER IF EXISTS trg_issues_updated_at;`,
+		`CREATE TRIGGER trg_issues_updated_at
+		AFTER UPDATE ON issues
+		FOR EACH ROW
+		WHEN NEW.updated_at IS OLD.updated_at
+		BEGIN
+			UPDATE issues SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+		END;`,
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("replace updated_at trigger: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateClaimWorkspace records the Git worktree and branch that hold each claim.
+func migrateClaimWorkspace(db execer) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info('issues')`)
+	if err != nil {
+		return fmt.Errorf("inspect issues table columns: %w", err)
+	}
+	existing := make(map[string]struct{})
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan issues table metadata: %w", err)
+		}
+		existing[name] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("inspect issues table columns: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("inspect issues table columns: %w", err)
+	}
+
+	for _, column := range []string{"claimed_worktree", "claimed_branch"} {
+		if _, ok := existing[column]; ok {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE issues ADD COLUMN ` + column + ` TEXT`); err != nil {
+			return fmt.Errorf("add %s column: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// ensureSearchIndex creates the issues_fts full-text index and the triggers that keep it in sync.
+// The index row for an issue shares its rowid with issues.id; comments are folded into one column.
+func ensureSearchIndex(db execer) error {
+	var existing int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'issues_fts'`).Scan(&existing); err != nil {
+		return fmt.Errorf("inspect search index: %w", err)
+	}
+
+	statements := []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS issues_fts USING fts5(
+			title,
+			description,
+			comments,
+			tokenize = 'porter unicode61'
+		);`,
+		`CREATE TRIGGER IF NOT EXISTS trg_issues_fts_insert
+		AFTER INSERT ON issues
+		FOR EACH ROW
+		BEGIN
+			INSERT INTO issues_fts(rowid, title, description, comments) VALUES (NEW.id, NEW.title, NEW.description, '');
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS trg_issues_fts_update
+		AFTER UPDATE OF title, description ON issues
+		FOR EACH ROW
+		BEGIN
+			UPDATE issues_fts SET title = NEW.title, description = NEW.description WHERE rowid = NEW.id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS trg_issues_fts_delete
+		AFTER DELETE ON issues
+		FOR EACH ROW
+		BEGIN
+			DELETE FROM issues_fts WHERE rowid = OLD.id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS trg_issue_comments_fts_insert
+		AFTER INSERT ON issue_comments
+		FOR EACH ROW
+		BEGIN
+			UPDATE issues_fts
+			SET comments = (SELECT COALESCE(GROUP_CONCAT(body, char(10)), '') FROM issue_comments WHERE issue_id = NEW.issue_id)
+			WHERE rowid = NEW.issue_id;
+		END;`,
+		`CREATE TRIGGER IF NOT EXISTS trg_issue_comments_fts_delete
+		AFTER DELETE ON issue_comments
+		FOR EACH ROW
+		BEGIN
+			UPDATE issues_fts
+			SET comments = (SELECT COALESCE(GROUP_CONCAT(body, char(10)), '') FROM issue_comments WHERE issue_id = OLD.issue_id)
+			WHERE rowid = OLD.issue_id;
+		END;`,
+	}
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("create search index: %w", err)
+		}
+	}
+
+	if existing > 0 {
+		return nil
+	}
+	// Databases created before the index existed need one backfill pass.
+	if _, err := db.Exec(`
+		INSERT INTO issues_fts(rowid, title, description, comments)
+		SELECT i.id, i.title, i.description,
+		       COALESCE((SELECT GROUP_CONCAT(c.body, char(10)) FROM issue_comments c WHERE c.issue_id = i.id), '')
+		FROM issues i`); err != nil {
+		return fmt.Errorf("backfill search index: %w", err)
+	}
 	return nil
 }
 
 // ensureIssuesColumns adds missing issues columns and indexes for upgrades.
-func ensureIssuesColumns(db *sql.DB) error {
+func ensureIssuesColumns(db execer) error {
 	rows, err := db.Query(`PRAGMA table_info(issues);`)
 	if err != nil {
 		return fmt.Errorf("inspect issues table columns: %w", err)
@@ -72,6 +381,8 @@ func ensureIssuesColumns(db *sql.DB) error {
 	hasPublicID := false
 	hasClaimedAt := false
 	hasClaimExpiresAt := false
+	hasClaimedBy := false
+	hasEstimate := false
 	for rows.Next() {
 		var cid int
 		var name string
@@ -91,6 +402,12 @@ func ensureIssuesColumns(db *sql.DB) error {
 		if name == "claim_expires_at" {
 			hasClaimExpiresAt = true
 		}
+		if name == "claimed_by" {
+			hasClaimedBy = true
+		}
+		if name == "estimate" {
+			hasEstimate = true
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return fmt.Errorf("iterate issues table metadata: %w", err)
@@ -111,6 +428,16 @@ func ensureIssuesColumns(db *sql.DB) error {
 			return fmt.Errorf("add claim_expires_at column: %w", err)
 		}
 	}
+	if !hasClaimedBy {
+		if _, err := db.Exec(`ALTER TABLE issues ADD COLUMN claimed_by TEXT`); err != nil {
+			return fmt.Errorf("add claimed_by column: %w", err)
+		}
+	}
+	if !hasEstimate {
+		if _, err := db.Exec(`ALTER TABLE issues ADD COLUMN estimate INTEGER`); err != nil {
+			return fmt.Errorf("add estimate column: %w", err)
+		}
+	}
 
 	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_public_id_unique ON issues(public_id)`); err != nil {
 		return fmt.Errorf("create unique public_id index: %w", err)
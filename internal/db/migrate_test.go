@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+// openTestDB opens an empty database in a temporary directory.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	sqlDB, err := Open(filepath.Join(t.TempDir(), DBFileName))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return sqlDB
+}
+
+func TestMigrateRecordsVersionAndIsIdempotent(t *testing.T) {
+	sqlDB := openTestDB(t)
+	if err := CheckVersion(sqlDB); !errors.Is(err, ErrSchemaOutdated) {
+		t.Fatalf("fresh db check = %v, want ErrSchemaOutdated", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := Migrate(sqlDB); err != nil {
+			t.Fatalf("migrate pass %d: %v", i, err)
+		}
+	}
+	status, err := Status(sqlDB)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.Current != LatestVersion() || len(status.Pending) != 0 {
+		t.Fatalf("unexpected status after migrate: %+v", status)
+	}
+	if err := CheckVersion(sqlDB); err != nil {
+		t.Fatalf("check after migrate: %v", err)
+	}
+}
+
+func TestMigrateUpgradesUnversionedLegacySchema(t *testing.T) {
+	sqlDB := openTestDB(t)
+	if _, err := sqlDB.Exec(`CREATE TABLE issues (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		type TEXT NOT NULL,
+		priority INTEGER NOT NULL DEFAULT 2,
+		status TEXT NOT NULL DEFAULT 'open',
+		parent_id INTEGER,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		closed_at DATETIME
+	)`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	if _, err := sqlDB.Exec(`INSERT INTO issues (title, type) VALUES ('Old work', 'task')`); err != nil {
+		t.Fatalf("insert legacy issue: %v", err)
+	}
+
+	if err := Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate legacy db: %v", err)
+	}
+	var publicID string
+	var hits int
+	if err := sqlDB.QueryRow(`SELECT public_id FROM issues WHERE id = 1`).Scan(&publicID); err != nil {
+		t.Fatalf("read public id: %v", err)
+	}
+	if err := sqlDB.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE issues_fts MATCH 'old'`).Scan(&hits); err != nil {
+		t.Fatalf("search backfill: %v", err)
+	}
+	if publicID != "legacy-1" || hits != 1 {
+		t.Fatalf("legacy upgrade: public_id = %q, search hits = %d", publicID, hits)
+	}
+}
+
+func TestMigrateRefusesNewerSchema(t *testing.T) {
+	sqlDB := openTestDB(t)
+	if _, err := sqlDB.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, LatestVersion()+1)); err != nil {
+		t.Fatalf("set user_version: %v", err)
+	}
+	if err := Migrate(sqlDB); !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("migrate = %v, want ErrSchemaTooNew", err)
+	}
+	if err := CheckVersion(sqlDB); !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("check = %v, want ErrSchemaTooNew", err)
+	}
+}
+
+func TestMigrateRollsBackFailedStep(t *testing.T) {
+	sqlDB := openTestDB(t)
+	steps := []Migration{
+		{Version: 1, Name: "create", up: func(tx execer) error {
+			_, err := tx.Exec(`CREATE TABLE notes (id INTEGER PRIMARY KEY)`)
+			return err
+		}},
+		{Version: 2, Name: "broken", up: func(tx execer) error {
+			if _, err := tx.Exec(`CREATE TABLE partial (id INTEGER PRIMARY KEY)`); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		}},
+	}
+
+	if err := migrate(sqlDB, steps); err == nil {
+		t.Fatal("expected failing step to error")
+	}
+	result, err := status(sqlDB, steps)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if result.Current != 1 || len(result.Pending) != 1 || result.Pending[0].Name != "broken" {
+		t.Fatalf("unexpected status after failed step: %+v", result)
+	}
+	var tables int
+	if err := sqlDB.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'partial'`).Scan(&tables); err != nil {
+		t.Fatalf("inspect tables: %v", err)
+	}
+	if tables != 0 {
+		t.Fatal("expected failed step to roll back its table")
+	}
+}
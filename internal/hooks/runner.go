@@ -0,0 +1,134 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/config"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// EnvHookDepth marks faz processes started from inside a hook so they do not fire hooks again.
+const EnvHookDepth = "FAZ_HOOK"
+
+// killGrace is how long a timed-out hook gets to release its output pipes after being killed.
+const killGrace = time.Second
+
+// Runner executes configured hook commands through the shell.
+type Runner struct {
+	hooks  config.Hooks
+	dir    string
+	output io.Writer
+	warn   func(message string)
+}
+
+// NewRunner builds a runner whose hooks run in dir and write their output to output.
+func NewRunner(hooks config.Hooks, dir string, output io.Writer) *Runner {
+	return &Runner{hooks: hooks, dir: dir, output: output}
+}
+
+// OnWarning also hands each failed notification hook to warn, for callers such
+// as the kanban board that cannot show the warning line written to the output.
+// warn may be called from several goroutines and must not block.
+func (r *Runner) OnWarning(warn func(message string)) {
+	r.warn = warn
+}
+
+// Has reports whether any hook is registered for event.
+func (r *Runner) Has(event string) bool {
+	return len(r.hooks.For(event)) > 0
+}
+
+// Before runs veto hooks in order and stops at the first one that fails.
+func (r *Runner) Before(payload model.HookPayload) error {
+	for _, hook := range r.hooks.For(payload.Event) {
+		if !hook.Matches(payload.Issue) {
+			continue
+		}
+		if err := r.run(hook, payload); err != nil {
+			return fmt.Errorf("hook %q: %w", hook.Command, err)
+		}
+	}
+	return nil
+}
+
+// After runs notification hooks; failures are reported but never undo the transition.
+func (r *Runner) After(payload model.HookPayload) {
+	for _, hook := range r.hooks.For(payload.Event) {
+		if !hook.Matches(payload.Issue) {
+			continue
+		}
+		if err := r.run(hook, payload); err != nil {
+			message := fmt.Sprintf("%s hook %q for %s: %v", payload.Event, hook.Command, payload.Issue.ID, err)
+			_, _ = fmt.Fprintf(r.output, "warning: %s\n", message)
+			if r.warn != nil {
+				r.warn(message)
+			}
+		}
+	}
+}
+
+// run executes one hook with the payload on stdin and FAZ_* variables in its environment.
+func (r *Runner) run(hook config.Hook, payload model.HookPayload) error {
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	timeout := r.hooks.TimeoutFor(hook)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), payloadEnv(payload, r.dir)...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = r.output
+	cmd.Stderr = r.output
+	cmd.WaitDelay = killGrace
+
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
+
+// payloadEnv flattens the payload into FAZ_* environment variables.
+func payloadEnv(payload model.HookPayload, dir string) []string {
+	issue := payload.Issue
+	env := []string{
+		EnvHookDepth + "=1",
+		"FAZ_EVENT=" + payload.Event,
+		"FAZ_ACTOR=" + payload.Actor,
+		"FAZ_PROJECT_DIR=" + dir,
+		"FAZ_ISSUE_ID=" + issue.ID,
+		"FAZ_ISSUE_TITLE=" + issue.Title,
+		"FAZ_ISSUE_TYPE=" + issue.Type,
+		"FAZ_ISSUE_STATUS=" + issue.Status,
+		"FAZ_ISSUE_PRIORITY=" + strconv.Itoa(issue.Priority),
+		"FAZ_ISSUE_LABELS=" + strings.Join(issue.Labels, ","),
+		"FAZ_ISSUE_PARENT=" + derefOr(issue.ParentID),
+		"FAZ_CLAIMED_BY=" + derefOr(issue.ClaimedBy),
+	}
+	if payload.DependsOn != nil {
+		env = append(env, "FAZ_DEPENDS_ON_ID="+payload.DependsOn.ID)
+	}
+	return env
+}
+
+func derefOr(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,96 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/config"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+func TestRunnerPassesPayloadOnStdinAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	runner := NewRunner(config.Hooks{
+		OnClose: []config.Hook{
+			{Command: `cat > payload.json; printf '%s %s %s' "$FAZ_EVENT" "$FAZ_ISSUE_ID" "$FAZ_ISSUE_PRIORITY" > env.txt`},
+			{Command: "touch bug-only", Types: []string{"bug"}},
+		},
+	}, dir, &strings.Builder{})
+
+	runner.After(model.HookPayload{
+		Event: model.HookOnClose,
+		Actor: "agent-7",
+		Issue: model.Issue{ID: "faz-ab12", Title: "Login", Type: "task", Priority: 1, Status: "closed"},
+	})
+
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	if err != nil {
+		t.Fatalf("read env output: %v", err)
+	}
+	if string(env) != "on_close faz-ab12 1" {
+		t.Fatalf("unexpected env: %q", env)
+	}
+	var payload model.HookPayload
+	data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	if err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Actor != "agent-7" || payload.Issue.Title != "Login" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bug-only")); !os.IsNotExist(err) {
+		t.Fatalf("expected type-filtered hook to be skipped, stat err = %v", err)
+	}
+}
+
+func TestRunnerBeforeVetoesOnFailureAndTimeout(t *testing.T) {
+	var output strings.Builder
+	runner := NewRunner(config.Hooks{
+		PreClose: []config.Hook{{Command: "echo tests failing >&2; exit 3"}},
+	}, t.TempDir(), &output)
+
+	err := runner.Before(model.HookPayload{Event: model.HookPreClose, Issue: model.Issue{ID: "faz-ab12"}})
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Fatalf("expected exit status veto, got %v", err)
+	}
+	if !strings.Contains(output.String(), "tests failing") {
+		t.Fatalf("expected hook stderr forwarded, got %q", output.String())
+	}
+
+	slow := NewRunner(config.Hooks{
+		PreClose: []config.Hook{{Command: "sleep 5", Timeout: config.Duration{Duration: 100 * time.Millisecond}}},
+	}, t.TempDir(), &output)
+	started := time.Now()
+	err = slow.Before(model.HookPayload{Event: model.HookPreClose, Issue: model.Issue{ID: "faz-ab12"}})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout veto, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("timeout took %s", elapsed)
+	}
+}
+
+func TestRunnerAfterReportsFailuresToWarn(t *testing.T) {
+	var output strings.Builder
+	runner := NewRunner(config.Hooks{
+		OnClose: []config.Hook{{Command: "echo notify down; exit 1"}},
+	}, t.TempDir(), &output)
+	var warnings []string
+	runner.OnWarning(func(message string) { warnings = append(warnings, message) })
+
+	runner.After(model.HookPayload{Event: model.HookOnClose, Issue: model.Issue{ID: "faz-ab12"}})
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "on_close hook") || !strings.Contains(warnings[0], "exit status 1") {
+		t.Fatalf("unexpected warnings: %q", warnings)
+	}
+	if !strings.Contains(output.String(), "notify down") || !strings.Contains(output.String(), "warning: "+warnings[0]) {
+		t.Fatalf("expected hook output and warning in the output, got %q", output.String())
+	}
+}
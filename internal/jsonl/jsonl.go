@@ -0,0 +1,208 @@
+// Package jsonl reads and writes the line-oriented issue export shared through Git.
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// Record kinds, one per line.
+const (
+	KindIssue      = "issue"
+	KindDependency = "dependency"
+	KindTombstone  = "tombstone"
+)
+
+// maxLineBytes bounds one line; descriptions are the only large field.
+const maxLineBytes = 16 << 20
+
+// issueLine is an issue record as written to the file.
+type issueLine struct {
+	Kind string `json:"kind"`
+	model.ExportedIssue
+}
+
+// dependencyLine is a dependency record as written to the file.
+type dependencyLine struct {
+	Kind string `json:"kind"`
+	model.Dependency
+}
+
+// tombstoneLine is a deleted-issue record as written to the file.
+type tombstoneLine struct {
+	Kind string `json:"kind"`
+	model.Tombstone
+}
+
+// Write encodes export deterministically: issues sorted by ID, dependencies sorted
+// by issue and blocker, then tombstones sorted by ID, one compact JSON object per
+// line with UTC timestamps.
+func Write(w io.Writer, export model.Export) error {
+	issues := append([]model.ExportedIssue(nil), export.Issues...)
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	deps := append([]model.Dependency(nil), export.Dependencies...)
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].IssueID != deps[j].IssueID {
+			return deps[i].IssueID < deps[j].IssueID
+		}
+		return deps[i].DependsOnID < deps[j].DependsOnID
+	})
+	tombstones := append([]model.Tombstone(nil), export.Tombstones...)
+	sort.Slice(tombstones, func(i, j int) bool { return tombstones[i].ID < tombstones[j].ID })
+
+	buffered := bufio.NewWriter(w)
+	for _, issue := range issues {
+		if err := writeLine(buffered, issueLine{Kind: KindIssue, ExportedIssue: normalizeIssue(issue)}); err != nil {
+			return err
+		}
+	}
+	for _, dep := range deps {
+		if err := writeLine(buffered, dependencyLine{Kind: KindDependency, Dependency: dep}); err != nil {
+			return err
+		}
+	}
+	for _, tombstone := range tombstones {
+		tombstone.DeletedAt = canonicalTime(tombstone.DeletedAt)
+		if err := writeLine(buffered, tombstoneLine{Kind: KindTombstone, Tombstone: tombstone}); err != nil {
+			return err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	return nil
+}
+
+// WriteFile writes export to path atomically so readers never see a partial file.
+func WriteFile(path string, export model.Export) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	defer func() { _ = os.Remove(temp.Name()) }()
+	if err := Write(temp, export); err != nil {
+		_ = temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	if err := os.Chmod(temp.Name(), 0o644); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("replace export file: %w", err)
+	}
+	return nil
+}
+
+// ReadFile decodes the export stored at path.
+func ReadFile(path string) (model.Export, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return model.Export{}, fmt.Errorf("open export: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	export, err := Read(file)
+	if err != nil {
+		return model.Export{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return export, nil
+}
+
+// Read decodes an export written by Write. Blank lines are skipped; unknown kinds are errors.
+func Read(r io.Reader) (model.Export, error) {
+	export := model.Export{
+		Issues:       make([]model.ExportedIssue, 0),
+		Dependencies: make([]model.Dependency, 0),
+		Tombstones:   make([]model.Tombstone, 0),
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var header struct {
+			Kind string `json:"kind"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return model.Export{}, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		switch header.Kind {
+		case KindIssue:
+			var record issueLine
+			if err := json.Unmarshal(line, &record); err != nil {
+				return model.Export{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if record.ID == "" {
+				return model.Export{}, fmt.Errorf("line %d: issue record without id", lineNo)
+			}
+			export.Issues = append(export.Issues, normalizeIssue(record.ExportedIssue))
+		case KindDependency:
+			var record dependencyLine
+			if err := json.Unmarshal(line, &record); err != nil {
+				return model.Export{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if record.IssueID == "" || record.DependsOnID == "" {
+				return model.Export{}, fmt.Errorf("line %d: dependency record needs issue_id and depends_on_id", lineNo)
+			}
+			export.Dependencies = append(export.Dependencies, record.Dependency)
+		case KindTombstone:
+			var record tombstoneLine
+			if err := json.Unmarshal(line, &record); err != nil {
+				return model.Export{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if record.ID == "" {
+				return model.Export{}, fmt.Errorf("line %d: tombstone record without id", lineNo)
+			}
+			record.DeletedAt = canonicalTime(record.DeletedAt)
+			export.Tombstones = append(export.Tombstones, record.Tombstone)
+		default:
+			return model.Export{}, fmt.Errorf("line %d: unknown record kind %q", lineNo, header.Kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return model.Export{}, fmt.Errorf("read export: %w", err)
+	}
+	return export, nil
+}
+
+// writeLine encodes one record without HTML escaping so text stays readable in diffs.
+func writeLine(w io.Writer, record any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(record); err != nil {
+		return fmt.Errorf("encode export record: %w", err)
+	}
+	return nil
+}
+
+// normalizeIssue puts an issue in canonical form: UTC second-precision times and sorted labels.
+func normalizeIssue(issue model.ExportedIssue) model.ExportedIssue {
+	labels := append([]string{}, issue.Labels...)
+	sort.Strings(labels)
+	issue.Labels = labels
+	issue.CreatedAt = canonicalTime(issue.CreatedAt)
+	issue.UpdatedAt = canonicalTime(issue.UpdatedAt)
+	if issue.ClosedAt != nil {
+		closedAt := canonicalTime(*issue.ClosedAt)
+		issue.ClosedAt = &closedAt
+	}
+	return issue
+}
+
+// canonicalTime matches the one-second precision SQLite stores.
+func canonicalTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Second)
+}
@@ -0,0 +1,73 @@
+package jsonl
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+func TestWriteIsSortedAndRoundTrips(t *testing.T) {
+	created := time.Date(2024, 5, 6, 7, 8, 9, 500, time.FixedZone("CET", 3600))
+	export := model.Export{
+		Issues: []model.ExportedIssue{
+			{ID: "faz-zz99", Title: "Later <b>", Type: "task", Status: "open", Labels: []string{"ui", "api"}, CreatedAt: created, UpdatedAt: created},
+			{ID: "faz-ab12", Title: "First", Type: "bug", Status: "closed", Labels: []string{}, CreatedAt: created, UpdatedAt: created, ClosedAt: &created},
+		},
+		Dependencies: []model.Dependency{
+			{IssueID: "faz-zz99", DependsOnID: "faz-ab12"},
+			{IssueID: "faz-ab12", DependsOnID: "faz-zz99"},
+		},
+	}
+
+	var first bytes.Buffer
+	if err := Write(&first, export); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(first.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), first.String())
+	}
+	for i, prefix := range []string{
+		`{"kind":"issue","id":"faz-ab12"`,
+		`{"kind":"issue","id":"faz-zz99","title":"Later <b>"`,
+		`{"kind":"dependency","issue_id":"faz-ab12"`,
+		`{"kind":"dependency","issue_id":"faz-zz99"`,
+	} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("line %d = %s, want prefix %s", i+1, lines[i], prefix)
+		}
+	}
+	if !strings.Contains(lines[1], `"labels":["api","ui"]`) || !strings.Contains(lines[1], `"created_at":"2024-05-06T06:08:09Z"`) {
+		t.Fatalf("issue line not canonical: %s", lines[1])
+	}
+
+	decoded, err := Read(strings.NewReader(first.String()))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var second bytes.Buffer
+	if err := Write(&second, decoded); err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	if first.String() != second.String() {
+		t.Fatalf("round trip changed output:\n%s\n---\n%s", first.String(), second.String())
+	}
+	if !reflect.DeepEqual(decoded.Issues[0].Labels, []string{}) {
+		t.Fatalf("expected empty labels, got %#v", decoded.Issues[0].Labels)
+	}
+}
+
+func TestReadReportsLineNumbers(t *testing.T) {
+	input := `{"kind":"issue","id":"faz-ab12","title":"A","type":"task","status":"open"}
+
+{"kind":"comment","id":"faz-ab12"}
+`
+	_, err := Read(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected line 3 error, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package jsonl
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// mergeFieldGroups lists the ExportedIssue fields merged together. Lifecycle fields
+// move as one so a merge never pairs an open status with a closed_at time.
+var mergeFieldGroups = [][]string{
+	{"Title"},
+	{"Description"},
+	{"Type"},
+	{"Priority"},
+	{"Estimate"},
+	{"Status", "ClosedAt", "ClaimedBy"},
+	{"ParentID"},
+	{"CreatedAt"},
+}
+
+// issueSide is one version of an issue: present, deleted, or absent when both are nil.
+type issueSide struct {
+	issue     *model.ExportedIssue
+	tombstone *model.Tombstone
+}
+
+// Merge combines two exports that diverged from base, record by record.
+//
+// Issues are keyed on public ID. A field group changed on one side only takes that
+// change; changed on both, the side with the later updated_at wins. Labels and
+// dependencies merge as sets, keeping additions and removals from both sides.
+// A deletion wins over an unchanged issue and loses to an edit made after it;
+// deletions are kept as tombstones.
+// The result is always complete, so the merge never leaves conflict markers.
+func Merge(base, ours, theirs model.Export) model.Export {
+	baseSides := exportSides(base)
+	ourSides := exportSides(ours)
+	theirSides := exportSides(theirs)
+
+	ids := make(map[string]struct{}, len(ourSides)+len(theirSides))
+	for _, sides := range []map[string]issueSide{baseSides, ourSides, theirSides} {
+		for id := range sides {
+			ids[id] = struct{}{}
+		}
+	}
+
+	merged := model.Export{
+		Issues:       make([]model.ExportedIssue, 0, len(ids)),
+		Dependencies: make([]model.Dependency, 0),
+		Tombstones:   make([]model.Tombstone, 0),
+	}
+	present := make(map[string]struct{}, len(ids))
+	for id := range ids {
+		issue, tombstone := mergeRecord(baseSides[id], ourSides[id], theirSides[id])
+		switch {
+		case issue != nil:
+			merged.Issues = append(merged.Issues, *issue)
+			present[id] = struct{}{}
+		case tombstone != nil:
+			merged.Tombstones = append(merged.Tombstones, *tombstone)
+		}
+	}
+	// A deleted parent leaves its children top-level, as deleting it locally does.
+	for i, issue := range merged.Issues {
+		if issue.ParentID == nil {
+			continue
+		}
+		if _, ok := present[*issue.ParentID]; !ok {
+			merged.Issues[i].ParentID = nil
+		}
+	}
+	sort.Slice(merged.Issues, func(i, j int) bool { return merged.Issues[i].ID < merged.Issues[j].ID })
+	sort.Slice(merged.Tombstones, func(i, j int) bool { return merged.Tombstones[i].ID < merged.Tombstones[j].ID })
+
+	for _, key := range mergeSet(dependencyKeys(base), dependencyKeys(ours), dependencyKeys(theirs)) {
+		dep := dependencyFromKey(key)
+		_, childOK := present[dep.IssueID]
+		_, blockerOK := present[dep.DependsOnID]
+		if childOK && blockerOK {
+			merged.Dependencies = append(merged.Dependencies, dep)
+		}
+	}
+	return merged
+}
+
+// mergeRecord resolves one issue ID across the three versions.
+func mergeRecord(base, ours, theirs issueSide) (*model.ExportedIssue, *model.Tombstone) {
+	switch {
+	case ours.issue != nil && theirs.issue != nil:
+		issue := mergeIssue(base.issue, *ours.issue, *theirs.issue)
+		return &issue, nil
+	case ours.issue != nil:
+		return survivesDeletion(base, ours, theirs)
+	case theirs.issue != nil:
+		return survivesDeletion(base, theirs, ours)
+	}
+	return nil, latestTombstone(base, ours, theirs)
+}
+
+// survivesDeletion resolves an issue kept on one side and missing on the other.
+// Missing means deleted when the other side has a tombstone or the base had the issue.
+func survivesDeletion(base, kept, other issueSide) (*model.ExportedIssue, *model.Tombstone) {
+	if other.tombstone == nil && base.issue == nil {
+		return kept.issue, nil
+	}
+	tombstone := latestTombstone(base, other)
+	if tombstone == nil {
+		// The deletion predates tombstones; date it by the last version both sides saw.
+		tombstone = &model.Tombstone{ID: kept.issue.ID, DeletedAt: base.issue.UpdatedAt}
+	}
+	edited := base.issue == nil || !reflect.DeepEqual(*kept.issue, *base.issue)
+	if edited && kept.issue.UpdatedAt.After(tombstone.DeletedAt) {
+		return kept.issue, nil
+	}
+	return nil, tombstone
+}
+
+// latestTombstone returns the most recent tombstone among sides, or nil.
+func latestTombstone(sides ...issueSide) *model.Tombstone {
+	var latest *model.Tombstone
+	for _, side := range sides {
+		if side.tombstone != nil && (latest == nil || side.tombstone.DeletedAt.After(latest.DeletedAt)) {
+			latest = side.tombstone
+		}
+	}
+	return latest
+}
+
+// mergeIssue merges two versions of an issue one field group at a time.
+// Without a base every differing group goes to the later writer.
+func mergeIssue(base *model.ExportedIssue, ours, theirs model.ExportedIssue) model.ExportedIssue {
+	theirsNewer := theirs.UpdatedAt.After(ours.UpdatedAt)
+	merged := ours
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	ourValue := reflect.ValueOf(ours)
+	theirValue := reflect.ValueOf(theirs)
+	var baseValue reflect.Value
+	if base != nil {
+		baseValue = reflect.ValueOf(*base)
+	}
+
+	for _, group := range mergeFieldGroups {
+		if sameFields(ourValue, theirValue, group) {
+			continue
+		}
+		takeTheirs := theirsNewer
+		if base != nil {
+			switch {
+			case sameFields(ourValue, baseValue, group):
+				takeTheirs = true
+			case sameFields(theirValue, baseValue, group):
+				takeTheirs = false
+			}
+		}
+		if takeTheirs {
+			for _, field := range group {
+				mergedValue.FieldByName(field).Set(theirValue.FieldByName(field))
+			}
+		}
+	}
+
+	var baseLabels []string
+	if base != nil {
+		baseLabels = base.Labels
+	}
+	merged.Labels = mergeSet(baseLabels, ours.Labels, theirs.Labels)
+	if theirsNewer {
+		merged.UpdatedAt = theirs.UpdatedAt
+	}
+	return merged
+}
+
+// sameFields reports whether two issue values agree on every field in group.
+func sameFields(a, b reflect.Value, group []string) bool {
+	for _, field := range group {
+		if !reflect.DeepEqual(a.FieldByName(field).Interface(), b.FieldByName(field).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeSet three-way merges string sets: it keeps the union of both sides minus
+// anything in base that either side removed. The result is sorted.
+func mergeSet(base, ours, theirs []string) []string {
+	inBase := stringSet(base)
+	inOurs := stringSet(ours)
+	inTheirs := stringSet(theirs)
+
+	merged := make([]string, 0, len(inOurs)+len(inTheirs))
+	seen := make(map[string]struct{}, len(inOurs)+len(inTheirs))
+	for _, value := range append(append([]string{}, ours...), theirs...) {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		_, wasBase := inBase[value]
+		_, keptOurs := inOurs[value]
+		_, keptTheirs := inTheirs[value]
+		if wasBase && (!keptOurs || !keptTheirs) {
+			continue
+		}
+		merged = append(merged, value)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// exportSides indexes an export's issues and tombstones by ID in canonical form.
+func exportSides(export model.Export) map[string]issueSide {
+	sides := make(map[string]issueSide, len(export.Issues)+len(export.Tombstones))
+	for _, issue := range export.Issues {
+		normalized := normalizeIssue(issue)
+		side := sides[issue.ID]
+		side.issue = &normalized
+		sides[issue.ID] = side
+	}
+	for _, tombstone := range export.Tombstones {
+		tombstone.DeletedAt = canonicalTime(tombstone.DeletedAt)
+		side := sides[tombstone.ID]
+		if side.issue == nil {
+			side.tombstone = &tombstone
+			sides[tombstone.ID] = side
+		}
+	}
+	return sides
+}
+
+// dependencyKeys encodes edges as strings so they merge as a set.
+func dependencyKeys(export model.Export) []string {
+	keys := make([]string, 0, len(export.Dependencies))
+	for _, dep := range export.Dependencies {
+		keys = append(keys, dep.IssueID+"\x00"+dep.DependsOnID)
+	}
+	return keys
+}
+
+// dependencyFromKey decodes an edge encoded by dependencyKeys.
+func dependencyFromKey(key string) model.Dependency {
+	issueID, dependsOnID, _ := strings.Cut(key, "\x00")
+	return model.Dependency{IssueID: issueID, DependsOnID: dependsOnID}
+}
+
+// stringSet builds a membership set.
+func stringSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
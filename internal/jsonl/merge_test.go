@@ -0,0 +1,94 @@
+package jsonl
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+func TestMergeTakesFieldChangesFromBothSides(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := model.Export{
+		Issues: []model.ExportedIssue{
+			{ID: "faz-ab12", Title: "Login", Type: "task", Priority: 2, Status: "open", Labels: []string{"ui", "old"}, CreatedAt: t0, UpdatedAt: t0},
+			{ID: "faz-cd34", Title: "Cache", Type: "task", Priority: 2, Status: "open", CreatedAt: t0, UpdatedAt: t0},
+		},
+		Dependencies: []model.Dependency{{IssueID: "faz-cd34", DependsOnID: "faz-ab12"}},
+	}
+	ours := model.Export{
+		Issues: []model.ExportedIssue{
+			{ID: "faz-ab12", Title: "Login v2", Type: "task", Priority: 2, Status: "open", Labels: []string{"ui", "api"}, CreatedAt: t0, UpdatedAt: t0.Add(time.Hour)},
+			{ID: "faz-cd34", Title: "Cache ours", Type: "task", Priority: 2, Status: "open", CreatedAt: t0, UpdatedAt: t0.Add(time.Hour)},
+		},
+	}
+	closedAt := t0.Add(2 * time.Hour)
+	theirs := model.Export{
+		Issues: []model.ExportedIssue{
+			{ID: "faz-ab12", Title: "Login", Type: "task", Priority: 0, Status: "closed", ClosedAt: &closedAt, Labels: []string{"ui", "old", "auth"}, CreatedAt: t0, UpdatedAt: closedAt},
+			{ID: "faz-cd34", Title: "Cache theirs", Type: "task", Priority: 2, Status: "open", CreatedAt: t0, UpdatedAt: t0.Add(2 * time.Hour)},
+			{ID: "faz-ef56", Title: "New", Type: "bug", Priority: 1, Status: "open", CreatedAt: t0, UpdatedAt: t0},
+		},
+		Dependencies: []model.Dependency{
+			{IssueID: "faz-cd34", DependsOnID: "faz-ab12"},
+			{IssueID: "faz-ef56", DependsOnID: "faz-ab12"},
+		},
+	}
+
+	merged := Merge(base, ours, theirs)
+	if len(merged.Issues) != 3 {
+		t.Fatalf("expected 3 issues, got %+v", merged.Issues)
+	}
+	login := merged.Issues[0]
+	if login.Title != "Login v2" || login.Priority != 0 || login.Status != "closed" || login.ClosedAt == nil {
+		t.Fatalf("expected one-sided changes from both sides, got %+v", login)
+	}
+	if !reflect.DeepEqual(login.Labels, []string{"api", "auth", "ui"}) {
+		t.Fatalf("labels = %v", login.Labels)
+	}
+	if !login.UpdatedAt.Equal(closedAt) {
+		t.Fatalf("updated_at = %v, want %v", login.UpdatedAt, closedAt)
+	}
+	if merged.Issues[1].Title != "Cache theirs" {
+		t.Fatalf("expected later writer to win a conflicting title, got %q", merged.Issues[1].Title)
+	}
+	want := []model.Dependency{{IssueID: "faz-ef56", DependsOnID: "faz-ab12"}}
+	if !reflect.DeepEqual(merged.Dependencies, want) {
+		t.Fatalf("dependencies = %+v, want %+v", merged.Dependencies, want)
+	}
+}
+
+func TestMergeResolvesDeletionsWithTombstones(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	issue := func(id, title string, updated time.Time) model.ExportedIssue {
+		return model.ExportedIssue{ID: id, Title: title, Type: "task", Status: "open", Labels: []string{}, CreatedAt: t0, UpdatedAt: updated}
+	}
+	base := model.Export{Issues: []model.ExportedIssue{
+		issue("faz-ab12", "Unchanged", t0),
+		issue("faz-cd34", "Edited later", t0),
+		issue("faz-ef56", "Edited earlier", t0),
+	}}
+	ours := model.Export{Issues: []model.ExportedIssue{
+		issue("faz-ab12", "Unchanged", t0),
+		issue("faz-cd34", "Edited later!", t0.Add(3*time.Hour)),
+		issue("faz-ef56", "Edited earlier!", t0.Add(time.Hour)),
+	}}
+	theirs := model.Export{Tombstones: []model.Tombstone{
+		{ID: "faz-ab12", DeletedAt: t0.Add(2 * time.Hour)},
+		{ID: "faz-cd34", DeletedAt: t0.Add(2 * time.Hour)},
+		{ID: "faz-ef56", DeletedAt: t0.Add(2 * time.Hour)},
+	}}
+
+	merged := Merge(base, ours, theirs)
+	if len(merged.Issues) != 1 || merged.Issues[0].ID != "faz-cd34" {
+		t.Fatalf("expected only the later edit to survive, got %+v", merged.Issues)
+	}
+	var deleted []string
+	for _, tombstone := range merged.Tombstones {
+		deleted = append(deleted, tombstone.ID)
+	}
+	if !reflect.DeepEqual(deleted, []string{"faz-ab12", "faz-ef56"}) {
+		t.Fatalf("tombstones = %v", deleted)
+	}
+}
@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// latestProtocolVersion is offered when the client asks for a version faz does not know.
+const latestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists MCP revisions whose tool surface faz implements.
+var supportedProtocolVersions = map[string]struct{}{
+	"2024-11-05":          {},
+	"2025-03-26":          {},
+	latestProtocolVersion: {},
+}
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessageBytes bounds one newline-delimited JSON-RPC message.
+const maxMessageBytes = 4 << 20
+
+// Server answers MCP JSON-RPC requests over a newline-delimited stream.
+type Server struct {
+	svc     *service.IssueService
+	actor   string
+	version string
+	tools   []tool
+}
+
+// request is one incoming JSON-RPC message; a missing ID marks a notification.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is one outgoing JSON-RPC reply.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC protocol-level failure.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer builds an MCP server exposing faz tools backed by svc; notes are authored by actor.
+func NewServer(svc *service.IssueService, actor, version string) *Server {
+	if version == "" {
+		version = "dev"
+	}
+	return &Server{svc: svc, actor: actor, version: version, tools: issueTools()}
+}
+
+// Serve reads requests from in and writes replies to out until in is exhausted.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageBytes)
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		reply := s.handle(line)
+		if reply == nil {
+			continue
+		}
+		if err := encoder.Encode(reply); err != nil {
+			return fmt.Errorf("write mcp response: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read mcp request: %w", err)
+	}
+	return nil
+}
+
+// handle dispatches one raw message and returns the reply, or nil for notifications.
+func (s *Server) handle(raw []byte) *response {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error"}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req)
+	case "ping":
+		return &response{JSONRPC: "2.0", ID: req.ID, Result: struct{}{}}
+	case "tools/list":
+		return &response{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{"tools": s.toolDescriptors()}}
+	case "tools/call":
+		return s.callTool(req)
+	default:
+		return errorResponse(req.ID, codeMethodNotFound, fmt.Sprintf("method %q not found", req.Method))
+	}
+}
+
+// initialize negotiates the protocol version and advertises tool support.
+func (s *Server) initialize(req request) *response {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, "invalid initialize params")
+		}
+	}
+	version := latestProtocolVersion
+	if _, ok := supportedProtocolVersions[params.ProtocolVersion]; ok {
+		version = params.ProtocolVersion
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "faz", "version": s.version},
+		"instructions":    "faz tracks project tasks. Call faz_ready to find unblocked work, faz_claim before starting it, faz_comment to log progress, and faz_close when done.",
+	}}
+}
+
+// callTool runs one tool; tool failures are reported in the result so the model can react.
+func (s *Server) callTool(req request) *response {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return errorResponse(req.ID, codeInvalidParams, "invalid tools/call params")
+	}
+	for _, candidate := range s.tools {
+		if candidate.name != params.Name {
+			continue
+		}
+		arguments := params.Arguments
+		if len(arguments) == 0 || string(arguments) == "null" {
+			arguments = json.RawMessage("{}")
+		}
+		payload, err := candidate.run(s, arguments)
+		if err != nil {
+			return &response{JSONRPC: "2.0", ID: req.ID, Result: toolResult(err.Error(), true)}
+		}
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return &response{JSONRPC: "2.0", ID: req.ID, Result: toolResult(fmt.Sprintf("encode result: %v", err), true)}
+		}
+		return &response{JSONRPC: "2.0", ID: req.ID, Result: toolResult(string(encoded), false)}
+	}
+	return errorResponse(req.ID, codeInvalidParams, fmt.Sprintf("unknown tool %q", params.Name))
+}
+
+// toolDescriptors lists tools in the shape expected by tools/list.
+func (s *Server) toolDescriptors() []map[string]any {
+	descriptors := make([]map[string]any, 0, len(s.tools))
+	for _, t := range s.tools {
+		descriptors = append(descriptors, map[string]any{
+			"name":        t.name,
+			"description": t.description,
+			"inputSchema": t.schema,
+		})
+	}
+	return descriptors
+}
+
+// toolResult wraps text in an MCP tool call result.
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// errorResponse builds a JSON-RPC error reply.
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
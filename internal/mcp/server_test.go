@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// newTestServer builds an MCP server over a migrated temporary project database.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	dbPath, err := db.EnsureProjectFiles(t.TempDir())
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+	issueRepo := repo.NewIssueRepo(sqlDB)
+	issueRepo.SetActor("agent-7")
+	return NewServer(service.NewIssueService(issueRepo, "faz"), "agent-7", "test")
+}
+
+// rpcReply is a decoded JSON-RPC response with a tool-call shaped result.
+type rpcReply struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// exchange runs newline-delimited requests through the server and decodes each reply.
+func exchange(t *testing.T, server *Server, requests ...string) []rpcReply {
+	t.Helper()
+	var out strings.Builder
+	if err := server.Serve(strings.NewReader(strings.Join(requests, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	replies := make([]rpcReply, 0, len(requests))
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var reply rpcReply
+		if err := json.Unmarshal(scanner.Bytes(), &reply); err != nil {
+			t.Fatalf("decode reply %q: %v", scanner.Text(), err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// callTool invokes one tool and returns its text payload and error flag.
+func callTool(t *testing.T, server *Server, name string, arguments string) (string, bool) {
+	t.Helper()
+	request := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + name + `","arguments":` + arguments + `}}`
+	replies := exchange(t, server, request)
+	if len(replies) != 1 || replies[0].Error != nil {
+		t.Fatalf("unexpected replies for %s: %+v", name, replies)
+	}
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(replies[0].Result, &result); err != nil {
+		t.Fatalf("decode %s result: %v", name, err)
+	}
+	if len(result.Content) != 1 {
+		t.Fatalf("expected one content block from %s, got %+v", name, result)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestServerHandshakeAndToolList(t *testing.T) {
+	server := newTestServer(t)
+	replies := exchange(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`not json`,
+	)
+	if len(replies) != 4 {
+		t.Fatalf("expected 4 replies (notification gets none), got %d", len(replies))
+	}
+
+	var initialized struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(replies[0].Result, &initialized); err != nil {
+		t.Fatalf("decode initialize: %v", err)
+	}
+	if initialized.ProtocolVersion != "2025-03-26" || initialized.ServerInfo.Name != "faz" {
+		t.Fatalf("unexpected initialize result: %s", replies[0].Result)
+	}
+
+	var listed struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(replies[1].Result, &listed); err != nil {
+		t.Fatalf("decode tools/list: %v", err)
+	}
+	names := make([]string, 0, len(listed.Tools))
+	for _, tool := range listed.Tools {
+		names = append(names, tool.Name)
+	}
+	for _, want := range []string{"faz_ready", "faz_claim", "faz_create", "faz_close", "faz_show", "faz_dep_add"} {
+		if !strings.Contains(strings.Join(names, ","), want) {
+			t.Fatalf("tools/list missing %s: %v", want, names)
+		}
+	}
+
+	if replies[2].Error == nil || replies[2].Error.Code != codeMethodNotFound {
+		t.Fatalf("expected method not found, got %+v", replies[2])
+	}
+	if replies[3].Error == nil || replies[3].Error.Code != codeParseError {
+		t.Fatalf("expected parse error, got %+v", replies[3])
+	}
+}
+
+func TestServerToolsDriveIssueWorkflow(t *testing.T) {
+	server := newTestServer(t)
+
+	text, isError := callTool(t, server, "faz_create", `{"title":"Design","priority":1}`)
+	if isError {
+		t.Fatalf("create design: %s", text)
+	}
+	var design model.Issue
+	if err := json.Unmarshal([]byte(text), &design); err != nil {
+		t.Fatalf("decode created issue: %v", err)
+	}
+	text, _ = callTool(t, server, "faz_create", `{"title":"Build","priority":0}`)
+	var build model.Issue
+	if err := json.Unmarshal([]byte(text), &build); err != nil {
+		t.Fatalf("decode created issue: %v", err)
+	}
+
+	if text, isError := callTool(t, server, "faz_dep_add", `{"issue":"`+build.ID+`","depends_on":"`+design.ID+`"}`); isError {
+		t.Fatalf("dep add: %s", text)
+	}
+	if text, isError := callTool(t, server, "faz_dep_add", `{"issue":"`+design.ID+`","depends_on":"`+build.ID+`"}`); !isError || !strings.Contains(text, "cycle") {
+		t.Fatalf("expected cycle rejection, got %s", text)
+	}
+
+	text, _ = callTool(t, server, "faz_ready", `{}`)
+	var ready []model.Issue
+	if err := json.Unmarshal([]byte(text), &ready); err != nil {
+		t.Fatalf("decode ready: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != design.ID {
+		t.Fatalf("expected only the unblocked issue to be ready, got %+v", ready)
+	}
+
+	text, isError = callTool(t, server, "faz_claim", `{"next":true}`)
+	if isError {
+		t.Fatalf("claim next: %s", text)
+	}
+	var claimed model.Issue
+	if err := json.Unmarshal([]byte(text), &claimed); err != nil {
+		t.Fatalf("decode claimed: %v", err)
+	}
+	if claimed.ID != design.ID || claimed.ClaimedBy == nil || *claimed.ClaimedBy != "agent-7" {
+		t.Fatalf("unexpected claimed issue: %+v", claimed)
+	}
+	if text, isError := callTool(t, server, "faz_claim", `{"id":"`+design.ID+`"}`); !isError || !strings.Contains(text, "already claimed") {
+		t.Fatalf("expected already claimed error, got %s", text)
+	}
+
+	if text, isError := callTool(t, server, "faz_comment", `{"id":"`+design.ID+`","text":"Wireframes done"}`); isError {
+		t.Fatalf("comment: %s", text)
+	}
+	if text, isError := callTool(t, server, "faz_close", `{"ids":["`+design.ID+`"]}`); isError {
+		t.Fatalf("close: %s", text)
+	}
+
+	text, _ = callTool(t, server, "faz_show", `{"id":"`+build.ID+`"}`)
+	var detail issueDetail
+	if err := json.Unmarshal([]byte(text), &detail); err != nil {
+		t.Fatalf("decode show: %v", err)
+	}
+	if len(detail.Dependencies) != 1 || detail.Dependencies[0].Status != "closed" {
+		t.Fatalf("expected closed blocker in show, got %+v", detail.Dependencies)
+	}
+
+	if text, isError := callTool(t, server, "faz_show", `{"id":"`+design.ID+`","verbose":true}`); !isError || !strings.Contains(text, "invalid arguments") {
+		t.Fatalf("expected unknown argument rejection, got %s", text)
+	}
+}
@@ -0,0 +1,371 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
+)
+
+// showCommentLimit caps how many notes faz_show returns, like `faz show`.
+const showCommentLimit = 5
+
+// tool is one MCP tool backed by the issue service.
+type tool struct {
+	name        string
+	description string
+	schema      map[string]any
+	run         func(s *Server, arguments json.RawMessage) (any, error)
+}
+
+// issueDetail is the faz_show payload, matching `faz show --json`.
+type issueDetail struct {
+	Issue        model.Issue     `json:"issue"`
+	Children     []model.Issue   `json:"children"`
+	Dependencies []model.Issue   `json:"dependencies"`
+	Dependents   []model.Issue   `json:"dependents"`
+	Comments     []model.Comment `json:"comments"`
+}
+
+// filterArgs are the shared ready/claim-next filters.
+type filterArgs struct {
+	Type       string   `json:"type"`
+	Parent     string   `json:"parent"`
+	Labels     []string `json:"labels"`
+	LabelMatch string   `json:"label_match"`
+}
+
+// issueTools returns the tools exposed by `faz mcp`.
+func issueTools() []tool {
+	filterProperties := map[string]any{
+		"type":        stringProperty("Only this issue type (task, bug, feature, chore, decision)"),
+		"parent":      stringProperty("Only children of this parent issue ID"),
+		"labels":      arrayProperty("Only issues with these labels"),
+		"label_match": stringProperty("How labels combine: any (default), all or none"),
+	}
+
+	return []tool{
+		{
+			name:        "faz_ready",
+			description: "List unblocked open issues that are not claimed, highest priority first.",
+			schema:      objectSchema(filterProperties),
+			run:         runReady,
+		},
+		{
+			name:        "faz_claim",
+			description: "Claim an issue (set in_progress with a lease) before working on it. Pass id, or next=true to atomically claim the best ready issue matching the filters.",
+			schema: objectSchema(mergeProperties(filterProperties, map[string]any{
+				"id":   stringProperty("Issue ID to claim"),
+				"next": map[string]any{"type": "boolean", "description": "Claim the highest-priority ready issue instead of a specific ID"},
+				"ttl":  stringProperty("Lease duration such as 10m or 1h (default 10m)"),
+			})),
+			run: runClaim,
+		},
+		{
+			name:        "faz_create",
+			description: "Create an issue and return it.",
+			schema: objectSchema(map[string]any{
+				"title":       stringProperty("Short issue title"),
+				"description": stringProperty("Why the issue exists and what needs to be done"),
+				"type":        stringProperty("epic, task (default), bug, feature, chore or decision"),
+				"priority":    map[string]any{"type": "integer", "minimum": 0, "maximum": 3, "description": "0 (highest) to 3, default 2"},
+				"parent":      stringProperty("Parent issue ID, usually an epic"),
+				"labels":      arrayProperty("Labels to attach"),
+				"estimate":    map[string]any{"type": "integer", "minimum": 0, "description": "Effort estimate used to weight faz plan"},
+			}, "title"),
+			run: runCreate,
+		},
+		{
+			name:        "faz_close",
+			description: "Close one or more finished issues.",
+			schema: objectSchema(map[string]any{
+				"ids": arrayProperty("Issue IDs to close"),
+			}, "ids"),
+			run: runClose,
+		},
+		{
+			name:        "faz_show",
+			description: "Show an issue with its children, blockers, dependents and latest notes.",
+			schema: objectSchema(map[string]any{
+				"id": stringProperty("Issue ID"),
+			}, "id"),
+			run: runShow,
+		},
+		{
+			name:        "faz_dep_add",
+			description: "Record that issue depends on depends_on (depends_on blocks issue). Cycles are rejected.",
+			schema: objectSchema(map[string]any{
+				"issue":      stringProperty("Blocked issue ID"),
+				"depends_on": stringProperty("Blocking issue ID"),
+			}, "issue", "depends_on"),
+			run: runDepAdd,
+		},
+		{
+			name:        "faz_comment",
+			description: "Append a work-log note to an issue.",
+			schema: objectSchema(map[string]any{
+				"id":   stringProperty("Issue ID"),
+				"text": stringProperty("Note text"),
+			}, "id", "text"),
+			run: runComment,
+		},
+	}
+}
+
+func runReady(s *Server, arguments json.RawMessage) (any, error) {
+	var args filterArgs
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	filter, err := args.listFilter()
+	if err != nil {
+		return nil, err
+	}
+	return s.svc.Ready(filter)
+}
+
+func runClaim(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		filterArgs
+		ID   string `json:"id"`
+		Next bool   `json:"next"`
+		TTL  string `json:"ttl"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	ttl := s.svc.Settings().DefaultClaimTTL
+	if args.TTL != "" {
+		parsed, err := time.ParseDuration(args.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ttl %q", args.TTL)
+		}
+		ttl = parsed
+	}
+
+	var id string
+	switch {
+	case args.Next && args.ID != "":
+		return nil, fmt.Errorf("pass either id or next, not both")
+	case args.Next:
+		filter, err := args.listFilter()
+		if err != nil {
+			return nil, err
+		}
+		id, err = s.svc.ClaimNext(filter, ttl)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		normalized, err := service.NormalizeIssueID(args.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.svc.Claim(normalized, ttl); err != nil {
+			return nil, err
+		}
+		id = normalized
+	}
+	return s.svc.Get(id)
+}
+
+func runCreate(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Type        string   `json:"type"`
+		Priority    *int     `json:"priority"`
+		Parent      string   `json:"parent"`
+		Labels      []string `json:"labels"`
+		Estimate    *int     `json:"estimate"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	issue := model.Issue{
+		Title:       args.Title,
+		Description: strings.TrimSpace(args.Description),
+		Type:        args.Type,
+		Priority:    s.svc.Settings().DefaultPriority,
+		Status:      "open",
+		Labels:      args.Labels,
+		Estimate:    args.Estimate,
+	}
+	if issue.Type == "" {
+		issue.Type = s.svc.Settings().DefaultType
+	}
+	if args.Priority != nil {
+		issue.Priority = *args.Priority
+	}
+	if strings.TrimSpace(args.Parent) != "" {
+		parent, err := service.NormalizeIssueID(args.Parent)
+		if err != nil {
+			return nil, err
+		}
+		issue.ParentID = &parent
+	}
+	id, err := s.svc.Create(issue)
+	if err != nil {
+		return nil, err
+	}
+	return s.svc.Get(id)
+}
+
+func runClose(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		IDs []string `json:"ids"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if len(args.IDs) == 0 {
+		return nil, fmt.Errorf("ids is required")
+	}
+	closed := make([]model.Issue, 0, len(args.IDs))
+	for _, raw := range args.IDs {
+		id, err := service.NormalizeIssueID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.svc.Close(id); err != nil {
+			return nil, err
+		}
+		issue, err := s.svc.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		closed = append(closed, issue)
+	}
+	return closed, nil
+}
+
+func runShow(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	id, err := service.NormalizeIssueID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := s.svc.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	children, err := s.svc.Children(id)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := s.svc.Dependencies(id)
+	if err != nil {
+		return nil, err
+	}
+	dependents, err := s.svc.Dependents(id)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.svc.Comments(id, showCommentLimit)
+	if err != nil {
+		return nil, err
+	}
+	return issueDetail{Issue: issue, Children: children, Dependencies: dependencies, Dependents: dependents, Comments: comments}, nil
+}
+
+func runDepAdd(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Issue     string `json:"issue"`
+		DependsOn string `json:"depends_on"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	issueID, err := service.NormalizeIssueID(args.Issue)
+	if err != nil {
+		return nil, err
+	}
+	dependsOnID, err := service.NormalizeIssueID(args.DependsOn)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.AddDependency(issueID, dependsOnID); err != nil {
+		return nil, err
+	}
+	return model.Dependency{IssueID: issueID, DependsOnID: dependsOnID}, nil
+}
+
+func runComment(s *Server, arguments json.RawMessage) (any, error) {
+	var args struct {
+		ID   string `json:"id"`
+		Text string `json:"text"`
+	}
+	if err := decodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	id, err := service.NormalizeIssueID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.svc.Comment(id, s.actor, args.Text)
+}
+
+// listFilter converts tool filter arguments into a ListFilter.
+func (f filterArgs) listFilter() (model.ListFilter, error) {
+	filter := model.ListFilter{Type: f.Type, Labels: f.Labels, LabelMatch: f.LabelMatch}
+	if strings.TrimSpace(f.Parent) != "" {
+		parent, err := service.NormalizeIssueID(f.Parent)
+		if err != nil {
+			return model.ListFilter{}, err
+		}
+		filter.ParentID = parent
+	}
+	return filter, nil
+}
+
+// decodeArguments strictly decodes tool arguments so typos surface as errors.
+func decodeArguments(arguments json.RawMessage, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(arguments))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// objectSchema builds a JSON Schema object with the given properties and required keys.
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mergeProperties returns a new property map holding both inputs.
+func mergeProperties(base, extra map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(extra))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
+
+func stringProperty(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func arrayProperty(description string) map[string]any {
+	return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": description}
+}
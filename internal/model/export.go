@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// ExportedIssue is the shareable form of an issue written to the JSONL export.
+// Lease timestamps stay local; labels are sorted so lines diff cleanly.
+type ExportedIssue struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Priority    int        `json:"priority"`
+	Estimate    *int       `json:"estimate"`
+	Status      string     `json:"status"`
+	ClaimedBy   *string    `json:"claimed_by"`
+	ParentID    *string    `json:"parent_id"`
+	Labels      []string   `json:"labels"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+// Tombstone records that an issue was deleted so merges and imports do not resurrect it.
+type Tombstone struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Export is the full shareable content of a task store.
+type Export struct {
+	Issues       []ExportedIssue
+	Dependencies []Dependency
+	Tombstones   []Tombstone
+}
+
+// Import conflict reasons reported by `faz import`.
+const (
+	ImportConflictLocalNewer    = "local_newer"
+	ImportConflictMissingParent = "missing_parent"
+	ImportConflictMissingIssue  = "missing_issue"
+	ImportConflictCycle         = "dependency_cycle"
+)
+
+// ImportConflict is one record import kept local or could not apply.
+type ImportConflict struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id,omitempty"`
+	Reason      string `json:"reason"`
+	Detail      string `json:"detail"`
+}
+
+// ImportReport summarizes what an import changed.
+type ImportReport struct {
+	Created           []string         `json:"created"`
+	Updated           []string         `json:"updated"`
+	Deleted           []string         `json:"deleted"`
+	Unchanged         int              `json:"unchanged"`
+	DependenciesAdded int              `json:"dependencies_added"`
+	Conflicts         []ImportConflict `json:"conflicts"`
+	DryRun            bool             `json:"dry_run"`
+}
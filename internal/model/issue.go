@@ -4,27 +4,156 @@ import "time"
 
 // Issue holds a tracked work item and its lifecycle metadata.
 type Issue struct {
-	ID             string
-	Title          string
-	Description    string
-	Type           string
-	Priority       int
-	Status         string
-	ClaimedAt      *time.Time
-	ClaimExpiresAt *time.Time
-	ParentID       *string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	ClosedAt       *time.Time
-	InternalID     int64
-	ParentInternal *int64
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Type           string     `json:"type"`
+	Priority       int        `json:"priority"`
+	Estimate       *int       `json:"estimate"`
+	Status         string     `json:"status"`
+	ClaimedAt      *time.Time `json:"claimed_at"`
+	ClaimExpiresAt *time.Time `json:"claim_expires_at"`
+	ClaimedBy      *string    `json:"claimed_by"`
+	ClaimWorktree  *string    `json:"claim_worktree"`
+	ClaimBranch    *string    `json:"claim_branch"`
+	ParentID       *string    `json:"parent_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+	Labels         []string   `json:"labels"`
+	InternalID     int64      `json:"-"`
+	ParentInternal *int64     `json:"-"`
 }
 
+// Label match modes for ListFilter.LabelMatch.
+const (
+	LabelMatchAny  = "any"
+	LabelMatchAll  = "all"
+	LabelMatchNone = "none"
+)
+
 // ListFilter defines optional filters for list queries.
 type ListFilter struct {
-	Type     string
-	Status   string
-	Priority *int
-	ParentID string
-	All      bool
+	Type        string
+	Status      string
+	Priority    *int
+	PriorityMax *int
+	ParentID    string
+	All         bool
+	Labels      []string
+	LabelMatch  string
+	// IDs, when set, restricts the results to these public IDs.
+	IDs []string
+}
+
+// Comment is one work-log note attached to an issue.
+type Comment struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LabelCount summarizes how many issues carry one label.
+type LabelCount struct {
+	Name   string `json:"name"`
+	Issues int64  `json:"issues"`
+	Open   int64  `json:"open"`
+}
+
+// Issue event actions recorded in the audit history.
+const (
+	EventCreated           = "created"
+	EventUpdated           = "updated"
+	EventClosed            = "closed"
+	EventReopened          = "reopened"
+	EventClaimed           = "claimed"
+	EventReleased          = "released"
+	EventDeleted           = "deleted"
+	EventDependencyAdded   = "dependency_added"
+	EventDependencyRemoved = "dependency_removed"
+	EventLabelAdded        = "label_added"
+	EventLabelRemoved      = "label_removed"
+	EventCommented         = "commented"
+	EventLeaseExpired      = "lease_expired"
+)
+
+// Hook events users can attach commands to in .faz/config.toml.
+// pre_close runs before the transition and can veto it; the rest run after.
+const (
+	HookOnCreate       = "on_create"
+	HookOnClaim        = "on_claim"
+	HookPreClose       = "pre_close"
+	HookOnClose        = "on_close"
+	HookOnReopen       = "on_reopen"
+	HookOnLeaseExpired = "on_lease_expired"
+	HookOnDepAdded     = "on_dep_added"
+)
+
+// HookEvents lists every hook event in lifecycle order.
+var HookEvents = []string{HookOnCreate, HookOnClaim, HookPreClose, HookOnClose, HookOnReopen, HookOnLeaseExpired, HookOnDepAdded}
+
+// HookPayload is the JSON document a hook command receives on stdin.
+type HookPayload struct {
+	Event     string `json:"event"`
+	Actor     string `json:"actor"`
+	Issue     Issue  `json:"issue"`
+	DependsOn *Issue `json:"depends_on,omitempty"`
+}
+
+// ChangePollInterval is how often watchers check the change log for new events.
+const ChangePollInterval = 500 * time.Millisecond
+
+// ChangeBatchSize caps how many change-log events one poll reads.
+const ChangeBatchSize = 500
+
+// IssueEvent is one append-only audit record of a change to an issue.
+// Events reference the public ID so history outlives deleted issues.
+// ID doubles as the change-log sequence: it only grows, in commit order.
+type IssueEvent struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Action    string    `json:"action"`
+	Field     string    `json:"field,omitempty"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Dependency problem kinds reported by `faz dep check`.
+const (
+	DependencyProblemCycle        = "cycle"
+	DependencyProblemMissingIssue = "missing_issue"
+	DependencyProblemClosedParent = "closed_parent"
+)
+
+// DependencyProblem describes one unhealthy edge or cycle in the dependency graph.
+type DependencyProblem struct {
+	Kind        string   `json:"kind"`
+	IssueID     string   `json:"issue_id"`
+	DependsOnID string   `json:"depends_on_id,omitempty"`
+	Path        []string `json:"path,omitempty"`
+	Detail      string   `json:"detail"`
+}
+
+// Dependency is one "IssueID depends on DependsOnID" edge.
+type Dependency struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+}
+
+// Markers wrapped around matched terms in SearchResult.Snippet.
+const (
+	SearchHighlightStart = "**"
+	SearchHighlightEnd   = "**"
+)
+
+// SearchResult is one full-text match with its relevance and a highlighted excerpt.
+// Lower Rank values are better matches.
+type SearchResult struct {
+	Issue   Issue   `json:"issue"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
 }
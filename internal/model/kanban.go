@@ -0,0 +1,38 @@
+package model
+
+// Kanban board columns users can list in kanban.columns.
+// Open issues land in BLOCKED or READY when those columns are shown and in TO DO otherwise.
+const (
+	KanbanColumnTodo    = "todo"
+	KanbanColumnBlocked = "blocked"
+	KanbanColumnReady   = "ready"
+	KanbanColumnClaimed = "claimed"
+	KanbanColumnDone    = "done"
+)
+
+// KanbanColumns lists every kanban column name.
+var KanbanColumns = []string{KanbanColumnTodo, KanbanColumnBlocked, KanbanColumnReady, KanbanColumnClaimed, KanbanColumnDone}
+
+// DefaultKanbanColumns is the board shown without kanban.columns.
+var DefaultKanbanColumns = []string{KanbanColumnTodo, KanbanColumnClaimed, KanbanColumnDone}
+
+// Kanban swimlane groupings users can set in kanban.swimlanes.
+const (
+	KanbanSwimlanesNone     = "none"
+	KanbanSwimlanesPriority = "priority"
+	KanbanSwimlanesType     = "type"
+	KanbanSwimlanesLabel    = "label"
+)
+
+// KanbanSwimlanes lists every swimlane grouping in the order the board cycles through them.
+var KanbanSwimlanes = []string{KanbanSwimlanesNone, KanbanSwimlanesPriority, KanbanSwimlanesType, KanbanSwimlanesLabel}
+
+// Built-in kanban themes users can set in kanban.theme or use as a palette base.
+const (
+	KanbanThemeDark         = "dark"
+	KanbanThemeLight        = "light"
+	KanbanThemeHighContrast = "high-contrast"
+)
+
+// KanbanThemes lists every built-in kanban theme.
+var KanbanThemes = []string{KanbanThemeDark, KanbanThemeLight, KanbanThemeHighContrast}
@@ -0,0 +1,26 @@
+package model
+
+// PlanItem is one open child issue scheduled into a plan wave.
+type PlanItem struct {
+	Issue            Issue    `json:"issue"`
+	Weight           int      `json:"weight"`
+	EarliestStart    int      `json:"earliest_start"`
+	BlockedBy        []string `json:"blocked_by"`
+	ExternalBlockers []string `json:"external_blockers"`
+	Critical         bool     `json:"critical"`
+}
+
+// PlanWave groups child issues that can run in parallel once earlier waves finish.
+type PlanWave struct {
+	Number int        `json:"number"`
+	Items  []PlanItem `json:"items"`
+}
+
+// Plan is a topological work plan for the open children of an epic.
+type Plan struct {
+	Epic           Issue      `json:"epic"`
+	Waves          []PlanWave `json:"waves"`
+	CriticalPath   []string   `json:"critical_path"`
+	CriticalWeight int        `json:"critical_weight"`
+	Done           []string   `json:"done"`
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// AddComment appends a work-log note to an issue and returns the stored comment.
+func (r *IssueRepo) AddComment(publicID, author, body string) (model.Comment, error) {
+	var comment model.Comment
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		internalID, err := internalIssueID(tx, publicID)
+		if err != nil {
+			return err
+		}
+		result, err := tx.Exec(
+			`INSERT INTO issue_comments(issue_id, author, body) VALUES(?, ?, ?)`,
+			internalID,
+			author,
+			body,
+		)
+		if err != nil {
+			return fmt.Errorf("insert comment: %w", err)
+		}
+		commentID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("read inserted comment ID: %w", err)
+		}
+		comment = model.Comment{ID: commentID, IssueID: publicID, Author: author, Body: body}
+		if err := tx.QueryRow(`SELECT created_at FROM issue_comments WHERE id = ?`, commentID).Scan(&comment.CreatedAt); err != nil {
+			return fmt.Errorf("read comment timestamp: %w", err)
+		}
+		return r.recordEvent(tx, publicID, model.EventCommented, "comment", nil, stringPtr(body))
+	})
+	if err != nil {
+		return model.Comment{}, err
+	}
+	return comment, nil
+}
+
+// ListComments returns an issue's comments oldest first, keeping only the newest limit when limit > 0.
+func (r *IssueRepo) ListComments(publicID string, limit int) ([]model.Comment, error) {
+	if _, err := r.GetIssue(publicID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT c.id, i.public_id, c.author, c.body, c.created_at
+		FROM issue_comments c
+		JOIN issues i ON i.id = c.issue_id
+		WHERE i.public_id = ?
+		ORDER BY c.id DESC`
+	args := []any{publicID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	comments := make([]model.Comment, 0)
+	for rows.Next() {
+		var comment model.Comment
+		if err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan comment row: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate comment rows: %w", err)
+	}
+
+	for left, right := 0, len(comments)-1; left < right; left, right = left+1, right-1 {
+		comments[left], comments[right] = comments[right], comments[left]
+	}
+	return comments, nil
+}
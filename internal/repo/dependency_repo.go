@@ -0,0 +1,350 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// dependencyEdge is one issue_id -> depends_on_id row keyed by internal IDs.
+type dependencyEdge struct {
+	from int64
+	to   int64
+}
+
+// CheckDependencies scans the dependency graph for cycles, dangling rows, and blockers under closed epics.
+func (r *IssueRepo) CheckDependencies() ([]model.DependencyProblem, error) {
+	problems := make([]model.DependencyProblem, 0)
+
+	cycles, err := r.dependencyCycles()
+	if err != nil {
+		return nil, err
+	}
+	for _, cycle := range cycles {
+		problems = append(problems, model.DependencyProblem{
+			Kind:    model.DependencyProblemCycle,
+			IssueID: cycle[0],
+			Path:    cycle,
+			Detail:  "cycle " + strings.Join(cycle, " -> ") + " keeps every member out of ready work",
+		})
+	}
+
+	missing, err := r.missingDependencyIssues()
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, missing...)
+
+	closedParents, err := r.closedParentBlockers()
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, closedParents...)
+
+	return problems, nil
+}
+
+// ListDependencyEdges returns every dependency between existing issues.
+func (r *IssueRepo) ListDependencyEdges() ([]model.Dependency, error) {
+	rows, err := r.db.Query(`
+		SELECT child.public_id, blocker.public_id
+		FROM dependencies d
+		JOIN issues child ON child.id = d.issue_id
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		ORDER BY child.public_id, blocker.public_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query dependency edges: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	edges := make([]model.Dependency, 0)
+	for rows.Next() {
+		var edge model.Dependency
+		if err := rows.Scan(&edge.IssueID, &edge.DependsOnID); err != nil {
+			return nil, fmt.Errorf("scan dependency edge: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dependency edges: %w", err)
+	}
+	return edges, nil
+}
+
+// dependencyCyclePath returns the cycle that adding issueID -> dependsOnID would close, or "".
+// It walks breadth-first from dependsOnID, visiting each issue once, and rebuilds
+// the path from the parent links only when issueID is reached.
+func dependencyCyclePath(tx *sql.Tx, issueID, dependsOnID string) (string, error) {
+	var startID, targetID int64
+	if err := tx.QueryRow(`SELECT id FROM issues WHERE public_id = ?`, dependsOnID).Scan(&startID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("check dependency cycle: %w", err)
+	}
+	if err := tx.QueryRow(`SELECT id FROM issues WHERE public_id = ?`, issueID).Scan(&targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("check dependency cycle: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		SELECT d.depends_on_id, n.public_id
+		FROM dependencies d
+		JOIN issues n ON n.id = d.depends_on_id
+		WHERE d.issue_id = ?
+		ORDER BY n.public_id`)
+	if err != nil {
+		return "", fmt.Errorf("check dependency cycle: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	names := map[int64]string{startID: dependsOnID}
+	parent := map[int64]int64{}
+	queue := []int64{startID}
+	_, reached := names[targetID]
+	for len(queue) > 0 && !reached {
+		current := queue[0]
+		queue = queue[1:]
+		next, err := dependencyNeighbors(stmt, current)
+		if err != nil {
+			return "", err
+		}
+		for _, neighbor := range next {
+			if _, seen := names[neighbor.id]; seen {
+				continue
+			}
+			names[neighbor.id] = neighbor.publicID
+			parent[neighbor.id] = current
+			queue = append(queue, neighbor.id)
+			reached = reached || neighbor.id == targetID
+		}
+	}
+	if !reached {
+		return "", nil
+	}
+
+	path := []string{names[targetID]}
+	for node := targetID; node != startID; {
+		node = parent[node]
+		path = append(path, names[node])
+	}
+	path = append(path, issueID)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return strings.Join(path, " -> "), nil
+}
+
+// dependencyNode is a blocker reached while walking the dependency graph.
+type dependencyNode struct {
+	id       int64
+	publicID string
+}
+
+// dependencyNeighbors lists the issues one issue depends on.
+func dependencyNeighbors(stmt *sql.Stmt, issueID int64) ([]dependencyNode, error) {
+	rows, err := stmt.Query(issueID)
+	if err != nil {
+		return nil, fmt.Errorf("check dependency cycle: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	nodes := make([]dependencyNode, 0)
+	for rows.Next() {
+		var node dependencyNode
+		if err := rows.Scan(&node.id, &node.publicID); err != nil {
+			return nil, fmt.Errorf("scan dependency cycle step: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dependency cycle step: %w", err)
+	}
+	return nodes, nil
+}
+
+// dependencyCycles finds each distinct cycle in the stored graph, rotated to start at its smallest ID.
+func (r *IssueRepo) dependencyCycles() ([][]string, error) {
+	rows, err := r.db.Query(`
+		SELECT d.issue_id, d.depends_on_id, child.public_id, blocker.public_id
+		FROM dependencies d
+		JOIN issues child ON child.id = d.issue_id
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		ORDER BY d.issue_id, d.depends_on_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query dependency graph: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := make(map[int64]string)
+	edges := make([]dependencyEdge, 0)
+	for rows.Next() {
+		var edge dependencyEdge
+		var fromName, toName string
+		if err := rows.Scan(&edge.from, &edge.to, &fromName, &toName); err != nil {
+			return nil, fmt.Errorf("scan dependency row: %w", err)
+		}
+		names[edge.from] = fromName
+		names[edge.to] = toName
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dependency rows: %w", err)
+	}
+
+	adjacency := make(map[int64][]int64)
+	nodes := make([]int64, 0, len(names))
+	for id := range names {
+		nodes = append(nodes, id)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	for _, edge := range edges {
+		adjacency[edge.from] = append(adjacency[edge.from], edge.to)
+	}
+
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[int64]int, len(nodes))
+	stack := make([]int64, 0)
+	seen := make(map[string]struct{})
+	cycles := make([][]string, 0)
+
+	var visit func(node int64)
+	visit = func(node int64) {
+		state[node] = onStack
+		stack = append(stack, node)
+		for _, next := range adjacency[node] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case onStack:
+				start := len(stack) - 1
+				for stack[start] != next {
+					start--
+				}
+				cycle := canonicalCycle(stack[start:], names)
+				key := strings.Join(cycle, ",")
+				if _, ok := seen[key]; !ok {
+					seen[key] = struct{}{}
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[node] = done
+	}
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+	return cycles, nil
+}
+
+// canonicalCycle rotates a cycle to start at its smallest public ID and closes it with the first node.
+func canonicalCycle(members []int64, names map[int64]string) []string {
+	start := 0
+	for i := range members {
+		if names[members[i]] < names[members[start]] {
+			start = i
+		}
+	}
+	cycle := make([]string, 0, len(members)+1)
+	for i := range members {
+		cycle = append(cycle, names[members[(start+i)%len(members)]])
+	}
+	return append(cycle, cycle[0])
+}
+
+// missingDependencyIssues reports dependency rows that point at issues that no longer exist.
+func (r *IssueRepo) missingDependencyIssues() ([]model.DependencyProblem, error) {
+	rows, err := r.db.Query(`
+		SELECT d.issue_id, d.depends_on_id, child.public_id, blocker.public_id
+		FROM dependencies d
+		LEFT JOIN issues child ON child.id = d.issue_id
+		LEFT JOIN issues blocker ON blocker.id = d.depends_on_id
+		WHERE child.id IS NULL OR blocker.id IS NULL
+		ORDER BY d.issue_id, d.depends_on_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query dangling dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	problems := make([]model.DependencyProblem, 0)
+	for rows.Next() {
+		var childInternal, blockerInternal int64
+		var childID, blockerID sql.NullString
+		if err := rows.Scan(&childInternal, &blockerInternal, &childID, &blockerID); err != nil {
+			return nil, fmt.Errorf("scan dangling dependency: %w", err)
+		}
+		problem := model.DependencyProblem{
+			Kind:        model.DependencyProblemMissingIssue,
+			IssueID:     displayIssueID(childID, childInternal),
+			DependsOnID: displayIssueID(blockerID, blockerInternal),
+		}
+		if !childID.Valid {
+			problem.Detail = "dependency row belongs to a deleted issue"
+		} else {
+			problem.Detail = "depends on a deleted issue and can never become ready"
+		}
+		problems = append(problems, problem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate dangling dependencies: %w", err)
+	}
+	return problems, nil
+}
+
+// closedParentBlockers reports open issues blocked by unfinished work inside a closed epic.
+func (r *IssueRepo) closedParentBlockers() ([]model.DependencyProblem, error) {
+	rows, err := r.db.Query(`
+		SELECT child.public_id, blocker.public_id, parent.public_id
+		FROM dependencies d
+		JOIN issues child ON child.id = d.issue_id
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		JOIN issues parent ON parent.id = blocker.parent_id
+		WHERE parent.status = 'closed'
+		  AND blocker.status != 'closed'
+		  AND child.status != 'closed'
+		ORDER BY child.public_id, blocker.public_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query closed-parent blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	problems := make([]model.DependencyProblem, 0)
+	for rows.Next() {
+		var problem model.DependencyProblem
+		var parentID string
+		if err := rows.Scan(&problem.IssueID, &problem.DependsOnID, &parentID); err != nil {
+			return nil, fmt.Errorf("scan closed-parent blocker: %w", err)
+		}
+		problem.Kind = model.DependencyProblemClosedParent
+		problem.Detail = fmt.Sprintf("blocker is still open under closed epic %s", parentID)
+		problems = append(problems, problem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate closed-parent blockers: %w", err)
+	}
+	return problems, nil
+}
+
+// displayIssueID prefers the public ID and falls back to the internal row ID for deleted issues.
+func displayIssueID(publicID sql.NullString, internalID int64) string {
+	if publicID.Valid {
+		return publicID.String
+	}
+	return "#" + strconv.FormatInt(internalID, 10)
+}
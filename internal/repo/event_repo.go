@@ -0,0 +1,285 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// defaultActor is recorded when no caller identity was configured.
+const defaultActor = "unknown"
+
+// auditedColumns maps issue columns tracked field-by-field to their event field names.
+var auditedColumns = []struct {
+	column string
+	field  string
+}{
+	{column: "i.title", field: "title"},
+	{column: "i.description", field: "description"},
+	{column: "i.type", field: "type"},
+	{column: "CAST(i.priority AS TEXT)", field: "priority"},
+	{column: "CAST(i.estimate AS TEXT)", field: "estimate"},
+	{column: "i.status", field: "status"},
+	{column: "p.public_id", field: "parent"},
+}
+
+// SetActor sets the identity recorded on audit events written by this repository.
+func (r *IssueRepo) SetActor(actor string) {
+	r.actor = actor
+}
+
+// SetWorkspace sets the Git worktree and branch recorded on claims made by this repository.
+func (r *IssueRepo) SetWorkspace(worktree, branch string) {
+	r.worktree = worktree
+	r.branch = branch
+}
+
+// Actor returns the identity recorded on audit events, or the placeholder when none was set.
+func (r *IssueRepo) Actor() string {
+	return r.actorName()
+}
+
+// actorName returns the configured actor or the placeholder used when none was set.
+func (r *IssueRepo) actorName() string {
+	if r.actor == "" {
+		return defaultActor
+	}
+	return r.actor
+}
+
+// ListIssueEvents returns the full audit history of one issue, oldest first.
+func (r *IssueRepo) ListIssueEvents(publicID string) ([]model.IssueEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, issue_id, action, field, old_value, new_value, actor, created_at
+		FROM issue_events
+		WHERE issue_id = ?
+		ORDER BY id ASC`, publicID)
+	if err != nil {
+		return nil, fmt.Errorf("query issue events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		if _, err := r.GetIssue(publicID); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// ListEventsSince returns audit events recorded at or after since, oldest first.
+func (r *IssueRepo) ListEventsSince(since time.Time) ([]model.IssueEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, issue_id, action, field, old_value, new_value, actor, created_at
+		FROM issue_events
+		WHERE created_at >= ?
+		ORDER BY id ASC`, since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanEvents(rows)
+}
+
+// ListEventsAfter returns up to limit audit events whose sequence ID is above afterID, oldest first.
+// SQLite serializes writers and never reuses AUTOINCREMENT IDs, so the ID is a gap-tolerant change cursor.
+func (r *IssueRepo) ListEventsAfter(afterID int64, limit int) ([]model.IssueEvent, error) {
+	query := `
+		SELECT id, issue_id, action, field, old_value, new_value, actor, created_at
+		FROM issue_events
+		WHERE id > ?
+		ORDER BY id ASC`
+	args := []any{afterID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanEvents(rows)
+}
+
+// LatestEventID returns the sequence ID of the newest audit event, or 0 when none exist.
+func (r *IssueRepo) LatestEventID() (int64, error) {
+	var latest int64
+	if err := r.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM issue_events`).Scan(&latest); err != nil {
+		return 0, fmt.Errorf("query latest event: %w", err)
+	}
+	return latest, nil
+}
+
+// MarkExpiredLeases records one lease_expired event per lapsed claim and returns the affected issue IDs.
+// The event stores the lapsed deadline, so each lease is reported once even across renewals.
+func (r *IssueRepo) MarkExpiredLeases() ([]string, error) {
+	var expired []string
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		expired = nil
+		rows, err := tx.Query(`
+			SELECT i.public_id, i.claimed_by, CAST(i.claim_expires_at AS TEXT)
+			FROM issues i
+			WHERE i.status = 'in_progress'
+			  AND i.claim_expires_at IS NOT NULL
+			  AND i.claim_expires_at <= CURRENT_TIMESTAMP
+			  AND NOT EXISTS (
+				SELECT 1 FROM issue_events e
+				WHERE e.issue_id = i.public_id
+				  AND e.action = ?
+				  AND e.new_value = CAST(i.claim_expires_at AS TEXT)
+			  )
+			ORDER BY i.id ASC`, model.EventLeaseExpired)
+		if err != nil {
+			return fmt.Errorf("query expired leases: %w", err)
+		}
+		type lapsed struct {
+			publicID  string
+			claimedBy sql.NullString
+			deadline  string
+		}
+		leases := make([]lapsed, 0)
+		for rows.Next() {
+			var lease lapsed
+			if err := rows.Scan(&lease.publicID, &lease.claimedBy, &lease.deadline); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("scan expired lease: %w", err)
+			}
+			leases = append(leases, lease)
+		}
+		if err := rows.Close(); err != nil {
+			return fmt.Errorf("iterate expired leases: %w", err)
+		}
+
+		for _, lease := range leases {
+			if err := r.recordEvent(tx, lease.publicID, model.EventLeaseExpired, "lease", nullableString(lease.claimedBy), stringPtr(lease.deadline)); err != nil {
+				return err
+			}
+			expired = append(expired, lease.publicID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// recordEvent appends one audit row inside the caller's transaction.
+func (r *IssueRepo) recordEvent(tx *sql.Tx, publicID, action, field string, oldValue, newValue *string) error {
+	_, err := tx.Exec(
+		`INSERT INTO issue_events(issue_id, action, field, old_value, new_value, actor)
+		 VALUES(?, ?, ?, ?, ?, ?)`,
+		publicID,
+		action,
+		field,
+		oldValue,
+		newValue,
+		r.actorName(),
+	)
+	if err != nil {
+		return fmt.Errorf("record %s event: %w", action, err)
+	}
+	return nil
+}
+
+// auditedValues snapshots the tracked fields of one issue inside a transaction.
+func auditedValues(tx *sql.Tx, publicID string) (map[string]*string, error) {
+	values := make([]sql.NullString, len(auditedColumns))
+	targets := make([]any, len(auditedColumns))
+	query := "SELECT "
+	for i, audited := range auditedColumns {
+		if i > 0 {
+			query += ", "
+		}
+		query += audited.column
+		targets[i] = &values[i]
+	}
+	query += ` FROM issues i LEFT JOIN issues p ON p.id = i.parent_id WHERE i.public_id = ?`
+
+	if err := tx.QueryRow(query, publicID).Scan(targets...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
+		}
+		return nil, fmt.Errorf("snapshot issue fields: %w", err)
+	}
+
+	snapshot := make(map[string]*string, len(auditedColumns))
+	for i, audited := range auditedColumns {
+		snapshot[audited.field] = nullableString(values[i])
+	}
+	return snapshot, nil
+}
+
+// recordFieldChanges appends one updated event per tracked field whose value changed.
+func (r *IssueRepo) recordFieldChanges(tx *sql.Tx, publicID string, before, after map[string]*string) error {
+	for _, audited := range auditedColumns {
+		oldValue, newValue := before[audited.field], after[audited.field]
+		if sameValue(oldValue, newValue) {
+			continue
+		}
+		if err := r.recordEvent(tx, publicID, model.EventUpdated, audited.field, oldValue, newValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanEvents consumes query rows into audit event models.
+func scanEvents(rows *sql.Rows) ([]model.IssueEvent, error) {
+	events := make([]model.IssueEvent, 0)
+	for rows.Next() {
+		var event model.IssueEvent
+		var oldValue, newValue sql.NullString
+		if err := rows.Scan(
+			&event.ID,
+			&event.IssueID,
+			&event.Action,
+			&event.Field,
+			&oldValue,
+			&newValue,
+			&event.Actor,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan event row: %w", err)
+		}
+		event.OldValue = nullableString(oldValue)
+		event.NewValue = nullableString(newValue)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate event rows: %w", err)
+	}
+	return events, nil
+}
+
+// nullableString converts a nullable column into an optional string.
+func nullableString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	text := value.String
+	return &text
+}
+
+// stringPtr returns a pointer to a copy of value.
+func stringPtr(value string) *string {
+	return &value
+}
+
+// sameValue reports whether two optional event values are equal.
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
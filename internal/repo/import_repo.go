@@ -0,0 +1,402 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// sqliteTimeLayout matches the text SQLite's CURRENT_TIMESTAMP stores.
+const sqliteTimeLayout = "2006-01-02 15:04:05"
+
+// errImportDryRun rolls back a dry-run import after its report is built.
+var errImportDryRun = errors.New("import dry run")
+
+// ExportIssue converts a stored issue into its shareable export form.
+func ExportIssue(issue model.Issue) model.ExportedIssue {
+	labels := append([]string{}, issue.Labels...)
+	sort.Strings(labels)
+	exported := model.ExportedIssue{
+		ID:          issue.ID,
+		Title:       issue.Title,
+		Description: issue.Description,
+		Type:        issue.Type,
+		Priority:    issue.Priority,
+		Estimate:    issue.Estimate,
+		Status:      issue.Status,
+		ClaimedBy:   issue.ClaimedBy,
+		ParentID:    issue.ParentID,
+		Labels:      labels,
+		CreatedAt:   issue.CreatedAt.UTC(),
+		UpdatedAt:   issue.UpdatedAt.UTC(),
+	}
+	if issue.ClosedAt != nil {
+		closedAt := issue.ClosedAt.UTC()
+		exported.ClosedAt = &closedAt
+	}
+	return exported
+}
+
+// ListTombstones returns the deleted issues whose IDs are not in use again,
+// each with the time of its latest deletion.
+func (r *IssueRepo) ListTombstones() ([]model.Tombstone, error) {
+	rows, err := r.db.Query(`
+		SELECT issue_id, MAX(created_at)
+		FROM issue_events
+		WHERE action = ? AND issue_id NOT IN (SELECT public_id FROM issues)
+		GROUP BY issue_id
+		ORDER BY issue_id`, model.EventDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("query tombstones: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tombstones := make([]model.Tombstone, 0)
+	for rows.Next() {
+		var tombstone model.Tombstone
+		var deletedAt string
+		if err := rows.Scan(&tombstone.ID, &deletedAt); err != nil {
+			return nil, fmt.Errorf("scan tombstone: %w", err)
+		}
+		tombstone.DeletedAt, err = time.Parse(sqliteTimeLayout, deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse tombstone time %q: %w", deletedAt, err)
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tombstones: %w", err)
+	}
+	return tombstones, nil
+}
+
+// ImportExport upserts issues and dependencies by public ID in one transaction.
+// An incoming issue replaces the local one only when its updated_at is newer, unless
+// overwrite is set; otherwise the local copy is kept and reported as a conflict.
+// Tombstones delete local issues under the same rule. Local issues and
+// dependencies missing from data are left alone. An imported in_progress issue
+// whose claim changes hands locally gets a fresh lease of the given length.
+func (r *IssueRepo) ImportExport(data model.Export, overwrite, dryRun bool, lease time.Duration) (model.ImportReport, error) {
+	modifier := fmt.Sprintf("+%d seconds", int(lease.Seconds()))
+	var report model.ImportReport
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		report = model.ImportReport{
+			Created:   make([]string, 0),
+			Updated:   make([]string, 0),
+			Conflicts: make([]model.ImportConflict, 0),
+			DryRun:    dryRun,
+		}
+
+		applied := make([]model.ExportedIssue, 0, len(data.Issues))
+		for _, incoming := range data.Issues {
+			local, found, err := exportedIssueInTx(tx, incoming.ID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case !found:
+				if err := r.insertImported(tx, incoming); err != nil {
+					return err
+				}
+				report.Created = append(report.Created, incoming.ID)
+			case sameExportedIssue(local, incoming):
+				report.Unchanged++
+				continue
+			case !overwrite && !incoming.UpdatedAt.After(local.UpdatedAt):
+				report.Conflicts = append(report.Conflicts, model.ImportConflict{
+					IssueID: incoming.ID,
+					Reason:  model.ImportConflictLocalNewer,
+					Detail: fmt.Sprintf("local copy updated %s, imported copy %s; kept local",
+						local.UpdatedAt.Format(time.RFC3339), incoming.UpdatedAt.Format(time.RFC3339)),
+				})
+				continue
+			default:
+				report.Updated = append(report.Updated, incoming.ID)
+			}
+			applied = append(applied, incoming)
+		}
+
+		// Fields are written once every imported issue exists so parents can point forward.
+		for _, incoming := range applied {
+			conflict, err := r.applyImported(tx, incoming, modifier)
+			if err != nil {
+				return err
+			}
+			if conflict != nil {
+				report.Conflicts = append(report.Conflicts, *conflict)
+			}
+		}
+
+		for _, dep := range data.Dependencies {
+			conflict, added, err := r.importDependency(tx, dep)
+			if err != nil {
+				return err
+			}
+			if conflict != nil {
+				report.Conflicts = append(report.Conflicts, *conflict)
+			}
+			if added {
+				report.DependenciesAdded++
+			}
+		}
+
+		for _, tombstone := range data.Tombstones {
+			deleted, conflict, err := r.importTombstone(tx, tombstone, overwrite)
+			if err != nil {
+				return err
+			}
+			if conflict != nil {
+				report.Conflicts = append(report.Conflicts, *conflict)
+			}
+			if deleted {
+				report.Deleted = append(report.Deleted, tombstone.ID)
+			}
+		}
+
+		// The updated_at trigger stamps every write above; restore the imported times last.
+		for _, incoming := range applied {
+			if _, err := tx.Exec(`UPDATE issues SET updated_at = ? WHERE public_id = ?`,
+				incoming.UpdatedAt.UTC().Format(sqliteTimeLayout), incoming.ID); err != nil {
+				return fmt.Errorf("restore imported updated_at: %w", err)
+			}
+		}
+
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return model.ImportReport{}, err
+	}
+	return report, nil
+}
+
+// insertImported creates the row for a new imported issue; applyImported fills the rest.
+func (r *IssueRepo) insertImported(tx *sql.Tx, issue model.ExportedIssue) error {
+	if _, err := tx.Exec(
+		`INSERT INTO issues(public_id, title, type, created_at) VALUES(?, ?, ?, ?)`,
+		issue.ID,
+		issue.Title,
+		issue.Type,
+		issue.CreatedAt.UTC().Format(sqliteTimeLayout),
+	); err != nil {
+		return fmt.Errorf("insert imported issue: %w", err)
+	}
+	return r.recordEvent(tx, issue.ID, model.EventCreated, "title", nil, stringPtr(issue.Title))
+}
+
+// applyImported overwrites an issue's fields and labels with the imported copy.
+// A parent that does not exist locally is reported and the issue is left without one.
+// An in_progress issue keeps its local claim times and worktree while the owner is
+// unchanged; a new or unleased owner gets a claim starting now that expires after
+// modifier, so the claim lapses like any other instead of blocking the issue forever.
+func (r *IssueRepo) applyImported(tx *sql.Tx, issue model.ExportedIssue, modifier string) (*model.ImportConflict, error) {
+	var conflict *model.ImportConflict
+	var parentInternalID *int64
+	if issue.ParentID != nil {
+		internalID, err := internalIssueID(tx, *issue.ParentID)
+		switch {
+		case errors.Is(err, ErrIssueNotFound):
+			conflict = &model.ImportConflict{
+				IssueID: issue.ID,
+				Reason:  model.ImportConflictMissingParent,
+				Detail:  fmt.Sprintf("parent %s does not exist locally", *issue.ParentID),
+			}
+		case err != nil:
+			return nil, err
+		default:
+			parentInternalID = &internalID
+		}
+	}
+
+	var closedAt *string
+	if issue.ClosedAt != nil {
+		formatted := issue.ClosedAt.UTC().Format(sqliteTimeLayout)
+		closedAt = &formatted
+	}
+
+	before, err := auditedValues(tx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(
+		`UPDATE issues
+		 SET title = ?1, description = ?2, type = ?3, priority = ?4, estimate = ?5, status = ?6,
+		     claimed_by = ?7, parent_id = ?8, created_at = ?9, closed_at = ?10,
+		     claimed_at = CASE
+		         WHEN ?6 != 'in_progress' THEN NULL
+		         WHEN ?7 IS NOT NULL AND (claimed_by IS NOT ?7 OR claim_expires_at IS NULL) THEN CURRENT_TIMESTAMP
+		         ELSE claimed_at END,
+		     claim_expires_at = CASE
+		         WHEN ?6 != 'in_progress' THEN NULL
+		         WHEN ?7 IS NOT NULL AND (claimed_by IS NOT ?7 OR claim_expires_at IS NULL) THEN DATETIME(CURRENT_TIMESTAMP, ?11)
+		         ELSE claim_expires_at END,
+		     claimed_worktree = CASE WHEN ?6 = 'in_progress' AND claimed_by IS ?7 THEN claimed_worktree END,
+		     claimed_branch = CASE WHEN ?6 = 'in_progress' AND claimed_by IS ?7 THEN claimed_branch END
+		 WHERE public_id = ?12`,
+		issue.Title,
+		issue.Description,
+		issue.Type,
+		issue.Priority,
+		issue.Estimate,
+		issue.Status,
+		issue.ClaimedBy,
+		parentInternalID,
+		issue.CreatedAt.UTC().Format(sqliteTimeLayout),
+		closedAt,
+		modifier,
+		issue.ID,
+	); err != nil {
+		return nil, fmt.Errorf("update imported issue: %w", err)
+	}
+
+	internalID, err := internalIssueID(tx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM issue_labels WHERE issue_id = ?`, internalID); err != nil {
+		return nil, fmt.Errorf("clear imported labels: %w", err)
+	}
+	if _, err := attachLabels(tx, internalID, issue.Labels); err != nil {
+		return nil, err
+	}
+
+	after, err := auditedValues(tx, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.recordFieldChanges(tx, issue.ID, before, after); err != nil {
+		return nil, err
+	}
+	return conflict, nil
+}
+
+// importDependency adds one imported edge unless it exists, names a missing issue, or closes a cycle.
+func (r *IssueRepo) importDependency(tx *sql.Tx, dep model.Dependency) (*model.ImportConflict, bool, error) {
+	var existing int
+	if err := tx.QueryRow(`
+		SELECT COUNT(*)
+		FROM dependencies d
+		JOIN issues child ON child.id = d.issue_id
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		WHERE child.public_id = ? AND blocker.public_id = ?`, dep.IssueID, dep.DependsOnID).Scan(&existing); err != nil {
+		return nil, false, fmt.Errorf("check imported dependency: %w", err)
+	}
+	if existing > 0 {
+		return nil, false, nil
+	}
+
+	for _, publicID := range []string{dep.IssueID, dep.DependsOnID} {
+		if _, err := internalIssueID(tx, publicID); err != nil {
+			if errors.Is(err, ErrIssueNotFound) {
+				return &model.ImportConflict{
+					IssueID:     dep.IssueID,
+					DependsOnID: dep.DependsOnID,
+					Reason:      model.ImportConflictMissingIssue,
+					Detail:      fmt.Sprintf("%s does not exist locally", publicID),
+				}, false, nil
+			}
+			return nil, false, err
+		}
+	}
+	cycle, err := dependencyCyclePath(tx, dep.IssueID, dep.DependsOnID)
+	if err != nil {
+		return nil, false, err
+	}
+	if cycle != "" {
+		return &model.ImportConflict{
+			IssueID:     dep.IssueID,
+			DependsOnID: dep.DependsOnID,
+			Reason:      model.ImportConflictCycle,
+			Detail:      "would create cycle " + cycle,
+		}, false, nil
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO dependencies(issue_id, depends_on_id)
+		 SELECT child.id, blocker.id
+		 FROM issues child, issues blocker
+		 WHERE child.public_id = ? AND blocker.public_id = ?`,
+		dep.IssueID,
+		dep.DependsOnID,
+	); err != nil {
+		return nil, false, fmt.Errorf("add imported dependency: %w", err)
+	}
+	if err := r.recordEvent(tx, dep.IssueID, model.EventDependencyAdded, "depends_on", nil, stringPtr(dep.DependsOnID)); err != nil {
+		return nil, false, err
+	}
+	return nil, true, nil
+}
+
+// importTombstone deletes a local issue unless it changed after the tombstone was written.
+// The deletion event keeps the tombstone's time so a re-export reproduces it.
+func (r *IssueRepo) importTombstone(tx *sql.Tx, tombstone model.Tombstone, overwrite bool) (bool, *model.ImportConflict, error) {
+	local, found, err := exportedIssueInTx(tx, tombstone.ID)
+	if err != nil || !found {
+		return false, nil, err
+	}
+	if !overwrite && local.UpdatedAt.After(tombstone.DeletedAt) {
+		return false, &model.ImportConflict{
+			IssueID: tombstone.ID,
+			Reason:  model.ImportConflictLocalNewer,
+			Detail: fmt.Sprintf("local copy updated %s, deleted in import %s; kept local",
+				local.UpdatedAt.Format(time.RFC3339), tombstone.DeletedAt.Format(time.RFC3339)),
+		}, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM issues WHERE public_id = ?`, tombstone.ID); err != nil {
+		return false, nil, fmt.Errorf("delete imported tombstone: %w", err)
+	}
+	if err := r.recordEvent(tx, tombstone.ID, model.EventDeleted, "title", stringPtr(local.Title), nil); err != nil {
+		return false, nil, err
+	}
+	if _, err := tx.Exec(`UPDATE issue_events SET created_at = ? WHERE id = last_insert_rowid()`,
+		tombstone.DeletedAt.UTC().Format(sqliteTimeLayout)); err != nil {
+		return false, nil, fmt.Errorf("date imported deletion: %w", err)
+	}
+	return true, nil, nil
+}
+
+// exportedIssueInTx loads one issue in export form, reporting whether it exists.
+func exportedIssueInTx(tx *sql.Tx, publicID string) (model.ExportedIssue, bool, error) {
+	var issue model.Issue
+	var labels sql.NullString
+	err := tx.QueryRow(fmt.Sprintf(`
+		SELECT %s
+		FROM issues i
+		LEFT JOIN issues p ON p.id = i.parent_id
+		WHERE i.public_id = ?`, issueSelectColumns), publicID).Scan(issueScanTargets(&issue, &labels)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ExportedIssue{}, false, nil
+	}
+	if err != nil {
+		return model.ExportedIssue{}, false, fmt.Errorf("query issue: %w", err)
+	}
+	issue.Labels = splitLabels(labels)
+	return ExportIssue(issue), true, nil
+}
+
+// sameExportedIssue compares two export records at the one-second precision SQLite keeps.
+func sameExportedIssue(a, b model.ExportedIssue) bool {
+	return reflect.DeepEqual(canonicalExport(a), canonicalExport(b))
+}
+
+// canonicalExport normalizes times and empty collections for comparison.
+func canonicalExport(issue model.ExportedIssue) model.ExportedIssue {
+	issue.CreatedAt = issue.CreatedAt.UTC().Truncate(time.Second)
+	issue.UpdatedAt = issue.UpdatedAt.UTC().Truncate(time.Second)
+	if issue.ClosedAt != nil {
+		closedAt := issue.ClosedAt.UTC().Truncate(time.Second)
+		issue.ClosedAt = &closedAt
+	}
+	labels := append([]string{}, issue.Labels...)
+	sort.Strings(labels)
+	issue.Labels = labels
+	return issue
+}
@@ -14,19 +14,28 @@ import (
 
 // IssueRepo handles issue persistence and graph queries.
 type IssueRepo struct {
-	db *sql.DB
+	db       *sql.DB
+	actor    string
+	worktree string
+	branch   string
 }
 
 var ErrIssueAlreadyClaimed = errors.New("issue is already claimed")
 var ErrIssueTypeNotClaimable = errors.New("issue type is not claimable")
+var ErrIssueNotFound = errors.New("not found")
+var ErrIssueClosed = errors.New("is closed")
+var ErrIssueNotClaimed = errors.New("issue is not claimed")
+var ErrNotClaimOwner = errors.New("issue is claimed by another agent")
+var ErrNoReadyIssue = errors.New("no ready issue matches the filters")
 
 const (
 	maxWriteAttempts = 8
 	baseWriteBackoff = 20 * time.Millisecond
 )
 
-const issueSelectColumns = `i.id, i.public_id, i.title, i.description, i.type, i.priority, i.status,
-	       i.claimed_at, i.claim_expires_at, i.parent_id, p.public_id, i.created_at, i.updated_at, i.closed_at`
+const issueSelectColumns = `i.id, i.public_id, i.title, i.description, i.type, i.priority, i.estimate, i.status,
+	       i.claimed_at, i.claim_expires_at, i.claimed_by, i.claimed_worktree, i.claimed_branch, i.parent_id, p.public_id, i.created_at, i.updated_at, i.closed_at,
+	       (SELECT GROUP_CONCAT(l.name, ',') FROM issue_labels il JOIN labels l ON l.id = il.label_id WHERE il.issue_id = i.id)`
 
 // NewIssueRepo builds a repository backed by sqlite.
 func NewIssueRepo(db *sql.DB) *IssueRepo {
@@ -44,19 +53,37 @@ func (r *IssueRepo) CreateIssue(issue model.Issue) (string, error) {
 		parentInternalID = &parentIssue.InternalID
 	}
 
-	_, err := r.execWithRetry(
-		`INSERT INTO issues(public_id, title, description, type, priority, status, parent_id)
-			 VALUES(?, ?, ?, ?, ?, ?, ?)`,
-		issue.ID,
-		issue.Title,
-		issue.Description,
-		issue.Type,
-		issue.Priority,
-		issue.Status,
-		parentInternalID,
-	)
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		result, err := tx.Exec(
+			`INSERT INTO issues(public_id, title, description, type, priority, estimate, status, parent_id)
+				 VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+			issue.ID,
+			issue.Title,
+			issue.Description,
+			issue.Type,
+			issue.Priority,
+			issue.Estimate,
+			issue.Status,
+			parentInternalID,
+		)
+		if err != nil {
+			return fmt.Errorf("insert issue: %w", err)
+		}
+		if err := r.recordEvent(tx, issue.ID, model.EventCreated, "title", nil, stringPtr(issue.Title)); err != nil {
+			return err
+		}
+		if len(issue.Labels) == 0 {
+			return nil
+		}
+		internalID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("read inserted issue ID: %w", err)
+		}
+		_, err = attachLabels(tx, internalID, issue.Labels)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("insert issue: %w", err)
+		return "", err
 	}
 
 	return issue.ID, nil
@@ -65,33 +92,20 @@ func (r *IssueRepo) CreateIssue(issue model.Issue) (string, error) {
 // GetIssue loads one issue by public ID.
 func (r *IssueRepo) GetIssue(publicID string) (model.Issue, error) {
 	var issue model.Issue
+	var labels sql.NullString
 	err := r.db.QueryRow(fmt.Sprintf(`
 		SELECT %s
 		FROM issues i
 		LEFT JOIN issues p ON p.id = i.parent_id
 		WHERE i.public_id = ?`, issueSelectColumns), publicID).
-		Scan(
-			&issue.InternalID,
-			&issue.ID,
-			&issue.Title,
-			&issue.Description,
-			&issue.Type,
-			&issue.Priority,
-			&issue.Status,
-			&issue.ClaimedAt,
-			&issue.ClaimExpiresAt,
-			&issue.ParentInternal,
-			&issue.ParentID,
-			&issue.CreatedAt,
-			&issue.UpdatedAt,
-			&issue.ClosedAt,
-		)
+		Scan(issueScanTargets(&issue, &labels)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return model.Issue{}, fmt.Errorf("issue %q not found", publicID)
+			return model.Issue{}, fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
 		}
 		return model.Issue{}, fmt.Errorf("query issue: %w", err)
 	}
+	issue.Labels = splitLabels(labels)
 
 	return issue, nil
 }
@@ -205,28 +219,7 @@ func (r *IssueRepo) ListIssues(filter model.ListFilter) ([]model.Issue, error) {
 		LEFT JOIN issues p ON p.id = i.parent_id
 		LEFT JOIN issues root ON root.id = COALESCE(i.parent_id, i.id)`
 
-	where := make([]string, 0)
-	args := make([]any, 0)
-
-	if filter.Type != "" {
-		where = append(where, "i.type = ?")
-		args = append(args, filter.Type)
-	}
-	if filter.Status != "" {
-		where = append(where, "i.status = ?")
-		args = append(args, filter.Status)
-	} else if !filter.All {
-		where = append(where, "i.status != 'closed'")
-	}
-	if filter.Priority != nil {
-		where = append(where, "i.priority = ?")
-		args = append(args, *filter.Priority)
-	}
-	if filter.ParentID != "" {
-		where = append(where, "p.public_id = ?")
-		args = append(args, filter.ParentID)
-	}
-
+	where, args := listConditions(filter)
 	if len(where) > 0 {
 		query = query + " WHERE " + strings.Join(where, " AND ")
 	}
@@ -256,20 +249,58 @@ func (r *IssueRepo) ListIssues(filter model.ListFilter) ([]model.Issue, error) {
 	return scanIssues(rows)
 }
 
-// DeleteIssue permanently removes an issue.
-func (r *IssueRepo) DeleteIssue(publicID string) error {
-	result, err := r.execWithRetry(`DELETE FROM issues WHERE public_id = ?`, publicID)
-	if err != nil {
-		return fmt.Errorf("delete issue: %w", err)
+// listConditions builds WHERE clauses for ListFilter over issues i joined to parent p.
+func listConditions(filter model.ListFilter) ([]string, []any) {
+	where := make([]string, 0)
+	args := make([]any, 0)
+
+	if filter.Type != "" {
+		where = append(where, "i.type = ?")
+		args = append(args, filter.Type)
 	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check delete issue result: %w", err)
+	if filter.Status != "" {
+		where = append(where, "i.status = ?")
+		args = append(args, filter.Status)
+	} else if !filter.All {
+		where = append(where, "i.status != 'closed'")
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("issue %q not found", publicID)
+	if filter.Priority != nil {
+		where = append(where, "i.priority = ?")
+		args = append(args, *filter.Priority)
 	}
-	return nil
+	if filter.ParentID != "" {
+		where = append(where, "p.public_id = ?")
+		args = append(args, filter.ParentID)
+	}
+	if len(filter.IDs) > 0 {
+		where = append(where, "i.public_id IN ("+strings.TrimSuffix(strings.Repeat("?, ", len(filter.IDs)), ", ")+")")
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+	return appendLabelFilter(where, args, filter)
+}
+
+// DeleteIssue permanently removes an issue.
+func (r *IssueRepo) DeleteIssue(publicID string) error {
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		before, err := auditedValues(tx, publicID)
+		if err != nil {
+			return err
+		}
+		result, err := tx.Exec(`DELETE FROM issues WHERE public_id = ?`, publicID)
+		if err != nil {
+			return fmt.Errorf("delete issue: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check delete issue result: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
+		}
+		return r.recordEvent(tx, publicID, model.EventDeleted, "title", before["title"], nil)
+	})
 }
 
 // UpdateIssue updates selected fields on an issue.
@@ -312,132 +343,153 @@ func (r *IssueRepo) UpdateIssue(publicID string, fields map[string]any) error {
 	args = append(args, publicID)
 
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE public_id = ?", strings.Join(setClauses, ", "))
-	result, err := r.execWithRetry(query, args...)
-	if err != nil {
-		return fmt.Errorf("update issue: %w", err)
-	}
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		before, err := auditedValues(tx, publicID)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check update issue result: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("issue %q not found", publicID)
-	}
+		result, err := tx.Exec(query, args...)
+		if err != nil {
+			return fmt.Errorf("update issue: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check update issue result: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
+		}
 
-	return nil
+		after, err := auditedValues(tx, publicID)
+		if err != nil {
+			return err
+		}
+		return r.recordFieldChanges(tx, publicID, before, after)
+	})
 }
 
 // CloseIssue marks an issue as closed.
 func (r *IssueRepo) CloseIssue(publicID string) error {
-	result, err := r.execWithRetry(
+	return r.setStatus(publicID, "close", model.EventClosed,
 		`UPDATE issues
 			 SET status = 'closed',
 		     closed_at = CURRENT_TIMESTAMP,
 		     claimed_at = NULL,
-		     claim_expires_at = NULL
-		 WHERE public_id = ?`, publicID,
+		     claim_expires_at = NULL,
+		     claimed_by = NULL,
+		     claimed_worktree = NULL,
+		     claimed_branch = NULL
+		 WHERE public_id = ?`,
 	)
-	if err != nil {
-		return fmt.Errorf("close issue: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check close result: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("issue %q not found", publicID)
-	}
-
-	return nil
 }
 
 // ReopenIssue marks an issue as open.
 func (r *IssueRepo) ReopenIssue(publicID string) error {
-	result, err := r.execWithRetry(
+	return r.setStatus(publicID, "reopen", model.EventReopened,
 		`UPDATE issues
 			 SET status = 'open',
 		     closed_at = NULL,
 		     claimed_at = NULL,
-		     claim_expires_at = NULL
-		 WHERE public_id = ?`, publicID,
+		     claim_expires_at = NULL,
+		     claimed_by = NULL,
+		     claimed_worktree = NULL,
+		     claimed_branch = NULL
+		 WHERE public_id = ?`,
 	)
-	if err != nil {
-		return fmt.Errorf("reopen issue: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check reopen result: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("issue %q not found", publicID)
-	}
+}
 
-	return nil
+// setStatus runs one status transition statement and records it under action.
+func (r *IssueRepo) setStatus(publicID, verb, action, query string) error {
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		before, err := auditedValues(tx, publicID)
+		if err != nil {
+			return err
+		}
+		result, err := tx.Exec(query, publicID)
+		if err != nil {
+			return fmt.Errorf("%s issue: %w", verb, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check %s result: %w", verb, err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
+		}
+		after, err := auditedValues(tx, publicID)
+		if err != nil {
+			return err
+		}
+		return r.recordEvent(tx, publicID, action, "status", before["status"], after["status"])
+	})
 }
 
 // AddDependency links issue with a blocker.
 func (r *IssueRepo) AddDependency(issueID, dependsOnID string) error {
-	result, err := r.execWithRetry(
-		`INSERT INTO dependencies(issue_id, depends_on_id)
-			 SELECT child.id, blocker.id
-		 FROM issues child, issues blocker
-		 WHERE child.public_id = ? AND blocker.public_id = ?`,
-		issueID,
-		dependsOnID,
-	)
-	if err != nil {
-		return fmt.Errorf("add dependency: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check add dependency result: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("issue %q or blocker %q not found", issueID, dependsOnID)
-	}
-	return nil
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		cycle, err := dependencyCyclePath(tx, issueID, dependsOnID)
+		if err != nil {
+			return err
+		}
+		if cycle != "" {
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, cycle)
+		}
+		result, err := tx.Exec(
+			`INSERT INTO dependencies(issue_id, depends_on_id)
+				 SELECT child.id, blocker.id
+			 FROM issues child, issues blocker
+			 WHERE child.public_id = ? AND blocker.public_id = ?`,
+			issueID,
+			dependsOnID,
+		)
+		if err != nil {
+			return fmt.Errorf("add dependency: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check add dependency result: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("issue %q or blocker %q %w", issueID, dependsOnID, ErrIssueNotFound)
+		}
+		return r.recordEvent(tx, issueID, model.EventDependencyAdded, "depends_on", nil, stringPtr(dependsOnID))
+	})
 }
 
 // RemoveDependency unlinks a blocker.
 func (r *IssueRepo) RemoveDependency(issueID, dependsOnID string) error {
-	result, err := r.execWithRetry(
-		`DELETE FROM dependencies
-			 WHERE issue_id = (SELECT id FROM issues WHERE public_id = ?)
-		   AND depends_on_id = (SELECT id FROM issues WHERE public_id = ?)`,
-		issueID,
-		dependsOnID,
-	)
-	if err != nil {
-		return fmt.Errorf("remove dependency: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("check remove dependency result: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("dependency from %q to %q not found", issueID, dependsOnID)
-	}
-	return nil
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		result, err := tx.Exec(
+			`DELETE FROM dependencies
+				 WHERE issue_id = (SELECT id FROM issues WHERE public_id = ?)
+			   AND depends_on_id = (SELECT id FROM issues WHERE public_id = ?)`,
+			issueID,
+			dependsOnID,
+		)
+		if err != nil {
+			return fmt.Errorf("remove dependency: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check remove dependency result: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("dependency from %q to %q %w", issueID, dependsOnID, ErrIssueNotFound)
+		}
+		return r.recordEvent(tx, issueID, model.EventDependencyRemoved, "depends_on", stringPtr(dependsOnID), nil)
+	})
 }
 
-// ReadyIssues lists open work with no open blockers.
-func (r *IssueRepo) ReadyIssues() ([]model.Issue, error) {
+// ReadyIssues lists open work with no open blockers, narrowed by optional filters.
+func (r *IssueRepo) ReadyIssues(filter model.ListFilter) ([]model.Issue, error) {
+	where, args := readyConditions(filter)
 	rows, err := r.db.Query(fmt.Sprintf(`
 		SELECT %s
 		FROM issues i
 		LEFT JOIN issues p ON p.id = i.parent_id
-		WHERE i.status IN ('open', 'in_progress')
-		  AND (i.claim_expires_at IS NULL OR i.claim_expires_at <= CURRENT_TIMESTAMP)
-		  AND i.type != 'epic'
-		  AND NOT EXISTS (
-			SELECT 1
-			FROM dependencies d
-			JOIN issues b ON b.id = d.depends_on_id
-			WHERE d.issue_id = i.id
-			  AND b.status != 'closed'
-		  )
-		ORDER BY i.priority ASC, i.id ASC`, issueSelectColumns))
+		WHERE %s
+		ORDER BY i.priority ASC, i.id ASC`, issueSelectColumns, strings.Join(where, "\n\t\t  AND ")), args...)
 	if err != nil {
 		return nil, fmt.Errorf("query ready issues: %w", err)
 	}
@@ -446,6 +498,36 @@ func (r *IssueRepo) ReadyIssues() ([]model.Issue, error) {
 	return scanIssues(rows)
 }
 
+// readyConditions builds the ready-work predicates for issue alias i and parent alias p.
+func readyConditions(filter model.ListFilter) ([]string, []any) {
+	where := []string{
+		"i.status IN ('open', 'in_progress')",
+		"(i.claim_expires_at IS NULL OR i.claim_expires_at <= CURRENT_TIMESTAMP)",
+		"i.type != 'epic'",
+		`NOT EXISTS (
+			SELECT 1
+			FROM dependencies d
+			JOIN issues b ON b.id = d.depends_on_id
+			WHERE d.issue_id = i.id
+			  AND b.status != 'closed'
+		  )`,
+	}
+	args := make([]any, 0)
+	if filter.Type != "" {
+		where = append(where, "i.type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.ParentID != "" {
+		where = append(where, "p.public_id = ?")
+		args = append(args, filter.ParentID)
+	}
+	if filter.PriorityMax != nil {
+		where = append(where, "i.priority <= ?")
+		args = append(args, *filter.PriorityMax)
+	}
+	return appendLabelFilter(where, args, filter)
+}
+
 // OpenIssueCount returns the number of non-closed issues.
 func (r *IssueRepo) OpenIssueCount() (int64, error) {
 	var count int64
@@ -477,24 +559,11 @@ func scanIssues(rows *sql.Rows) ([]model.Issue, error) {
 	issues := make([]model.Issue, 0)
 	for rows.Next() {
 		var issue model.Issue
-		if err := rows.Scan(
-			&issue.InternalID,
-			&issue.ID,
-			&issue.Title,
-			&issue.Description,
-			&issue.Type,
-			&issue.Priority,
-			&issue.Status,
-			&issue.ClaimedAt,
-			&issue.ClaimExpiresAt,
-			&issue.ParentInternal,
-			&issue.ParentID,
-			&issue.CreatedAt,
-			&issue.UpdatedAt,
-			&issue.ClosedAt,
-		); err != nil {
+		var labels sql.NullString
+		if err := rows.Scan(issueScanTargets(&issue, &labels)...); err != nil {
 			return nil, fmt.Errorf("scan issue row: %w", err)
 		}
+		issue.Labels = splitLabels(labels)
 		issues = append(issues, issue)
 	}
 	if err := rows.Err(); err != nil {
@@ -504,14 +573,118 @@ func scanIssues(rows *sql.Rows) ([]model.Issue, error) {
 	return issues, nil
 }
 
+// issueScanTargets returns scan destinations matching issueSelectColumns.
+func issueScanTargets(issue *model.Issue, labels *sql.NullString) []any {
+	return []any{
+		&issue.InternalID,
+		&issue.ID,
+		&issue.Title,
+		&issue.Description,
+		&issue.Type,
+		&issue.Priority,
+		&issue.Estimate,
+		&issue.Status,
+		&issue.ClaimedAt,
+		&issue.ClaimExpiresAt,
+		&issue.ClaimedBy,
+		&issue.ClaimWorktree,
+		&issue.ClaimBranch,
+		&issue.ParentInternal,
+		&issue.ParentID,
+		&issue.CreatedAt,
+		&issue.UpdatedAt,
+		&issue.ClosedAt,
+		labels,
+	}
+}
+
 // ClaimIssue atomically claims an issue by moving it to in_progress with a TTL.
 func (r *IssueRepo) ClaimIssue(publicID string, lease time.Duration) error {
 	modifier := fmt.Sprintf("+%d seconds", int(lease.Seconds()))
-	result, err := r.execWithRetry(
+	claimed := false
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		var err error
+		claimed, err = r.claimInTx(tx, publicID, modifier)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if claimed {
+		return nil
+	}
+
+	issue, getErr := r.GetIssue(publicID)
+	if getErr != nil {
+		return getErr
+	}
+	if issue.Status == "closed" {
+		return fmt.Errorf("issue %q %w", publicID, ErrIssueClosed)
+	}
+	if issue.Type == "epic" {
+		return ErrIssueTypeNotClaimable
+	}
+	if issue.ClaimExpiresAt != nil && issue.ClaimExpiresAt.After(time.Now()) {
+		return ErrIssueAlreadyClaimed
+	}
+	return ErrIssueAlreadyClaimed
+}
+
+// ClaimNextReady selects and claims the highest-priority ready issue matching filter in one transaction.
+func (r *IssueRepo) ClaimNextReady(filter model.ListFilter, lease time.Duration) (string, error) {
+	modifier := fmt.Sprintf("+%d seconds", int(lease.Seconds()))
+	where, args := readyConditions(filter)
+	query := fmt.Sprintf(`
+		SELECT i.public_id
+		FROM issues i
+		LEFT JOIN issues p ON p.id = i.parent_id
+		WHERE %s
+		ORDER BY i.priority ASC, i.id ASC
+		LIMIT 1`, strings.Join(where, "\n\t\t  AND "))
+
+	claimedID := ""
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		claimedID = ""
+		var candidate string
+		if err := tx.QueryRow(query, args...).Scan(&candidate); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNoReadyIssue
+			}
+			return fmt.Errorf("select next ready issue: %w", err)
+		}
+		claimed, err := r.claimInTx(tx, candidate, modifier)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return fmt.Errorf("claim next ready issue %q: %w", candidate, ErrIssueAlreadyClaimed)
+		}
+		claimedID = candidate
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return claimedID, nil
+}
+
+// claimInTx moves one claimable issue to in_progress and reports whether a row changed.
+func (r *IssueRepo) claimInTx(tx *sql.Tx, publicID, modifier string) (bool, error) {
+	before, err := auditedValues(tx, publicID)
+	if err != nil {
+		if errors.Is(err, ErrIssueNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	result, err := tx.Exec(
 		`UPDATE issues
 			 SET status = 'in_progress',
 		     claimed_at = CURRENT_TIMESTAMP,
-		     claim_expires_at = DATETIME(CURRENT_TIMESTAMP, ?)
+		     claim_expires_at = DATETIME(CURRENT_TIMESTAMP, ?),
+		     claimed_by = ?,
+		     claimed_worktree = NULLIF(?, ''),
+		     claimed_branch = NULLIF(?, '')
 		 WHERE public_id = ?
 		   AND status != 'closed'
 		   AND type != 'epic'
@@ -520,34 +693,114 @@ func (r *IssueRepo) ClaimIssue(publicID string, lease time.Duration) error {
 			OR (claim_expires_at <= CURRENT_TIMESTAMP)
 		   )`,
 		modifier,
+		r.actorName(),
+		r.worktree,
+		r.branch,
 		publicID,
 	)
 	if err != nil {
-		return fmt.Errorf("claim issue: %w", err)
+		return false, fmt.Errorf("claim issue: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("check claim result: %w", err)
+		return false, fmt.Errorf("check claim result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	if err := r.recordEvent(tx, publicID, model.EventClaimed, "status", before["status"], stringPtr("in_progress")); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RenewClaim extends the lease of an issue held by the configured actor.
+func (r *IssueRepo) RenewClaim(publicID string, lease time.Duration) error {
+	modifier := fmt.Sprintf("+%d seconds", int(lease.Seconds()))
+	result, err := r.execWithRetry(
+		`UPDATE issues
+			 SET claim_expires_at = DATETIME(CURRENT_TIMESTAMP, ?)
+		 WHERE public_id = ?
+		   AND status = 'in_progress'
+		   AND claimed_by = ?`,
+		modifier,
+		publicID,
+		r.actorName(),
+	)
+	if err != nil {
+		return fmt.Errorf("renew claim: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check renew result: %w", err)
 	}
 	if rowsAffected > 0 {
 		return nil
 	}
+	return r.claimOwnershipError(publicID)
+}
 
-	issue, getErr := r.GetIssue(publicID)
-	if getErr != nil {
-		return getErr
+// ReleaseIssue returns a claimed issue to open when the actor owns it or its lease has expired.
+func (r *IssueRepo) ReleaseIssue(publicID string) error {
+	released := false
+	err := r.withTxRetry(func(tx *sql.Tx) error {
+		released = false
+		result, err := tx.Exec(
+			`UPDATE issues
+				 SET status = 'open',
+			     claimed_at = NULL,
+			     claim_expires_at = NULL,
+			     claimed_by = NULL,
+			     claimed_worktree = NULL,
+			     claimed_branch = NULL
+			 WHERE public_id = ?
+			   AND status = 'in_progress'
+			   AND (
+				claimed_by IS NULL
+				OR claimed_by = ?
+				OR claim_expires_at IS NULL
+				OR claim_expires_at <= CURRENT_TIMESTAMP
+			   )`,
+			publicID,
+			r.actorName(),
+		)
+		if err != nil {
+			return fmt.Errorf("release issue: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("check release result: %w", err)
+		}
+		if rowsAffected == 0 {
+			return nil
+		}
+		released = true
+		return r.recordEvent(tx, publicID, model.EventReleased, "status", stringPtr("in_progress"), stringPtr("open"))
+	})
+	if err != nil {
+		return err
 	}
-	if issue.Status == "closed" {
-		return fmt.Errorf("issue %q is closed", publicID)
+	if released {
+		return nil
 	}
-	if issue.Type == "epic" {
-		return ErrIssueTypeNotClaimable
+	return r.claimOwnershipError(publicID)
+}
+
+// claimOwnershipError explains why a lease operation by the actor matched no row.
+func (r *IssueRepo) claimOwnershipError(publicID string) error {
+	issue, err := r.GetIssue(publicID)
+	if err != nil {
+		return err
 	}
-	if issue.ClaimExpiresAt != nil && issue.ClaimExpiresAt.After(time.Now()) {
-		return ErrIssueAlreadyClaimed
+	if issue.Status != "in_progress" {
+		return fmt.Errorf("%w: %s is %s", ErrIssueNotClaimed, publicID, issue.Status)
 	}
-	return ErrIssueAlreadyClaimed
+	owner := defaultActor
+	if issue.ClaimedBy != nil {
+		owner = *issue.ClaimedBy
+	}
+	return fmt.Errorf("%w: %s is held by %s", ErrNotClaimOwner, publicID, owner)
 }
 
 // execWithRetry retries transient sqlite busy/locked write errors.
@@ -570,6 +823,42 @@ func (r *IssueRepo) execWithRetry(query string, args ...any) (sql.Result, error)
 	return nil, fmt.Errorf("sqlite write failed after %d attempts", maxWriteAttempts)
 }
 
+// withTxRetry runs fn in one transaction, retrying the whole unit on transient lock errors.
+func (r *IssueRepo) withTxRetry(fn func(tx *sql.Tx) error) error {
+	var lastErr error
+	for attempt := 0; attempt < maxWriteAttempts; attempt++ {
+		err := r.runTx(fn)
+		if err == nil {
+			return nil
+		}
+		if !isRetryableWriteError(err) {
+			return err
+		}
+		lastErr = err
+		time.Sleep(writeBackoff(attempt))
+	}
+	if lastErr != nil {
+		return fmt.Errorf("sqlite write failed after %d attempts: %w", maxWriteAttempts, lastErr)
+	}
+	return fmt.Errorf("sqlite write failed after %d attempts", maxWriteAttempts)
+}
+
+// runTx executes fn inside a transaction and commits only when it succeeds.
+func (r *IssueRepo) runTx(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
 // isRetryableWriteError reports whether a write should retry.
 func isRetryableWriteError(err error) bool {
 	if err == nil {
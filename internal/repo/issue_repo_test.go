@@ -2,7 +2,10 @@ package repo
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -46,7 +49,7 @@ func TestReadyIssuesRespectsOpenBlockers(t *testing.T) {
 		t.Fatalf("add dependency: %v", err)
 	}
 
-	ready, err := repo.ReadyIssues()
+	ready, err := repo.ReadyIssues(model.ListFilter{})
 	if err != nil {
 		t.Fatalf("query ready: %v", err)
 	}
@@ -58,7 +61,7 @@ func TestReadyIssuesRespectsOpenBlockers(t *testing.T) {
 		t.Fatalf("close blocker: %v", err)
 	}
 
-	ready, err = repo.ReadyIssues()
+	ready, err = repo.ReadyIssues(model.ListFilter{})
 	if err != nil {
 		t.Fatalf("query ready after close: %v", err)
 	}
@@ -163,7 +166,7 @@ func TestReadyIssuesIncludesExpiredInProgressClaims(t *testing.T) {
 
 	time.Sleep(80 * time.Millisecond)
 
-	ready, err := repo.ReadyIssues()
+	ready, err := repo.ReadyIssues(model.ListFilter{})
 	if err != nil {
 		t.Fatalf("query ready: %v", err)
 	}
@@ -442,6 +445,59 @@ func TestListIssuesOrdersByPublicIDNaturalChildOrder(t *testing.T) {
 	}
 }
 
+func TestListIssuesRestrictsToIDsWithinFilter(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+
+	for _, id := range []string{"faz-a111", "faz-b222", "faz-c333"} {
+		if _, err := repo.CreateIssue(model.Issue{ID: id, Title: id, Type: "task", Priority: 1, Status: "open"}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	if err := repo.CloseIssue("faz-b222"); err != nil {
+		t.Fatalf("close issue: %v", err)
+	}
+
+	ids := func(issues []model.Issue) []string {
+		out := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return out
+	}
+
+	open, err := repo.ListIssues(model.ListFilter{IDs: []string{"faz-a111", "faz-b222"}})
+	if err != nil {
+		t.Fatalf("list open by ids: %v", err)
+	}
+	if got := ids(open); !reflect.DeepEqual(got, []string{"faz-a111"}) {
+		t.Fatalf("open issues by ids = %v, want [faz-a111]", got)
+	}
+
+	all, err := repo.ListIssues(model.ListFilter{All: true, IDs: []string{"faz-a111", "faz-b222"}})
+	if err != nil {
+		t.Fatalf("list all by ids: %v", err)
+	}
+	if got := ids(all); !reflect.DeepEqual(got, []string{"faz-a111", "faz-b222"}) {
+		t.Fatalf("all issues by ids = %v, want [faz-a111 faz-b222]", got)
+	}
+}
+
 func TestListIssuesOrdersEpicsByTitleSequence(t *testing.T) {
 	projectDir := t.TempDir()
 	dbPath, err := db.EnsureProjectFiles(projectDir)
@@ -545,3 +601,977 @@ func TestListIssuesOrdersEpicsByTitleSequence(t *testing.T) {
 		t.Fatalf("unexpected epic sequence ordering, got=%v want=%v", gotIDs, wantIDs)
 	}
 }
+
+func TestLabelFiltersApplyToListAndReady(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-a111", Title: "Both", Type: "task", Priority: 1, Status: "open", Labels: []string{"db", "frontend"}}); err != nil {
+		t.Fatalf("create both: %v", err)
+	}
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-b111", Title: "Frontend", Type: "task", Priority: 1, Status: "open", Labels: []string{"frontend"}}); err != nil {
+		t.Fatalf("create frontend: %v", err)
+	}
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-c111", Title: "None", Type: "task", Priority: 1, Status: "open"}); err != nil {
+		t.Fatalf("create unlabeled: %v", err)
+	}
+	if err := repo.AddLabels("faz-c111", []string{"flaky-test"}); err != nil {
+		t.Fatalf("add label: %v", err)
+	}
+	if err := repo.RemoveLabels("faz-c111", []string{"flaky-test"}); err != nil {
+		t.Fatalf("remove label: %v", err)
+	}
+	if err := repo.RemoveLabels("faz-c111", []string{"flaky-test"}); !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("expected ErrIssueNotFound removing missing label, got %v", err)
+	}
+
+	issueIDs := func(issues []model.Issue) []string {
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	cases := []struct {
+		name   string
+		filter model.ListFilter
+		want   []string
+	}{
+		{name: "any", filter: model.ListFilter{Labels: []string{"db", "frontend"}, LabelMatch: model.LabelMatchAny}, want: []string{"faz-a111", "faz-b111"}},
+		{name: "all", filter: model.ListFilter{Labels: []string{"db", "frontend"}, LabelMatch: model.LabelMatchAll}, want: []string{"faz-a111"}},
+		{name: "none", filter: model.ListFilter{Labels: []string{"frontend"}, LabelMatch: model.LabelMatchNone}, want: []string{"faz-c111"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listed, err := repo.ListIssues(tc.filter)
+			if err != nil {
+				t.Fatalf("list issues: %v", err)
+			}
+			if got := issueIDs(listed); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("list ids = %v, want %v", got, tc.want)
+			}
+			ready, err := repo.ReadyIssues(tc.filter)
+			if err != nil {
+				t.Fatalf("ready issues: %v", err)
+			}
+			if got := issueIDs(ready); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ready ids = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	issue, err := repo.GetIssue("faz-a111")
+	if err != nil {
+		t.Fatalf("get issue: %v", err)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"db", "frontend"}) {
+		t.Fatalf("issue labels = %v", issue.Labels)
+	}
+
+	counts, err := repo.ListLabels()
+	if err != nil {
+		t.Fatalf("list labels: %v", err)
+	}
+	if len(counts) != 2 || counts[1].Name != "frontend" || counts[1].Issues != 2 {
+		t.Fatalf("unexpected label counts: %+v", counts)
+	}
+}
+
+func TestListCommentsReturnsNewestWindowOldestFirst(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-a111", Title: "Task", Type: "task", Priority: 1, Status: "open"}); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	for _, body := range []string{"first", "second", "third"} {
+		if _, err := repo.AddComment("faz-a111", "agent", body); err != nil {
+			t.Fatalf("add comment %q: %v", body, err)
+		}
+	}
+
+	comments, err := repo.ListComments("faz-a111", 2)
+	if err != nil {
+		t.Fatalf("list comments: %v", err)
+	}
+	if len(comments) != 2 || comments[0].Body != "second" || comments[1].Body != "third" {
+		t.Fatalf("unexpected comment window: %+v", comments)
+	}
+
+	if _, err := repo.AddComment("faz-zzzz", "agent", "orphan"); !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("expected not found for missing issue, got %v", err)
+	}
+}
+
+func TestMutationsAppendAuditEvents(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	repo.SetActor("agent-7")
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-a111", Title: "Task", Type: "task", Priority: 2, Status: "open"}); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := repo.UpdateIssue("faz-a111", map[string]any{"priority": 0, "title": "Task"}); err != nil {
+		t.Fatalf("update issue: %v", err)
+	}
+	if err := repo.CloseIssue("faz-a111"); err != nil {
+		t.Fatalf("close issue: %v", err)
+	}
+	if err := repo.ReopenIssue("faz-a111"); err != nil {
+		t.Fatalf("reopen issue: %v", err)
+	}
+	if err := repo.DeleteIssue("faz-a111"); err != nil {
+		t.Fatalf("delete issue: %v", err)
+	}
+
+	events, err := repo.ListIssueEvents("faz-a111")
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	want := []struct{ action, field, oldValue, newValue string }{
+		{model.EventCreated, "title", "", "Task"},
+		{model.EventUpdated, "priority", "2", "0"},
+		{model.EventClosed, "status", "open", "closed"},
+		{model.EventReopened, "status", "closed", "open"},
+		{model.EventDeleted, "title", "Task", ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, expected := range want {
+		event := events[i]
+		if event.Action != expected.action || event.Field != expected.field || event.Actor != "agent-7" {
+			t.Fatalf("event %d = %+v, want %+v", i, event, expected)
+		}
+		if got := derefOr(event.OldValue); got != expected.oldValue {
+			t.Fatalf("event %d old value = %q, want %q", i, got, expected.oldValue)
+		}
+		if got := derefOr(event.NewValue); got != expected.newValue {
+			t.Fatalf("event %d new value = %q, want %q", i, got, expected.newValue)
+		}
+	}
+
+	recent, err := repo.ListEventsSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("list recent events: %v", err)
+	}
+	if len(recent) != len(want) {
+		t.Fatalf("expected %d recent events, got %d", len(want), len(recent))
+	}
+}
+
+// derefOr returns the pointed-to string or empty for nil.
+func derefOr(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func TestRenewAndReleaseRequireClaimOwner(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	owner := NewIssueRepo(sqlDB)
+	owner.SetActor("agent-a")
+	other := NewIssueRepo(sqlDB)
+	other.SetActor("agent-b")
+
+	if _, err := owner.CreateIssue(model.Issue{ID: "faz-a111", Title: "Task", Type: "task", Priority: 1, Status: "open"}); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := owner.RenewClaim("faz-a111", time.Minute); !errors.Is(err, ErrIssueNotClaimed) {
+		t.Fatalf("expected not claimed before claim, got %v", err)
+	}
+	if err := owner.ClaimIssue("faz-a111", time.Minute); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+
+	issue, err := owner.GetIssue("faz-a111")
+	if err != nil {
+		t.Fatalf("get issue: %v", err)
+	}
+	if issue.ClaimedBy == nil || *issue.ClaimedBy != "agent-a" {
+		t.Fatalf("expected claimed_by agent-a, got %v", issue.ClaimedBy)
+	}
+
+	if err := other.RenewClaim("faz-a111", time.Hour); !errors.Is(err, ErrNotClaimOwner) {
+		t.Fatalf("expected not owner on renew, got %v", err)
+	}
+	if err := other.ReleaseIssue("faz-a111"); !errors.Is(err, ErrNotClaimOwner) {
+		t.Fatalf("expected not owner on release, got %v", err)
+	}
+	if err := owner.RenewClaim("faz-a111", time.Hour); err != nil {
+		t.Fatalf("renew claim: %v", err)
+	}
+	if err := owner.ReleaseIssue("faz-a111"); err != nil {
+		t.Fatalf("release issue: %v", err)
+	}
+
+	issue, err = owner.GetIssue("faz-a111")
+	if err != nil {
+		t.Fatalf("get released issue: %v", err)
+	}
+	if issue.Status != "open" || issue.ClaimedBy != nil || issue.ClaimExpiresAt != nil {
+		t.Fatalf("expected released issue to be open and unclaimed, got %+v", issue)
+	}
+}
+
+func TestClaimNextReadyPicksHighestPriorityMatchingIssue(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	repo.SetActor("agent-a")
+	for _, issue := range []model.Issue{
+		{ID: "faz-a111", Title: "Low task", Type: "task", Priority: 3, Status: "open"},
+		{ID: "faz-b111", Title: "Urgent bug", Type: "bug", Priority: 0, Status: "open"},
+		{ID: "faz-c111", Title: "Blocked task", Type: "task", Priority: 0, Status: "open"},
+		{ID: "faz-d111", Title: "Mid task", Type: "task", Priority: 1, Status: "open", Labels: []string{"db"}},
+	} {
+		if _, err := repo.CreateIssue(issue); err != nil {
+			t.Fatalf("create %s: %v", issue.ID, err)
+		}
+	}
+	if err := repo.AddDependency("faz-c111", "faz-a111"); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	id, err := repo.ClaimNextReady(model.ListFilter{Type: "task"}, time.Minute)
+	if err != nil {
+		t.Fatalf("claim next task: %v", err)
+	}
+	if id != "faz-d111" {
+		t.Fatalf("expected unblocked P1 task faz-d111, got %s", id)
+	}
+
+	id, err = repo.ClaimNextReady(model.ListFilter{}, time.Minute)
+	if err != nil {
+		t.Fatalf("claim next: %v", err)
+	}
+	if id != "faz-b111" {
+		t.Fatalf("expected P0 bug faz-b111, got %s", id)
+	}
+
+	priorityMax := 2
+	if _, err := repo.ClaimNextReady(model.ListFilter{PriorityMax: &priorityMax}, time.Minute); !errors.Is(err, ErrNoReadyIssue) {
+		t.Fatalf("expected no ready issue within P0-P2, got %v", err)
+	}
+
+	issue, err := repo.GetIssue("faz-b111")
+	if err != nil {
+		t.Fatalf("get claimed issue: %v", err)
+	}
+	if issue.Status != "in_progress" || issue.ClaimedBy == nil || *issue.ClaimedBy != "agent-a" {
+		t.Fatalf("expected claimed bug owned by agent-a, got %+v", issue)
+	}
+}
+
+func TestClaimNextReadyNeverHandsOutTheSameIssueTwice(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	setupDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.Migrate(setupDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+	setup := NewIssueRepo(setupDB)
+	const issueCount = 4
+	for i := 0; i < issueCount; i++ {
+		if _, err := setup.CreateIssue(model.Issue{ID: fmt.Sprintf("faz-%04d", i), Title: "Task", Type: "task", Priority: 2, Status: "open"}); err != nil {
+			t.Fatalf("create issue: %v", err)
+		}
+	}
+	_ = setupDB.Close()
+
+	const agents = 6
+	results := make(chan string, agents)
+	errs := make(chan error, agents)
+	var wg sync.WaitGroup
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func(agent int) {
+			defer wg.Done()
+			agentDB, err := db.Open(dbPath)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer func() { _ = agentDB.Close() }()
+			agentRepo := NewIssueRepo(agentDB)
+			agentRepo.SetActor(fmt.Sprintf("agent-%d", agent))
+			id, err := agentRepo.ClaimNextReady(model.ListFilter{}, time.Minute)
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- id
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+	close(errs)
+
+	seen := make(map[string]bool)
+	for id := range results {
+		if seen[id] {
+			t.Fatalf("issue %s was claimed twice", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != issueCount {
+		t.Fatalf("expected %d distinct claims, got %d", issueCount, len(seen))
+	}
+	for err := range errs {
+		if !errors.Is(err, ErrNoReadyIssue) {
+			t.Fatalf("unexpected claim error: %v", err)
+		}
+	}
+}
+
+func TestAddDependencyCycleCheckHandlesDiamonds(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	// Chain 40 diamonds: top -> left/right -> bottom, each bottom the next top.
+	// Enumerating every path would take 2^40 steps.
+	repo := NewIssueRepo(sqlDB)
+	create := func(id string) {
+		if _, err := repo.CreateIssue(model.Issue{ID: id, Title: id, Type: "task", Priority: 1, Status: "open"}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	depend := func(from, to string) {
+		if err := repo.AddDependency(from, to); err != nil {
+			t.Fatalf("add %s->%s: %v", from, to, err)
+		}
+	}
+	top := "faz-n000"
+	create(top)
+	for i := 0; i < 40; i++ {
+		left, right, bottom := fmt.Sprintf("faz-l%03d", i), fmt.Sprintf("faz-r%03d", i), fmt.Sprintf("faz-n%03d", i+1)
+		create(left)
+		create(right)
+		create(bottom)
+		depend(top, left)
+		depend(top, right)
+		depend(left, bottom)
+		depend(right, bottom)
+		top = bottom
+	}
+
+	err = repo.AddDependency(top, "faz-n000")
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if want := top + " -> faz-n000 -> faz-l000 -> faz-n001"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected cycle path starting %q in %q", want, err.Error())
+	}
+}
+
+func TestAddDependencyRejectsCyclesWithPath(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	for _, id := range []string{"faz-a111", "faz-b111", "faz-c111"} {
+		if _, err := repo.CreateIssue(model.Issue{ID: id, Title: id, Type: "task", Priority: 1, Status: "open"}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	if err := repo.AddDependency("faz-a111", "faz-b111"); err != nil {
+		t.Fatalf("add a->b: %v", err)
+	}
+	if err := repo.AddDependency("faz-b111", "faz-c111"); err != nil {
+		t.Fatalf("add b->c: %v", err)
+	}
+
+	err = repo.AddDependency("faz-c111", "faz-a111")
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if want := "faz-c111 -> faz-a111 -> faz-b111 -> faz-c111"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected cycle path %q in %q", want, err.Error())
+	}
+	if err := repo.AddDependency("faz-a111", "faz-a111"); !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("expected self-dependency to be reported as a cycle, got %v", err)
+	}
+
+	problems, err := repo.CheckDependencies()
+	if err != nil {
+		t.Fatalf("check dependencies: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected healthy graph, got %+v", problems)
+	}
+
+	if _, err := sqlDB.Exec(`INSERT INTO dependencies(issue_id, depends_on_id)
+		SELECT c.id, a.id FROM issues c, issues a WHERE c.public_id = 'faz-c111' AND a.public_id = 'faz-a111'`); err != nil {
+		t.Fatalf("insert legacy cycle: %v", err)
+	}
+	problems, err = repo.CheckDependencies()
+	if err != nil {
+		t.Fatalf("check dependencies with cycle: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Kind != model.DependencyProblemCycle {
+		t.Fatalf("expected one cycle problem, got %+v", problems)
+	}
+	if got := strings.Join(problems[0].Path, " -> "); got != "faz-a111 -> faz-b111 -> faz-c111 -> faz-a111" {
+		t.Fatalf("unexpected cycle path %q", got)
+	}
+}
+
+func TestCheckDependenciesReportsOpenBlockersUnderClosedEpics(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	epicID := "faz-e111"
+	if _, err := repo.CreateIssue(model.Issue{ID: epicID, Title: "Epic", Type: "epic", Priority: 1, Status: "open"}); err != nil {
+		t.Fatalf("create epic: %v", err)
+	}
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-e111.1", Title: "Leftover", Type: "task", Priority: 1, Status: "open", ParentID: &epicID}); err != nil {
+		t.Fatalf("create leftover: %v", err)
+	}
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-a111", Title: "Waiting", Type: "task", Priority: 1, Status: "open"}); err != nil {
+		t.Fatalf("create waiting: %v", err)
+	}
+	if err := repo.AddDependency("faz-a111", "faz-e111.1"); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if err := repo.CloseIssue(epicID); err != nil {
+		t.Fatalf("close epic: %v", err)
+	}
+
+	problems, err := repo.CheckDependencies()
+	if err != nil {
+		t.Fatalf("check dependencies: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Kind != model.DependencyProblemClosedParent || problems[0].DependsOnID != "faz-e111.1" {
+		t.Fatalf("expected closed-parent problem, got %+v", problems)
+	}
+}
+
+func TestSearchIssuesTracksIssueAndCommentChanges(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	loginID, err := repo.CreateIssue(model.Issue{ID: "faz-ab12", Title: "Login page flaky", Description: "Session store times out", Type: "bug", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create login issue: %v", err)
+	}
+	checkoutID, err := repo.CreateIssue(model.Issue{ID: "faz-cd34", Title: "Checkout tax", Description: "Rounding error", Type: "task", Priority: 2, Status: "open"})
+	if err != nil {
+		t.Fatalf("create checkout issue: %v", err)
+	}
+
+	searchIDs := func(match string, filter model.ListFilter) []string {
+		t.Helper()
+		results, err := repo.SearchIssues(match, filter, 0)
+		if err != nil {
+			t.Fatalf("search %q: %v", match, err)
+		}
+		ids := make([]string, 0, len(results))
+		for _, result := range results {
+			ids = append(ids, result.Issue.ID)
+		}
+		return ids
+	}
+
+	if got := searchIDs(`"session"*`, model.ListFilter{}); !reflect.DeepEqual(got, []string{loginID}) {
+		t.Fatalf("description search = %v", got)
+	}
+
+	if _, err := repo.AddComment(checkoutID, "agent-7", "Maybe the session cookie is stale"); err != nil {
+		t.Fatalf("add comment: %v", err)
+	}
+	if got := searchIDs(`"session"*`, model.ListFilter{}); len(got) != 2 {
+		t.Fatalf("expected comment to be indexed, got %v", got)
+	}
+	if got := searchIDs(`"session"*`, model.ListFilter{Type: "task"}); !reflect.DeepEqual(got, []string{checkoutID}) {
+		t.Fatalf("type-filtered search = %v", got)
+	}
+
+	if err := repo.UpdateIssue(loginID, map[string]any{"title": "Signin page session drops"}); err != nil {
+		t.Fatalf("update title: %v", err)
+	}
+	results, err := repo.SearchIssues(`"session"*`, model.ListFilter{}, 1)
+	if err != nil {
+		t.Fatalf("ranked search: %v", err)
+	}
+	if len(results) != 1 || results[0].Issue.ID != loginID {
+		t.Fatalf("expected title hit to rank first, got %+v", results)
+	}
+	if !strings.Contains(results[0].Snippet, model.SearchHighlightStart+"session") {
+		t.Fatalf("expected highlighted snippet, got %q", results[0].Snippet)
+	}
+	if got := searchIDs(`"login"*`, model.ListFilter{}); len(got) != 0 {
+		t.Fatalf("expected old title to drop out of the index, got %v", got)
+	}
+
+	if err := repo.CloseIssue(loginID); err != nil {
+		t.Fatalf("close issue: %v", err)
+	}
+	if got := searchIDs(`"signin"*`, model.ListFilter{}); len(got) != 0 {
+		t.Fatalf("expected closed issue to be hidden by default, got %v", got)
+	}
+	if got := searchIDs(`"signin"*`, model.ListFilter{All: true}); !reflect.DeepEqual(got, []string{loginID}) {
+		t.Fatalf("search with --all = %v", got)
+	}
+
+	if err := repo.DeleteIssue(checkoutID); err != nil {
+		t.Fatalf("delete issue: %v", err)
+	}
+	if got := searchIDs(`"rounding"*`, model.ListFilter{All: true}); len(got) != 0 {
+		t.Fatalf("expected deleted issue to leave the index, got %v", got)
+	}
+
+	if _, err := sqlDB.Exec(`DROP TABLE issues_fts`); err != nil {
+		t.Fatalf("drop search index: %v", err)
+	}
+	if _, err := sqlDB.Exec(`PRAGMA user_version = 1`); err != nil {
+		t.Fatalf("rewind schema version: %v", err)
+	}
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("re-migrate db: %v", err)
+	}
+	if got := searchIDs(`"signin"*`, model.ListFilter{All: true}); !reflect.DeepEqual(got, []string{loginID}) {
+		t.Fatalf("expected migrate to backfill the index, got %v", got)
+	}
+}
+
+func TestListEventsAfterStreamsChangeLogBySequence(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	latest, err := repo.LatestEventID()
+	if err != nil || latest != 0 {
+		t.Fatalf("empty log latest = %d, %v", latest, err)
+	}
+
+	issueID, err := repo.CreateIssue(model.Issue{ID: "faz-ab12", Title: "Login page", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	cursor, err := repo.LatestEventID()
+	if err != nil {
+		t.Fatalf("latest after create: %v", err)
+	}
+	if err := repo.ClaimIssue(issueID, time.Minute); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+	if err := repo.CloseIssue(issueID); err != nil {
+		t.Fatalf("close issue: %v", err)
+	}
+
+	events, err := repo.ListEventsAfter(cursor, 0)
+	if err != nil {
+		t.Fatalf("list events after %d: %v", cursor, err)
+	}
+	if len(events) != 2 || events[0].Action != model.EventClaimed || events[1].Action != model.EventClosed {
+		t.Fatalf("expected claimed then closed after cursor, got %+v", events)
+	}
+	if events[0].ID <= cursor || events[1].ID <= events[0].ID {
+		t.Fatalf("expected increasing sequence after %d, got %d, %d", cursor, events[0].ID, events[1].ID)
+	}
+
+	limited, err := repo.ListEventsAfter(0, 1)
+	if err != nil || len(limited) != 1 || limited[0].Action != model.EventCreated {
+		t.Fatalf("limited events = %+v, %v", limited, err)
+	}
+}
+
+func TestImportExportUpsertsByIDAndReportsConflicts(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-ab12", Title: "Local", Type: "task", Priority: 2, Status: "open"}); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	future := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	data := model.Export{
+		Issues: []model.ExportedIssue{
+			{ID: "faz-ab12", Title: "Stale remote", Type: "task", Priority: 1, Status: "open", CreatedAt: past, UpdatedAt: past},
+			{ID: "faz-cd34", Title: "Remote child", Type: "task", Priority: 1, Status: "open", ParentID: stringPtr("faz-ef56"), Labels: []string{"ui"}, CreatedAt: past, UpdatedAt: future},
+			{ID: "faz-ef56", Title: "Remote epic", Type: "epic", Priority: 0, Status: "open", CreatedAt: past, UpdatedAt: past},
+		},
+		Dependencies: []model.Dependency{
+			{IssueID: "faz-cd34", DependsOnID: "faz-ab12"},
+			{IssueID: "faz-ab12", DependsOnID: "faz-cd34"},
+			{IssueID: "faz-cd34", DependsOnID: "faz-zz99"},
+		},
+	}
+
+	preview, err := repo.ImportExport(data, false, true, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("dry run import: %v", err)
+	}
+	if !preview.DryRun || len(preview.Created) != 2 {
+		t.Fatalf("unexpected dry run report: %+v", preview)
+	}
+	if _, err := repo.GetIssue("faz-cd34"); !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("dry run wrote faz-cd34: %v", err)
+	}
+
+	report, err := repo.ImportExport(data, false, false, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !reflect.DeepEqual(report.Created, []string{"faz-cd34", "faz-ef56"}) || len(report.Updated) != 0 {
+		t.Fatalf("unexpected created/updated: %+v", report)
+	}
+	if report.DependenciesAdded != 1 {
+		t.Fatalf("expected 1 dependency added, got %d", report.DependenciesAdded)
+	}
+	reasons := make([]string, 0, len(report.Conflicts))
+	for _, conflict := range report.Conflicts {
+		reasons = append(reasons, conflict.Reason)
+	}
+	want := []string{model.ImportConflictLocalNewer, model.ImportConflictCycle, model.ImportConflictMissingIssue}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Fatalf("conflict reasons = %v, want %v", reasons, want)
+	}
+
+	local, err := repo.GetIssue("faz-ab12")
+	if err != nil || local.Title != "Local" {
+		t.Fatalf("local issue should be kept, got %+v, %v", local, err)
+	}
+	child, err := repo.GetIssue("faz-cd34")
+	if err != nil {
+		t.Fatalf("get imported child: %v", err)
+	}
+	if derefOr(child.ParentID) != "faz-ef56" || !child.UpdatedAt.Equal(future) || !reflect.DeepEqual(child.Labels, []string{"ui"}) {
+		t.Fatalf("imported child not stored as exported: %+v", child)
+	}
+
+	again, err := repo.ImportExport(data, true, false, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("overwrite import: %v", err)
+	}
+	if again.Unchanged != 2 || !reflect.DeepEqual(again.Updated, []string{"faz-ab12"}) {
+		t.Fatalf("unexpected overwrite report: %+v", again)
+	}
+	local, err = repo.GetIssue("faz-ab12")
+	if err != nil || local.Title != "Stale remote" || !local.UpdatedAt.Equal(past) {
+		t.Fatalf("overwrite should replace local issue, got %+v, %v", local, err)
+	}
+}
+
+func TestImportExportAppliesTombstones(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	for _, id := range []string{"faz-ab12", "faz-cd34", "faz-ef56"} {
+		if _, err := repo.CreateIssue(model.Issue{ID: id, Title: id, Type: "task", Priority: 2, Status: "open"}); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	if err := repo.DeleteIssue("faz-ab12"); err != nil {
+		t.Fatalf("delete issue: %v", err)
+	}
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	future := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	report, err := repo.ImportExport(model.Export{Tombstones: []model.Tombstone{
+		{ID: "faz-cd34", DeletedAt: future},
+		{ID: "faz-ef56", DeletedAt: past},
+	}}, false, false, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("import tombstones: %v", err)
+	}
+	if !reflect.DeepEqual(report.Deleted, []string{"faz-cd34"}) || len(report.Conflicts) != 1 || report.Conflicts[0].IssueID != "faz-ef56" {
+		t.Fatalf("unexpected tombstone report: %+v", report)
+	}
+
+	tombstones, err := repo.ListTombstones()
+	if err != nil {
+		t.Fatalf("list tombstones: %v", err)
+	}
+	if len(tombstones) != 2 || tombstones[0].ID != "faz-ab12" || tombstones[1].ID != "faz-cd34" || !tombstones[1].DeletedAt.Equal(future) {
+		t.Fatalf("unexpected tombstones: %+v", tombstones)
+	}
+}
+
+func TestImportExportLeasesImportedClaims(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	repo.SetActor("agent-1")
+	repo.SetWorkspace("/work/agent-1", "feature/login")
+	if _, err := repo.CreateIssue(model.Issue{ID: "faz-ab12", Title: "Local claim", Type: "task", Priority: 2, Status: "open"}); err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := repo.ClaimIssue("faz-ab12", time.Hour); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+	held, err := repo.GetIssue("faz-ab12")
+	if err != nil {
+		t.Fatalf("get claimed issue: %v", err)
+	}
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	future := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	data := model.Export{Issues: []model.ExportedIssue{
+		{ID: "faz-ab12", Title: "Same owner", Type: "task", Priority: 2, Status: "in_progress", ClaimedBy: stringPtr("agent-1"), CreatedAt: past, UpdatedAt: future},
+		{ID: "faz-cd34", Title: "Remote claim", Type: "task", Priority: 2, Status: "in_progress", ClaimedBy: stringPtr("agent-2"), CreatedAt: past, UpdatedAt: past},
+	}}
+	if _, err := repo.ImportExport(data, false, false, 5*time.Minute); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	kept, err := repo.GetIssue("faz-ab12")
+	if err != nil {
+		t.Fatalf("get kept claim: %v", err)
+	}
+	if kept.ClaimExpiresAt == nil || !kept.ClaimExpiresAt.Equal(*held.ClaimExpiresAt) || derefOr(kept.ClaimWorktree) != "/work/agent-1" {
+		t.Fatalf("same-owner import should keep the local lease, got %+v", kept)
+	}
+
+	imported, err := repo.GetIssue("faz-cd34")
+	if err != nil {
+		t.Fatalf("get imported claim: %v", err)
+	}
+	if imported.ClaimedAt == nil || imported.ClaimExpiresAt == nil {
+		t.Fatalf("imported claim has no lease: %+v", imported)
+	}
+	if remaining := time.Until(*imported.ClaimExpiresAt); remaining <= 0 || remaining > 5*time.Minute+time.Second {
+		t.Fatalf("imported lease should run for the default ttl, expires in %s", remaining)
+	}
+	if err := repo.ClaimIssue("faz-cd34", time.Minute); !errors.Is(err, ErrIssueAlreadyClaimed) {
+		t.Fatalf("imported claim should block other claimers, got %v", err)
+	}
+}
+
+func TestClaimRecordsWorkspaceUntilRelease(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	repo := NewIssueRepo(sqlDB)
+	repo.SetActor("agent-1")
+	repo.SetWorkspace("/work/agent-1", "feature/login")
+	issueID, err := repo.CreateIssue(model.Issue{ID: "faz-ab12", Title: "Login", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := repo.ClaimIssue(issueID, time.Minute); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+
+	claimed, err := repo.GetIssue(issueID)
+	if err != nil {
+		t.Fatalf("get claimed issue: %v", err)
+	}
+	if derefOr(claimed.ClaimWorktree) != "/work/agent-1" || derefOr(claimed.ClaimBranch) != "feature/login" {
+		t.Fatalf("claim workspace = %q %q", derefOr(claimed.ClaimWorktree), derefOr(claimed.ClaimBranch))
+	}
+
+	if err := repo.ReleaseIssue(issueID); err != nil {
+		t.Fatalf("release issue: %v", err)
+	}
+	released, err := repo.GetIssue(issueID)
+	if err != nil {
+		t.Fatalf("get released issue: %v", err)
+	}
+	if released.ClaimWorktree != nil || released.ClaimBranch != nil {
+		t.Fatalf("release should clear claim workspace, got %+v", released)
+	}
+}
@@ -0,0 +1,188 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// AddLabels attaches labels to an issue, creating label rows as needed.
+func (r *IssueRepo) AddLabels(publicID string, labels []string) error {
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		internalID, err := internalIssueID(tx, publicID)
+		if err != nil {
+			return err
+		}
+		added, err := attachLabels(tx, internalID, labels)
+		if err != nil {
+			return err
+		}
+		for _, label := range added {
+			if err := r.recordEvent(tx, publicID, model.EventLabelAdded, "label", nil, stringPtr(label)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveLabels detaches labels from an issue.
+func (r *IssueRepo) RemoveLabels(publicID string, labels []string) error {
+	return r.withTxRetry(func(tx *sql.Tx) error {
+		internalID, err := internalIssueID(tx, publicID)
+		if err != nil {
+			return err
+		}
+		removed := 0
+		for _, label := range labels {
+			result, err := tx.Exec(
+				`DELETE FROM issue_labels
+				 WHERE issue_id = ?
+				   AND label_id = (SELECT id FROM labels WHERE name = ?)`,
+				internalID,
+				label,
+			)
+			if err != nil {
+				return fmt.Errorf("remove label: %w", err)
+			}
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("check remove label result: %w", err)
+			}
+			if rowsAffected == 0 {
+				continue
+			}
+			removed++
+			if err := r.recordEvent(tx, publicID, model.EventLabelRemoved, "label", stringPtr(label), nil); err != nil {
+				return err
+			}
+		}
+		if removed == 0 {
+			return fmt.Errorf("labels %s on issue %q %w", strings.Join(labels, ", "), publicID, ErrIssueNotFound)
+		}
+		return nil
+	})
+}
+
+// ListLabels returns every label in use with total and open issue counts.
+func (r *IssueRepo) ListLabels() ([]model.LabelCount, error) {
+	rows, err := r.db.Query(`
+		SELECT l.name, COUNT(*), SUM(CASE WHEN i.status != 'closed' THEN 1 ELSE 0 END)
+		FROM labels l
+		JOIN issue_labels il ON il.label_id = l.id
+		JOIN issues i ON i.id = il.issue_id
+		GROUP BY l.id
+		ORDER BY l.name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("query labels: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	labels := make([]model.LabelCount, 0)
+	for rows.Next() {
+		var label model.LabelCount
+		if err := rows.Scan(&label.Name, &label.Issues, &label.Open); err != nil {
+			return nil, fmt.Errorf("scan label row: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate label rows: %w", err)
+	}
+	return labels, nil
+}
+
+// internalIssueID resolves a public ID to its row ID inside a transaction.
+func internalIssueID(tx *sql.Tx, publicID string) (int64, error) {
+	var internalID int64
+	err := tx.QueryRow(`SELECT id FROM issues WHERE public_id = ?`, publicID).Scan(&internalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("issue %q %w", publicID, ErrIssueNotFound)
+		}
+		return 0, fmt.Errorf("query issue ID: %w", err)
+	}
+	return internalID, nil
+}
+
+// attachLabels links labels to an issue row and returns the labels that were newly linked.
+func attachLabels(tx *sql.Tx, internalID int64, labels []string) ([]string, error) {
+	added := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO labels(name) VALUES(?)`, label); err != nil {
+			return nil, fmt.Errorf("insert label: %w", err)
+		}
+		result, err := tx.Exec(
+			`INSERT OR IGNORE INTO issue_labels(issue_id, label_id)
+			 SELECT ?, id FROM labels WHERE name = ?`,
+			internalID,
+			label,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("attach label: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("check attach label result: %w", err)
+		}
+		if rowsAffected > 0 {
+			added = append(added, label)
+		}
+	}
+	return added, nil
+}
+
+// appendLabelFilter adds label any/all/none predicates for the issue alias i.
+func appendLabelFilter(where []string, args []any, filter model.ListFilter) ([]string, []any) {
+	if len(filter.Labels) == 0 {
+		return where, args
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Labels)), ", ")
+	subquery := `SELECT COUNT(DISTINCT l.name)
+			FROM issue_labels il
+			JOIN labels l ON l.id = il.label_id
+			WHERE il.issue_id = i.id AND l.name IN (` + placeholders + `)`
+	for _, label := range filter.Labels {
+		args = append(args, label)
+	}
+
+	switch filter.LabelMatch {
+	case model.LabelMatchAll:
+		where = append(where, "("+subquery+") = ?")
+		args = append(args, len(uniqueStrings(filter.Labels)))
+	case model.LabelMatchNone:
+		where = append(where, "("+subquery+") = 0")
+	default:
+		where = append(where, "("+subquery+") > 0")
+	}
+	return where, args
+}
+
+// splitLabels decodes the GROUP_CONCAT label column into a sorted slice.
+func splitLabels(raw sql.NullString) []string {
+	if !raw.Valid || raw.String == "" {
+		return []string{}
+	}
+	labels := strings.Split(raw.String, ",")
+	sort.Strings(labels)
+	return labels
+}
+
+// uniqueStrings returns values without duplicates, preserving first occurrence order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
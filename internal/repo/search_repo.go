@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// searchSnippetTokens bounds the excerpt length returned with each match.
+const searchSnippetTokens = 16
+
+// SearchIssues runs an FTS5 match expression against titles, descriptions and comments.
+// Results are ordered by bm25 relevance with title hits weighted above description and comment hits.
+func (r *IssueRepo) SearchIssues(match string, filter model.ListFilter, limit int) ([]model.SearchResult, error) {
+	query := `
+		SELECT ` + issueSelectColumns + `,
+		       snippet(issues_fts, -1, ?, ?, '…', ?),
+		       bm25(issues_fts, 10.0, 4.0, 1.0) AS score
+		FROM issues_fts
+		JOIN issues i ON i.id = issues_fts.rowid
+		LEFT JOIN issues p ON p.id = i.parent_id`
+
+	args := []any{model.SearchHighlightStart, model.SearchHighlightEnd, searchSnippetTokens}
+	where, filterArgs := listConditions(filter)
+	where = append([]string{"issues_fts MATCH ?"}, where...)
+	args = append(args, match)
+	args = append(args, filterArgs...)
+
+	query += " WHERE " + strings.Join(where, " AND ") + " ORDER BY score ASC, i.public_id ASC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := make([]model.SearchResult, 0)
+	for rows.Next() {
+		var result model.SearchResult
+		var labels sql.NullString
+		targets := append(issueScanTargets(&result.Issue, &labels), &result.Snippet, &result.Rank)
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("scan search row: %w", err)
+		}
+		result.Issue.Labels = splitLabels(labels)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search rows: %w", err)
+	}
+	return results, nil
+}
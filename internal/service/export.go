@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+// Export returns every issue, dependency edge and deletion tombstone in shareable form.
+func (s *IssueService) Export() (model.Export, error) {
+	issues, err := s.repo.ListIssues(model.ListFilter{All: true})
+	if err != nil {
+		return model.Export{}, err
+	}
+	deps, err := s.repo.ListDependencyEdges()
+	if err != nil {
+		return model.Export{}, err
+	}
+	tombstones, err := s.repo.ListTombstones()
+	if err != nil {
+		return model.Export{}, err
+	}
+
+	data := model.Export{
+		Issues:       make([]model.ExportedIssue, 0, len(issues)),
+		Dependencies: deps,
+		Tombstones:   tombstones,
+	}
+	for _, issue := range issues {
+		data.Issues = append(data.Issues, repo.ExportIssue(issue))
+	}
+	return data, nil
+}
+
+// Import validates exported records and upserts them by public ID.
+// Local issues that are newer than the imported copy are kept and reported as
+// conflicts unless overwrite is set. A dry run reports without writing.
+func (s *IssueService) Import(data model.Export, overwrite, dryRun bool) (model.ImportReport, error) {
+	seen := make(map[string]struct{}, len(data.Issues))
+	for i, issue := range data.Issues {
+		id, err := NormalizeIssueID(issue.ID)
+		if err != nil {
+			return model.ImportReport{}, err
+		}
+		if _, ok := seen[id]; ok {
+			return model.ImportReport{}, invalidf("issue %s appears more than once", id)
+		}
+		seen[id] = struct{}{}
+		if err := s.validateImported(&issue); err != nil {
+			return model.ImportReport{}, fmt.Errorf("issue %s: %w", id, err)
+		}
+		issue.ID = id
+		data.Issues[i] = issue
+	}
+	for i, dep := range data.Dependencies {
+		issueID, err := NormalizeIssueID(dep.IssueID)
+		if err != nil {
+			return model.ImportReport{}, err
+		}
+		dependsOnID, err := NormalizeIssueID(dep.DependsOnID)
+		if err != nil {
+			return model.ImportReport{}, err
+		}
+		if issueID == dependsOnID {
+			return model.ImportReport{}, invalidf("issue %s cannot depend on itself", issueID)
+		}
+		data.Dependencies[i] = model.Dependency{IssueID: issueID, DependsOnID: dependsOnID}
+	}
+	for i, tombstone := range data.Tombstones {
+		id, err := NormalizeIssueID(tombstone.ID)
+		if err != nil {
+			return model.ImportReport{}, err
+		}
+		if _, ok := seen[id]; ok {
+			return model.ImportReport{}, invalidf("issue %s is both present and deleted", id)
+		}
+		seen[id] = struct{}{}
+		data.Tombstones[i].ID = id
+	}
+	return s.repo.ImportExport(data, overwrite, dryRun, s.settings.DefaultClaimTTL)
+}
+
+// validateImported applies the create-time rules to one imported issue.
+func (s *IssueService) validateImported(issue *model.ExportedIssue) error {
+	issue.Title = strings.TrimSpace(issue.Title)
+	if issue.Title == "" {
+		return invalidf("title is required")
+	}
+	if !s.validType(issue.Type) {
+		return invalidf("invalid type %q", issue.Type)
+	}
+	if _, ok := validStatuses[issue.Status]; !ok {
+		return invalidf("invalid status %q", issue.Status)
+	}
+	if issue.Priority < 0 || issue.Priority > 3 {
+		return invalidf("priority must be between 0 and 3")
+	}
+	if issue.Estimate != nil && *issue.Estimate < 0 {
+		return invalidf("estimate cannot be negative")
+	}
+	if issue.ParentID != nil {
+		parentID, err := NormalizeIssueID(*issue.ParentID)
+		if err != nil {
+			return err
+		}
+		issue.ParentID = &parentID
+	}
+	labels, err := NormalizeLabels(issue.Labels)
+	if err != nil {
+		return err
+	}
+	issue.Labels = labels
+	if issue.CreatedAt.IsZero() || issue.UpdatedAt.IsZero() {
+		return invalidf("created_at and updated_at are required")
+	}
+	return nil
+}
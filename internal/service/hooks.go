@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// ErrHookVetoed marks a transition refused by a pre_ hook.
+var ErrHookVetoed = errors.New("transition vetoed by hook")
+
+// HookRunner executes user hooks around lifecycle transitions.
+type HookRunner interface {
+	Has(event string) bool
+	Before(payload model.HookPayload) error
+	After(payload model.HookPayload)
+}
+
+// SetHooks installs the runner used for lifecycle hooks; nil disables them.
+func (s *IssueService) SetHooks(runner HookRunner) {
+	s.hooks = runner
+}
+
+// SweepExpiredLeases records a lease_expired change for claims whose lease lapsed since the
+// last sweep and fires on_lease_expired hooks for them. The change is recorded even without
+// hooks so change-log followers see the claim lapse. Claims sweep first so a lapsed lease is
+// reported before it is taken over; long-running commands also sweep on a timer.
+func (s *IssueService) SweepExpiredLeases() error {
+	expired, err := s.repo.MarkExpiredLeases()
+	if err != nil {
+		return err
+	}
+	for _, publicID := range expired {
+		s.afterHook(model.HookOnLeaseExpired, publicID, "")
+	}
+	return nil
+}
+
+// beforeHook runs veto hooks for event against the current state of an issue.
+func (s *IssueService) beforeHook(event, publicID string) error {
+	if s.hooks == nil || !s.hooks.Has(event) {
+		return nil
+	}
+	issue, err := s.repo.GetIssue(publicID)
+	if err != nil {
+		return err
+	}
+	if err := s.hooks.Before(model.HookPayload{Event: event, Actor: s.repo.Actor(), Issue: issue}); err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrHookVetoed, event, publicID, err)
+	}
+	return nil
+}
+
+// afterHook runs notification hooks for event once the transition has been stored.
+func (s *IssueService) afterHook(event, publicID, dependsOnID string) {
+	if s.hooks == nil || !s.hooks.Has(event) {
+		return
+	}
+	issue, err := s.repo.GetIssue(publicID)
+	if err != nil {
+		return
+	}
+	payload := model.HookPayload{Event: event, Actor: s.repo.Actor(), Issue: issue}
+	if dependsOnID != "" {
+		dependsOn, err := s.repo.GetIssue(dependsOnID)
+		if err != nil {
+			return
+		}
+		payload.DependsOn = &dependsOn
+	}
+	s.hooks.After(payload)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+// recordingHooks captures fired hooks and can veto pre_ events.
+type recordingHooks struct {
+	veto  error
+	fired []model.HookPayload
+}
+
+func (h *recordingHooks) Has(event string) bool { return true }
+
+func (h *recordingHooks) Before(payload model.HookPayload) error {
+	h.fired = append(h.fired, payload)
+	return h.veto
+}
+
+func (h *recordingHooks) After(payload model.HookPayload) {
+	h.fired = append(h.fired, payload)
+}
+
+func (h *recordingHooks) events() []string {
+	events := make([]string, 0, len(h.fired))
+	for _, payload := range h.fired {
+		events = append(events, payload.Event)
+	}
+	return events
+}
+
+func TestLifecycleTransitionsFireHooks(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	issueRepo := repo.NewIssueRepo(sqlDB)
+	issueRepo.SetActor("agent-7")
+	svc := NewIssueService(issueRepo, "faz")
+	runner := &recordingHooks{veto: errors.New("exit status 1")}
+	svc.SetHooks(runner)
+
+	designID, err := svc.Create(model.Issue{Title: "Design", Type: "task", Priority: 0, Status: "open"})
+	if err != nil {
+		t.Fatalf("create design: %v", err)
+	}
+	buildID, err := svc.Create(model.Issue{Title: "Build", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create build: %v", err)
+	}
+	if err := svc.AddDependency(buildID, designID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if err := svc.Claim(designID, time.Minute); err != nil {
+		t.Fatalf("claim design: %v", err)
+	}
+
+	err = svc.Close(designID)
+	if !errors.Is(err, ErrHookVetoed) {
+		t.Fatalf("expected pre_close veto, got %v", err)
+	}
+	if issue, _ := svc.Get(designID); issue.Status != "in_progress" {
+		t.Fatalf("expected vetoed issue to stay in_progress, got %s", issue.Status)
+	}
+
+	runner.veto = nil
+	if err := svc.Close(designID); err != nil {
+		t.Fatalf("close design: %v", err)
+	}
+	if err := svc.Reopen(designID); err != nil {
+		t.Fatalf("reopen design: %v", err)
+	}
+
+	want := []string{
+		model.HookOnCreate, model.HookOnCreate, model.HookOnDepAdded, model.HookOnClaim,
+		model.HookPreClose, model.HookPreClose, model.HookOnClose, model.HookOnReopen,
+	}
+	got := runner.events()
+	if len(got) != len(want) {
+		t.Fatalf("fired hooks = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fired hooks = %v, want %v", got, want)
+		}
+	}
+	depAdded := runner.fired[2]
+	if depAdded.Issue.ID != buildID || depAdded.DependsOn == nil || depAdded.DependsOn.ID != designID || depAdded.Actor != "agent-7" {
+		t.Fatalf("unexpected dep payload: %+v", depAdded)
+	}
+	if closed := runner.fired[6]; closed.Issue.Status != "closed" {
+		t.Fatalf("expected on_close to see the closed issue, got %s", closed.Issue.Status)
+	}
+}
+
+func TestUpdateStatusRunsCloseAndReopenHooks(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	svc := NewIssueService(repo.NewIssueRepo(sqlDB), "faz")
+	runner := &recordingHooks{veto: errors.New("exit status 1")}
+	svc.SetHooks(runner)
+
+	id, err := svc.Create(model.Issue{Title: "Ship", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	err = svc.Update(id, map[string]any{"status": "closed", "title": "Ship it"})
+	if !errors.Is(err, ErrHookVetoed) {
+		t.Fatalf("expected pre_close to veto the update, got %v", err)
+	}
+	if issue, _ := svc.Get(id); issue.Status != "open" || issue.Title != "Ship" {
+		t.Fatalf("vetoed update should change nothing, got %+v", issue)
+	}
+
+	runner.veto = nil
+	if err := svc.Update(id, map[string]any{"status": "closed", "title": "Ship it"}); err != nil {
+		t.Fatalf("update to closed: %v", err)
+	}
+	issue, err := svc.Get(id)
+	if err != nil || issue.Status != "closed" || issue.ClosedAt == nil || issue.Title != "Ship it" {
+		t.Fatalf("expected a closed issue with its new title, got %+v, %v", issue, err)
+	}
+	if err := svc.Update(id, map[string]any{"status": "open"}); err != nil {
+		t.Fatalf("update to open: %v", err)
+	}
+
+	want := []string{model.HookOnCreate, model.HookPreClose, model.HookPreClose, model.HookOnClose, model.HookOnReopen}
+	if got := runner.events(); len(got) != len(want) {
+		t.Fatalf("fired hooks = %v, want %v", got, want)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("fired hooks = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestSweepExpiredLeasesRecordsChangeWithoutHooks(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	svc := NewIssueService(repo.NewIssueRepo(sqlDB), "faz")
+	issueID, err := svc.Create(model.Issue{Title: "Task", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := svc.Claim(issueID, time.Minute); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+	if _, err := sqlDB.Exec(`UPDATE issues SET claim_expires_at = DATETIME(CURRENT_TIMESTAMP, '-1 minute')`); err != nil {
+		t.Fatalf("expire lease: %v", err)
+	}
+	cursor, err := svc.LatestChange()
+	if err != nil {
+		t.Fatalf("latest change: %v", err)
+	}
+
+	if err := svc.SweepExpiredLeases(); err != nil {
+		t.Fatalf("sweep leases: %v", err)
+	}
+	events, err := svc.Changes(cursor, 0)
+	if err != nil {
+		t.Fatalf("read changes: %v", err)
+	}
+	if len(events) != 1 || events[0].Action != model.EventLeaseExpired || events[0].IssueID != issueID {
+		t.Fatalf("expected one lease_expired change for %s, got %+v", issueID, events)
+	}
+}
+
+func TestSweepExpiredLeasesFiresOncePerLease(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	issueRepo := repo.NewIssueRepo(sqlDB)
+	svc := NewIssueService(issueRepo, "faz")
+	issueID, err := svc.Create(model.Issue{Title: "Task", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create issue: %v", err)
+	}
+	if err := svc.Claim(issueID, time.Minute); err != nil {
+		t.Fatalf("claim issue: %v", err)
+	}
+	if _, err := sqlDB.Exec(`UPDATE issues SET claim_expires_at = DATETIME(CURRENT_TIMESTAMP, '-1 minute')`); err != nil {
+		t.Fatalf("expire lease: %v", err)
+	}
+
+	runner := &recordingHooks{}
+	svc.SetHooks(runner)
+	for range 2 {
+		if err := svc.SweepExpiredLeases(); err != nil {
+			t.Fatalf("sweep leases: %v", err)
+		}
+	}
+	if got := runner.events(); len(got) != 1 || got[0] != model.HookOnLeaseExpired || runner.fired[0].Issue.ID != issueID {
+		t.Fatalf("expected one on_lease_expired for %s, got %+v", issueID, runner.fired)
+	}
+
+	secondID, err := svc.Create(model.Issue{Title: "Second", Type: "task", Priority: 1, Status: "open"})
+	if err != nil {
+		t.Fatalf("create second issue: %v", err)
+	}
+	if err := svc.Claim(secondID, time.Minute); err != nil {
+		t.Fatalf("claim second issue: %v", err)
+	}
+	if _, err := sqlDB.Exec(`UPDATE issues SET claim_expires_at = DATETIME(CURRENT_TIMESTAMP, '-1 minute') WHERE public_id = ?`, secondID); err != nil {
+		t.Fatalf("expire second lease: %v", err)
+	}
+	runner.fired = nil
+	if err := svc.Claim(secondID, time.Minute); err != nil {
+		t.Fatalf("take over lapsed claim: %v", err)
+	}
+	if got := runner.events(); len(got) != 2 || got[0] != model.HookOnLeaseExpired || got[1] != model.HookOnClaim {
+		t.Fatalf("expected a claim to report the lapsed lease before taking it over, got %v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"regexp"
@@ -29,6 +30,8 @@ var validStatuses = map[string]struct{}{
 
 var publicIDRegex = regexp.MustCompile(`^[a-z0-9_]+-[a-z0-9]{4}(\.[0-9]+)?$`)
 
+var labelRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:/-]{0,63}$`)
+
 const idAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 const (
@@ -36,11 +39,33 @@ const (
 	baseCreateBackoff = 20 * time.Millisecond
 )
 
+// ErrInvalidInput marks requests rejected by service validation.
+var ErrInvalidInput = errors.New("invalid input")
+
+// validationError carries a validation message and matches ErrInvalidInput.
+type validationError struct {
+	msg string
+}
+
+// Error returns the validation message.
+func (e validationError) Error() string { return e.msg }
+
+// Is reports ErrInvalidInput as the error's sentinel.
+func (e validationError) Is(target error) bool { return target == ErrInvalidInput }
+
+// invalidf formats a validation failure.
+func invalidf(format string, args ...any) error {
+	return validationError{msg: fmt.Sprintf(format, args...)}
+}
+
 // IssueService contains business rules for task lifecycle operations.
 type IssueService struct {
 	repo         *repo.IssueRepo
 	projectToken string
 	randSource   *rand.Rand
+	hooks        HookRunner
+	settings     Settings
+	types        map[string]struct{}
 }
 
 // NewIssueService builds a service with repository and project context.
@@ -50,6 +75,8 @@ func NewIssueService(repo *repo.IssueRepo, projectName string) *IssueService {
 		repo:         repo,
 		projectToken: token,
 		randSource:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		settings:     DefaultSettings(),
+		types:        validTypes,
 	}
 }
 
@@ -63,17 +90,25 @@ func (s *IssueService) Create(issue model.Issue) (string, error) {
 	issue.Status = strings.TrimSpace(issue.Status)
 
 	if issue.Title == "" {
-		return "", fmt.Errorf("title is required")
+		return "", invalidf("title is required")
 	}
-	if _, ok := validTypes[issue.Type]; !ok {
-		return "", fmt.Errorf("invalid type %q", issue.Type)
+	if !s.validType(issue.Type) {
+		return "", invalidf("invalid type %q", issue.Type)
 	}
 	if _, ok := validStatuses[issue.Status]; !ok {
-		return "", fmt.Errorf("invalid status %q", issue.Status)
+		return "", invalidf("invalid status %q", issue.Status)
 	}
 	if issue.Priority < 0 || issue.Priority > 3 {
-		return "", fmt.Errorf("priority must be between 0 and 3")
+		return "", invalidf("priority must be between 0 and 3")
+	}
+	if issue.Estimate != nil && *issue.Estimate < 0 {
+		return "", invalidf("estimate cannot be negative")
+	}
+	labels, err := NormalizeLabels(issue.Labels)
+	if err != nil {
+		return "", err
 	}
+	issue.Labels = labels
 
 	var lastRetryErr error
 	for attempt := 0; attempt < maxCreateAttempts; attempt++ {
@@ -85,6 +120,7 @@ func (s *IssueService) Create(issue model.Issue) (string, error) {
 
 		id, err := s.repo.CreateIssue(issue)
 		if err == nil {
+			s.afterHook(model.HookOnCreate, id, "")
 			return id, nil
 		}
 		if !isRetryableCreateError(err) {
@@ -109,44 +145,87 @@ func (s *IssueService) Update(publicID string, fields map[string]any) error {
 		case "title":
 			title := strings.TrimSpace(value.(string))
 			if title == "" {
-				return fmt.Errorf("title cannot be empty")
+				return invalidf("title cannot be empty")
 			}
 			clean[key] = title
 		case "description":
 			clean[key] = value
 		case "type":
 			typ := strings.TrimSpace(value.(string))
-			if _, ok := validTypes[typ]; !ok {
-				return fmt.Errorf("invalid type %q", typ)
+			if !s.validType(typ) {
+				return invalidf("invalid type %q", typ)
 			}
 			clean[key] = typ
 		case "status":
 			status := strings.TrimSpace(value.(string))
 			if _, ok := validStatuses[status]; !ok {
-				return fmt.Errorf("invalid status %q", status)
+				return invalidf("invalid status %q", status)
 			}
 			if status == "in_progress" {
-				return fmt.Errorf("status %q can only be set via `faz claim`", status)
+				return invalidf("status %q can only be set via `faz claim`", status)
 			}
 			clean[key] = status
 		case "priority":
 			priority := value.(int)
 			if priority < 0 || priority > 3 {
-				return fmt.Errorf("priority must be between 0 and 3")
+				return invalidf("priority must be between 0 and 3")
 			}
 			clean[key] = priority
+		case "estimate":
+			estimate, ok := value.(*int)
+			if !ok {
+				return invalidf("invalid estimate value")
+			}
+			if estimate != nil && *estimate < 0 {
+				return invalidf("estimate cannot be negative")
+			}
+			clean[key] = estimate
 		case "parent_public_id":
 			clean[key] = value
 		default:
-			return fmt.Errorf("unsupported field %q", key)
+			return invalidf("unsupported field %q", key)
 		}
 	}
 
 	if len(clean) == 0 {
-		return fmt.Errorf("no updates provided")
+		return invalidf("no updates provided")
 	}
 
-	return s.repo.UpdateIssue(publicID, clean)
+	status, ok := clean["status"].(string)
+	if !ok {
+		return s.repo.UpdateIssue(publicID, clean)
+	}
+	// Status changes go through Close and Reopen so hooks and close events run.
+	delete(clean, "status")
+	current, err := s.repo.GetIssue(publicID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case status == current.Status:
+		status = ""
+	case status == "open" && current.Status == "in_progress":
+		return invalidf("issue %s is claimed; release it with `faz release` instead of setting status %q", publicID, status)
+	case status == "closed":
+		if err := s.beforeHook(model.HookPreClose, publicID); err != nil {
+			return err
+		}
+	}
+	if len(clean) > 0 {
+		if err := s.repo.UpdateIssue(publicID, clean); err != nil {
+			return err
+		}
+	}
+	switch status {
+	case "closed":
+		if err := s.repo.CloseIssue(publicID); err != nil {
+			return err
+		}
+		s.afterHook(model.HookOnClose, publicID, "")
+	case "open":
+		return s.Reopen(publicID)
+	}
+	return nil
 }
 
 // Get returns one issue by ID.
@@ -154,22 +233,88 @@ func (s *IssueService) Get(publicID string) (model.Issue, error) {
 	return s.repo.GetIssue(publicID)
 }
 
-// Close marks an issue as closed.
+// Close marks an issue as closed unless a pre_close hook vetoes it.
 func (s *IssueService) Close(publicID string) error {
-	return s.repo.CloseIssue(publicID)
+	if err := s.beforeHook(model.HookPreClose, publicID); err != nil {
+		return err
+	}
+	if err := s.repo.CloseIssue(publicID); err != nil {
+		return err
+	}
+	s.afterHook(model.HookOnClose, publicID, "")
+	return nil
 }
 
 // Reopen marks an issue as open.
 func (s *IssueService) Reopen(publicID string) error {
-	return s.repo.ReopenIssue(publicID)
+	if err := s.repo.ReopenIssue(publicID); err != nil {
+		return err
+	}
+	s.afterHook(model.HookOnReopen, publicID, "")
+	return nil
 }
 
 // Claim atomically assigns an issue lease and marks it in_progress.
 func (s *IssueService) Claim(publicID string, lease time.Duration) error {
 	if lease <= 0 {
-		return fmt.Errorf("claim lease must be greater than zero")
+		return invalidf("claim lease must be greater than zero")
+	}
+	if err := s.SweepExpiredLeases(); err != nil {
+		return err
+	}
+	if err := s.repo.ClaimIssue(publicID, lease); err != nil {
+		return err
+	}
+	s.afterHook(model.HookOnClaim, publicID, "")
+	return nil
+}
+
+// ClaimNext atomically claims the highest-priority ready issue matching filter and returns its ID.
+func (s *IssueService) ClaimNext(filter model.ListFilter, lease time.Duration) (string, error) {
+	if lease <= 0 {
+		return "", invalidf("claim lease must be greater than zero")
+	}
+	if filter.Type != "" {
+		if !s.validType(filter.Type) {
+			return "", invalidf("invalid type %q", filter.Type)
+		}
+		if filter.Type == "epic" {
+			return "", fmt.Errorf("type %q: %w", filter.Type, repo.ErrIssueTypeNotClaimable)
+		}
+	}
+	if filter.PriorityMax != nil && (*filter.PriorityMax < 0 || *filter.PriorityMax > 3) {
+		return "", invalidf("priority must be between 0 and 3")
+	}
+	if filter.ParentID != "" {
+		if _, err := NormalizeIssueID(filter.ParentID); err != nil {
+			return "", err
+		}
+	}
+	if err := normalizeLabelFilter(&filter); err != nil {
+		return "", err
+	}
+	if err := s.SweepExpiredLeases(); err != nil {
+		return "", err
+	}
+	id, err := s.repo.ClaimNextReady(filter, lease)
+	if err != nil {
+		return "", err
+	}
+	s.afterHook(model.HookOnClaim, id, "")
+	return id, nil
+}
+
+// Renew extends the lease on an issue already held by the current actor.
+func (s *IssueService) Renew(publicID string, lease time.Duration) error {
+	if lease <= 0 {
+		return invalidf("claim lease must be greater than zero")
 	}
-	return s.repo.ClaimIssue(publicID, lease)
+	return s.repo.RenewClaim(publicID, lease)
+}
+
+// Release returns a claimed issue to open.
+func (s *IssueService) Release(publicID string) error {
+	return s.repo.ReleaseIssue(publicID)
 }
 
 // Delete permanently removes an issue.
@@ -177,35 +322,74 @@ func (s *IssueService) Delete(publicID string) error {
 	return s.repo.DeleteIssue(publicID)
 }
 
-// Ready returns work that has no open blockers.
-func (s *IssueService) Ready() ([]model.Issue, error) {
-	return s.repo.ReadyIssues()
+// Ready returns work that has no open blockers, optionally narrowed by labels.
+func (s *IssueService) Ready(filter model.ListFilter) ([]model.Issue, error) {
+	if err := normalizeLabelFilter(&filter); err != nil {
+		return nil, err
+	}
+	return s.repo.ReadyIssues(filter)
 }
 
 // List returns issues with optional filters.
 func (s *IssueService) List(filter model.ListFilter) ([]model.Issue, error) {
+	if err := s.validateListFilter(&filter); err != nil {
+		return nil, err
+	}
+	return s.repo.ListIssues(filter)
+}
+
+// Search returns issues whose title, description or comments match every query term, best match first.
+func (s *IssueService) Search(query string, filter model.ListFilter, limit int) ([]model.SearchResult, error) {
+	match, err := searchMatchExpression(query)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, invalidf("limit cannot be negative")
+	}
+	if err := s.validateListFilter(&filter); err != nil {
+		return nil, err
+	}
+	return s.repo.SearchIssues(match, filter, limit)
+}
+
+// validateListFilter checks filter values and normalizes its labels.
+func (s *IssueService) validateListFilter(filter *model.ListFilter) error {
 	if filter.Type != "" {
-		if _, ok := validTypes[filter.Type]; !ok {
-			return nil, fmt.Errorf("invalid type %q", filter.Type)
+		if !s.validType(filter.Type) {
+			return invalidf("invalid type %q", filter.Type)
 		}
 	}
 	if filter.Status != "" {
 		if _, ok := validStatuses[filter.Status]; !ok {
-			return nil, fmt.Errorf("invalid status %q", filter.Status)
+			return invalidf("invalid status %q", filter.Status)
 		}
 	}
 	if filter.Priority != nil {
 		if *filter.Priority < 0 || *filter.Priority > 3 {
-			return nil, fmt.Errorf("priority must be between 0 and 3")
+			return invalidf("priority must be between 0 and 3")
 		}
 	}
 	if filter.ParentID != "" {
 		if _, err := NormalizeIssueID(filter.ParentID); err != nil {
-			return nil, err
+			return err
 		}
 	}
+	return normalizeLabelFilter(filter)
+}
 
-	return s.repo.ListIssues(filter)
+// searchMatchExpression turns free text into an FTS5 query that requires every term as a prefix.
+// Terms are quoted so punctuation and FTS operators in user input are matched literally.
+func searchMatchExpression(query string) (string, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return "", invalidf("search query cannot be empty")
+	}
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+	return strings.Join(quoted, " "), nil
 }
 
 // Info returns open issue count and latest completed items.
@@ -238,7 +422,11 @@ func (s *IssueService) Dependents(publicID string) ([]model.Issue, error) {
 
 // AddDependency links a blocker to an issue.
 func (s *IssueService) AddDependency(issueID, dependsOnID string) error {
-	return s.repo.AddDependency(issueID, dependsOnID)
+	if err := s.repo.AddDependency(issueID, dependsOnID); err != nil {
+		return err
+	}
+	s.afterHook(model.HookOnDepAdded, issueID, dependsOnID)
+	return nil
 }
 
 // RemoveDependency unlinks a blocker from an issue.
@@ -246,16 +434,118 @@ func (s *IssueService) RemoveDependency(issueID, dependsOnID string) error {
 	return s.repo.RemoveDependency(issueID, dependsOnID)
 }
 
+// DependencyEdges returns every dependency edge in the project.
+func (s *IssueService) DependencyEdges() ([]model.Dependency, error) {
+	return s.repo.ListDependencyEdges()
+}
+
+// CheckDependencies reports cycles and stale edges in the dependency graph.
+func (s *IssueService) CheckDependencies() ([]model.DependencyProblem, error) {
+	return s.repo.CheckDependencies()
+}
+
+// AddLabels validates and attaches labels to an issue.
+func (s *IssueService) AddLabels(publicID string, labels []string) error {
+	clean, err := NormalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+	if len(clean) == 0 {
+		return invalidf("at least one label is required")
+	}
+	return s.repo.AddLabels(publicID, clean)
+}
+
+// RemoveLabels validates and detaches labels from an issue.
+func (s *IssueService) RemoveLabels(publicID string, labels []string) error {
+	clean, err := NormalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+	if len(clean) == 0 {
+		return invalidf("at least one label is required")
+	}
+	return s.repo.RemoveLabels(publicID, clean)
+}
+
+// Comment records a work-log note from an author on an issue.
+func (s *IssueService) Comment(publicID, author, body string) (model.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return model.Comment{}, invalidf("comment text is required")
+	}
+	author = strings.TrimSpace(author)
+	if author == "" {
+		return model.Comment{}, invalidf("comment author is required")
+	}
+	return s.repo.AddComment(publicID, author, body)
+}
+
+// Comments returns an issue's notes oldest first, keeping the newest limit when limit > 0.
+func (s *IssueService) Comments(publicID string, limit int) ([]model.Comment, error) {
+	return s.repo.ListComments(publicID, limit)
+}
+
+// History returns the audit trail of one issue, oldest first.
+func (s *IssueService) History(publicID string) ([]model.IssueEvent, error) {
+	return s.repo.ListIssueEvents(publicID)
+}
+
+// Log returns audit events across all issues recorded at or after since.
+func (s *IssueService) Log(since time.Time) ([]model.IssueEvent, error) {
+	return s.repo.ListEventsSince(since)
+}
+
+// Changes returns up to limit change-log events with a sequence above after, oldest first.
+func (s *IssueService) Changes(after int64, limit int) ([]model.IssueEvent, error) {
+	if after < 0 {
+		return nil, invalidf("change sequence cannot be negative")
+	}
+	return s.repo.ListEventsAfter(after, limit)
+}
+
+// LatestChange returns the sequence of the newest change-log event, or 0 for an empty log.
+func (s *IssueService) LatestChange() (int64, error) {
+	return s.repo.LatestEventID()
+}
+
+// Labels returns every label in use with issue counts.
+func (s *IssueService) Labels() ([]model.LabelCount, error) {
+	return s.repo.ListLabels()
+}
+
 // NormalizeIssueID validates and normalizes a public issue ID.
 func NormalizeIssueID(raw string) (string, error) {
 	id := strings.ToLower(strings.TrimSpace(raw))
 	if !publicIDRegex.MatchString(id) {
-		return "", fmt.Errorf("invalid issue ID %q", raw)
+		return "", invalidf("invalid issue ID %q", raw)
 	}
 	return id, nil
 }
 
-// ValidTypes lists allowed issue types.
+// NormalizeLabels lowercases, validates, and de-duplicates label names.
+func NormalizeLabels(raw []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(raw))
+	labels := make([]string, 0, len(raw))
+	for _, value := range raw {
+		label := strings.ToLower(strings.TrimSpace(value))
+		if label == "" {
+			continue
+		}
+		if !labelRegex.MatchString(label) {
+			return nil, invalidf("invalid label %q (use lowercase letters, digits, and _ . : / -)", value)
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// ValidTypes lists the built-in issue types.
 func ValidTypes() []string {
 	out := make([]string, 0, len(validTypes))
 	for t := range validTypes {
@@ -275,6 +565,23 @@ func ValidStatuses() []string {
 	return out
 }
 
+// normalizeLabelFilter validates label names and the label match mode in place.
+func normalizeLabelFilter(filter *model.ListFilter) error {
+	labels, err := NormalizeLabels(filter.Labels)
+	if err != nil {
+		return err
+	}
+	filter.Labels = labels
+	switch filter.LabelMatch {
+	case "":
+		filter.LabelMatch = model.LabelMatchAny
+	case model.LabelMatchAny, model.LabelMatchAll, model.LabelMatchNone:
+	default:
+		return invalidf("invalid label match %q (expected any|all|none)", filter.LabelMatch)
+	}
+	return nil
+}
+
 // nextPublicID chooses the next root or child ID for a new issue.
 func (s *IssueService) nextPublicID(parentID *string) (string, error) {
 	if parentID != nil {
@@ -283,7 +590,7 @@ func (s *IssueService) nextPublicID(parentID *string) (string, error) {
 			return "", err
 		}
 		if strings.Contains(parentPublicID, ".") {
-			return "", fmt.Errorf("nested child issues are not supported")
+			return "", invalidf("nested child issues are not supported")
 		}
 		nextIndex, err := s.repo.NextChildIndex(parentPublicID)
 		if err != nil {
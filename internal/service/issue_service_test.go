@@ -117,3 +117,32 @@ func TestUpdateRejectsInProgressStatus(t *testing.T) {
 		t.Fatalf("expected update to reject in_progress status")
 	}
 }
+
+func TestNormalizeLabels(t *testing.T) {
+	labels, err := NormalizeLabels([]string{" Frontend ", "db", "frontend", ""})
+	if err != nil {
+		t.Fatalf("normalize labels: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "db" || labels[1] != "frontend" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+
+	if _, err := NormalizeLabels([]string{"bad,label"}); err == nil {
+		t.Fatalf("expected comma label to be rejected")
+	}
+}
+
+func TestSearchMatchExpressionQuotesTerms(t *testing.T) {
+	got, err := searchMatchExpression(`  session "store" OR  `)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `"session"* """store"""* "OR"*`
+	if got != want {
+		t.Fatalf("match expression = %s, want %s", got, want)
+	}
+
+	if _, err := searchMatchExpression("   "); err == nil {
+		t.Fatalf("expected empty query to be rejected")
+	}
+}
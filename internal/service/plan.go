@@ -0,0 +1,175 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+// Plan orders an epic's open children into dependency waves and finds the critical path.
+func (s *IssueService) Plan(epicID string) (model.Plan, error) {
+	epic, err := s.repo.GetIssue(epicID)
+	if err != nil {
+		return model.Plan{}, err
+	}
+	if epic.Type != "epic" {
+		return model.Plan{}, invalidf("issue %s is a %s, not an epic", epic.ID, epic.Type)
+	}
+
+	children, err := s.repo.ListChildren(epic.ID)
+	if err != nil {
+		return model.Plan{}, err
+	}
+	blockers := make(map[string][]model.Issue, len(children))
+	for _, child := range children {
+		if child.Status == "closed" {
+			continue
+		}
+		deps, err := s.repo.ListDependencies(child.ID)
+		if err != nil {
+			return model.Plan{}, err
+		}
+		blockers[child.ID] = deps
+	}
+
+	return buildPlan(epic, children, blockers)
+}
+
+// buildPlan schedules open children into waves with Kahn's algorithm over in-epic blockers.
+// Each item weighs its estimate (1 when unset); the critical path is the heaviest blocker chain.
+func buildPlan(epic model.Issue, children []model.Issue, blockers map[string][]model.Issue) (model.Plan, error) {
+	plan := model.Plan{Epic: epic, Waves: []model.PlanWave{}, CriticalPath: []string{}, Done: []string{}}
+
+	open := make(map[string]model.Issue)
+	inEpic := make(map[string]bool, len(children))
+	for _, child := range children {
+		inEpic[child.ID] = true
+		if child.Status == "closed" {
+			plan.Done = append(plan.Done, child.ID)
+			continue
+		}
+		open[child.ID] = child
+	}
+
+	items := make(map[string]*model.PlanItem, len(open))
+	dependents := make(map[string][]string)
+	remaining := make(map[string]int, len(open))
+	for id, issue := range open {
+		item := &model.PlanItem{Issue: issue, Weight: planWeight(issue), BlockedBy: []string{}, ExternalBlockers: []string{}}
+		for _, blocker := range blockers[id] {
+			switch {
+			case blocker.Status == "closed":
+			case inEpic[blocker.ID]:
+				item.BlockedBy = append(item.BlockedBy, blocker.ID)
+				dependents[blocker.ID] = append(dependents[blocker.ID], id)
+			default:
+				item.ExternalBlockers = append(item.ExternalBlockers, blocker.ID)
+			}
+		}
+		sort.Strings(item.BlockedBy)
+		sort.Strings(item.ExternalBlockers)
+		remaining[id] = len(item.BlockedBy)
+		items[id] = item
+	}
+
+	finish := make(map[string]int, len(items))
+	depth := make(map[string]int, len(items))
+	previous := make(map[string]string, len(items))
+	frontier := make([]string, 0)
+	for id, count := range remaining {
+		if count == 0 {
+			frontier = append(frontier, id)
+		}
+	}
+
+	scheduled := 0
+	for len(frontier) > 0 {
+		sortPlanIDs(frontier, open)
+		wave := model.PlanWave{Number: len(plan.Waves) + 1, Items: make([]model.PlanItem, 0, len(frontier))}
+		next := make([]string, 0)
+		for _, id := range frontier {
+			item := items[id]
+			for _, blocker := range item.BlockedBy {
+				// Link the first blocker even when it finishes at 0 so zero-estimate
+				// predecessors stay on the chain.
+				if _, linked := previous[id]; !linked || finish[blocker] > item.EarliestStart {
+					item.EarliestStart = finish[blocker]
+					previous[id] = blocker
+				}
+			}
+			finish[id] = item.EarliestStart + item.Weight
+			depth[id] = len(plan.Waves)
+			for _, dependent := range dependents[id] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+			scheduled++
+		}
+		for _, id := range frontier {
+			wave.Items = append(wave.Items, *items[id])
+		}
+		plan.Waves = append(plan.Waves, wave)
+		frontier = next
+	}
+
+	if scheduled < len(items) {
+		stuck := make([]string, 0, len(items)-scheduled)
+		for id, count := range remaining {
+			if count > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		sort.Strings(stuck)
+		return model.Plan{}, fmt.Errorf("%w among children %s", repo.ErrDependencyCycle, strings.Join(stuck, ", "))
+	}
+
+	end := ""
+	for id, value := range finish {
+		// On equal weight prefer the later wave so zero-estimate dependents end the chain.
+		if end == "" || value > finish[end] || (value == finish[end] && (depth[id] > depth[end] || (depth[id] == depth[end] && id < end))) {
+			end = id
+		}
+	}
+	if end == "" {
+		return plan, nil
+	}
+	plan.CriticalWeight = finish[end]
+	for id := end; id != ""; id = previous[id] {
+		plan.CriticalPath = append([]string{id}, plan.CriticalPath...)
+	}
+	critical := make(map[string]bool, len(plan.CriticalPath))
+	for _, id := range plan.CriticalPath {
+		critical[id] = true
+	}
+	for w := range plan.Waves {
+		for i := range plan.Waves[w].Items {
+			item := &plan.Waves[w].Items[i]
+			item.Critical = critical[item.Issue.ID]
+		}
+	}
+	return plan, nil
+}
+
+// planWeight returns an issue's estimate, defaulting to one unit.
+func planWeight(issue model.Issue) int {
+	if issue.Estimate == nil {
+		return 1
+	}
+	return *issue.Estimate
+}
+
+// sortPlanIDs orders a wave by priority and then public ID.
+func sortPlanIDs(ids []string, issues map[string]model.Issue) {
+	sort.Slice(ids, func(i, j int) bool {
+		left, right := issues[ids[i]], issues[ids[j]]
+		if left.Priority != right.Priority {
+			return left.Priority < right.Priority
+		}
+		return left.ID < right.ID
+	})
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+func TestBuildPlanGroupsWavesAndWeightsCriticalPath(t *testing.T) {
+	epic := model.Issue{ID: "faz-ab12", Type: "epic", Status: "open"}
+	three := 3
+	issues := map[string]model.Issue{
+		"design":   {ID: "faz-ab12.0", Title: "Design", Status: "open", Priority: 1},
+		"backend":  {ID: "faz-ab12.1", Title: "Backend", Status: "open", Priority: 1, Estimate: &three},
+		"frontend": {ID: "faz-ab12.2", Title: "Frontend", Status: "open", Priority: 2},
+		"launch":   {ID: "faz-ab12.3", Title: "Launch", Status: "open", Priority: 0},
+		"spike":    {ID: "faz-ab12.4", Title: "Spike", Status: "closed", Priority: 2},
+		"vendor":   {ID: "faz-cd34", Title: "Vendor contract", Status: "open", Priority: 1},
+	}
+	children := []model.Issue{issues["design"], issues["backend"], issues["frontend"], issues["launch"], issues["spike"]}
+	blockers := map[string][]model.Issue{
+		"faz-ab12.1": {issues["design"], issues["spike"]},
+		"faz-ab12.2": {issues["design"], issues["vendor"]},
+		"faz-ab12.3": {issues["backend"], issues["frontend"]},
+	}
+
+	plan, err := buildPlan(epic, children, blockers)
+	if err != nil {
+		t.Fatalf("build plan: %v", err)
+	}
+
+	waves := make([][]string, 0, len(plan.Waves))
+	for _, wave := range plan.Waves {
+		ids := make([]string, 0, len(wave.Items))
+		for _, item := range wave.Items {
+			ids = append(ids, item.Issue.ID)
+		}
+		waves = append(waves, ids)
+	}
+	wantWaves := [][]string{{"faz-ab12.0"}, {"faz-ab12.1", "faz-ab12.2"}, {"faz-ab12.3"}}
+	if !reflect.DeepEqual(waves, wantWaves) {
+		t.Fatalf("waves = %v, want %v", waves, wantWaves)
+	}
+
+	wantPath := []string{"faz-ab12.0", "faz-ab12.1", "faz-ab12.3"}
+	if !reflect.DeepEqual(plan.CriticalPath, wantPath) || plan.CriticalWeight != 5 {
+		t.Fatalf("critical path = %v (weight %d), want %v (weight 5)", plan.CriticalPath, plan.CriticalWeight, wantPath)
+	}
+	if !reflect.DeepEqual(plan.Done, []string{"faz-ab12.4"}) {
+		t.Fatalf("done = %v", plan.Done)
+	}
+	frontend := plan.Waves[1].Items[1]
+	if frontend.Critical || !reflect.DeepEqual(frontend.ExternalBlockers, []string{"faz-cd34"}) {
+		t.Fatalf("unexpected frontend item: %+v", frontend)
+	}
+}
+
+func TestBuildPlanKeepsZeroEstimateBlockersOnCriticalPath(t *testing.T) {
+	epic := model.Issue{ID: "faz-ab12", Type: "epic", Status: "open"}
+	zero, two := 0, 2
+	kickoff := model.Issue{ID: "faz-ab12.0", Title: "Kickoff", Status: "open", Priority: 1, Estimate: &zero}
+	build := model.Issue{ID: "faz-ab12.1", Title: "Build", Status: "open", Priority: 1, Estimate: &two}
+	signoff := model.Issue{ID: "faz-ab12.2", Title: "Sign-off", Status: "open", Priority: 1, Estimate: &zero}
+	blockers := map[string][]model.Issue{
+		"faz-ab12.1": {kickoff},
+		"faz-ab12.2": {build},
+	}
+
+	plan, err := buildPlan(epic, []model.Issue{kickoff, build, signoff}, blockers)
+	if err != nil {
+		t.Fatalf("build plan: %v", err)
+	}
+	wantPath := []string{"faz-ab12.0", "faz-ab12.1", "faz-ab12.2"}
+	if !reflect.DeepEqual(plan.CriticalPath, wantPath) || plan.CriticalWeight != 2 {
+		t.Fatalf("critical path = %v (weight %d), want %v (weight 2)", plan.CriticalPath, plan.CriticalWeight, wantPath)
+	}
+}
+
+func TestBuildPlanReportsCycles(t *testing.T) {
+	epic := model.Issue{ID: "faz-ab12", Type: "epic", Status: "open"}
+	first := model.Issue{ID: "faz-ab12.0", Status: "open"}
+	second := model.Issue{ID: "faz-ab12.1", Status: "open"}
+	blockers := map[string][]model.Issue{
+		first.ID:  {second},
+		second.ID: {first},
+	}
+
+	if _, err := buildPlan(epic, []model.Issue{first, second}, blockers); !errors.Is(err, repo.ErrDependencyCycle) {
+		t.Fatalf("expected dependency cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+var idPrefixRegex = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+var typeNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Settings are the project-tunable defaults applied by the service and commands.
+type Settings struct {
+	IDPrefix        string
+	ExtraTypes      []string
+	DefaultType     string
+	DefaultPriority int
+	DefaultClaimTTL time.Duration
+}
+
+// DefaultSettings returns the built-in behavior used when no config overrides it.
+func DefaultSettings() Settings {
+	return Settings{
+		DefaultType:     "task",
+		DefaultPriority: 2,
+		DefaultClaimTTL: 10 * time.Minute,
+	}
+}
+
+// Validate checks that settings name a usable prefix, types and defaults.
+func (settings Settings) Validate() error {
+	if settings.IDPrefix != "" && !idPrefixRegex.MatchString(settings.IDPrefix) {
+		return invalidf("invalid id prefix %q (use 1-32 lowercase letters, digits or _)", settings.IDPrefix)
+	}
+	types := settings.typeSet()
+	for _, typ := range settings.ExtraTypes {
+		if !typeNameRegex.MatchString(typ) {
+			return invalidf("invalid issue type name %q", typ)
+		}
+	}
+	if _, ok := types[settings.DefaultType]; !ok {
+		return invalidf("invalid default type %q", settings.DefaultType)
+	}
+	if settings.DefaultPriority < 0 || settings.DefaultPriority > 3 {
+		return invalidf("default priority must be between 0 and 3")
+	}
+	if settings.DefaultClaimTTL <= 0 {
+		return invalidf("default claim ttl must be greater than zero")
+	}
+	return nil
+}
+
+// Configure validates settings and applies them to the service.
+// An empty IDPrefix keeps the prefix derived from the project directory name.
+func (s *IssueService) Configure(settings Settings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	s.settings = settings
+	s.types = settings.typeSet()
+	if settings.IDPrefix != "" {
+		s.projectToken = settings.IDPrefix
+	}
+	return nil
+}
+
+// Settings returns the defaults currently applied by the service.
+func (s *IssueService) Settings() Settings {
+	return s.settings
+}
+
+// Types lists the issue types this project accepts, built-in and configured.
+func (s *IssueService) Types() []string {
+	out := make([]string, 0, len(s.types))
+	for typ := range s.types {
+		out = append(out, typ)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// typeSet returns the built-in types plus the configured extras.
+func (settings Settings) typeSet() map[string]struct{} {
+	types := make(map[string]struct{}, len(validTypes)+len(settings.ExtraTypes))
+	for typ := range validTypes {
+		types[typ] = struct{}{}
+	}
+	for _, typ := range settings.ExtraTypes {
+		types[typ] = struct{}{}
+	}
+	return types
+}
+
+// validType reports whether typ is a built-in or configured issue type.
+func (s *IssueService) validType(typ string) bool {
+	_, ok := s.types[typ]
+	return ok
+}
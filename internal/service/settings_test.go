@@ -0,0 +1,72 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rpcarvs/faz/internal/db"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+func TestSettingsValidate(t *testing.T) {
+	if err := DefaultSettings().Validate(); err != nil {
+		t.Fatalf("default settings: %v", err)
+	}
+	for name, mutate := range map[string]func(*Settings){
+		"prefix":   func(s *Settings) { s.IDPrefix = "My-Project" },
+		"type":     func(s *Settings) { s.ExtraTypes = []string{"Spike"} },
+		"default":  func(s *Settings) { s.DefaultType = "spike" },
+		"priority": func(s *Settings) { s.DefaultPriority = 4 },
+		"ttl":      func(s *Settings) { s.DefaultClaimTTL = 0 },
+	} {
+		settings := DefaultSettings()
+		mutate(&settings)
+		if err := settings.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestConfigureAppliesPrefixAndExtraTypes(t *testing.T) {
+	projectDir := t.TempDir()
+	dbPath, err := db.EnsureProjectFiles(projectDir)
+	if err != nil {
+		t.Fatalf("ensure project files: %v", err)
+	}
+	sqlDB, err := db.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = sqlDB.Close() }()
+	if err := db.Migrate(sqlDB); err != nil {
+		t.Fatalf("migrate db: %v", err)
+	}
+
+	svc := NewIssueService(repo.NewIssueRepo(sqlDB), "faz")
+	spike := model.Issue{Title: "Spike", Type: "spike", Priority: 2, Status: "open"}
+	if _, err := svc.Create(spike); err == nil {
+		t.Fatal("expected unknown type to be rejected before configuration")
+	}
+
+	settings := DefaultSettings()
+	settings.IDPrefix = "core"
+	settings.ExtraTypes = []string{"spike"}
+	settings.DefaultType = "spike"
+	if err := svc.Configure(settings); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	id, err := svc.Create(spike)
+	if err != nil {
+		t.Fatalf("create spike: %v", err)
+	}
+	if !strings.HasPrefix(id, "core-") {
+		t.Fatalf("expected core- prefix, got %s", id)
+	}
+	if issues, err := svc.List(model.ListFilter{Type: "spike"}); err != nil || len(issues) != 1 {
+		t.Fatalf("list spikes = %d, %v", len(issues), err)
+	}
+	if svc.Settings().DefaultType != "spike" {
+		t.Fatalf("unexpected settings: %+v", svc.Settings())
+	}
+}
@@ -74,6 +74,17 @@ func hookConfigJSON() []byte {
 						},
 					},
 				},
+				// A separate entry, so installs from before it existed gain it without a duplicate.
+				map[string]any{
+					"matcher": "startup|resume|clear|compact",
+					"hooks": []any{
+						map[string]any{
+							"type":    "command",
+							"command": sessionIDCommand,
+							"timeout": 5,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -11,6 +11,10 @@ const skillDirName = "task-management-with-faz"
 const bundledSkillPath = "bundled/task-management-with-faz/SKILL.md"
 const sessionStartCommand = "git rev-parse --show-toplevel >/dev/null 2>&1 && faz init && faz onboard"
 
+// sessionIDCommand exports the agent's session id as FAZ_SESSION_ID so parallel
+// agents on one machine record distinct claim owners.
+const sessionIDCommand = "faz session-start"
+
 // bundledFiles contains built-in skill files to install for supported tools.
 //
 //go:embed bundled/task-management-with-faz/SKILL.md
@@ -29,6 +33,7 @@ type InstallOptions struct {
 	Local     bool
 	LocalRoot string
 	Force     bool
+	MCP       bool
 }
 
 // InstallResult reports all paths touched by a provider install.
@@ -40,9 +45,12 @@ type InstallResult struct {
 	HookAction          string
 	ClaudePointerPath   string
 	ClaudePointerAction string
+	MCPPath             string
+	MCPAction           string
 }
 
-// InstallProvider installs skill, context, and hooks for one supported agent.
+// InstallProvider installs skill, context, and hooks for one supported agent,
+// plus the faz MCP server registration when options.MCP is set.
 func InstallProvider(options InstallOptions) (InstallResult, error) {
 	if err := validateInstallOptions(options); err != nil {
 		return InstallResult{}, err
@@ -92,6 +100,15 @@ func InstallProvider(options InstallOptions) (InstallResult, error) {
 		result.ClaudePointerAction = action
 	}
 
+	if options.MCP {
+		path, action, err := installMCPConfig(options)
+		if err != nil {
+			return InstallResult{}, err
+		}
+		result.MCPPath = path
+		result.MCPAction = action
+	}
+
 	return result, nil
 }
 
@@ -274,6 +274,9 @@ func TestInstallHookConfigAtPathMergesWithoutDuplication(t *testing.T) {
 	if count := strings.Count(text, sessionStartCommand); count != 1 {
 		t.Fatalf("expected one faz hook, got %d in %s", count, text)
 	}
+	if count := strings.Count(text, sessionIDCommand); count != 1 {
+		t.Fatalf("expected one session id hook, got %d in %s", count, text)
+	}
 	if count := strings.Count(text, "echo existing"); count != 1 {
 		t.Fatalf("expected existing hook preserved, got %d in %s", count, text)
 	}
@@ -294,6 +297,73 @@ func TestInstallHookConfigAtPathReportsInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestInstallMCPConfigAtPathPreservesOtherServers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mcp.json")
+	existing := []byte(`{"mcpServers":{"other":{"command":"other-server"}},"theme":"dark"}`)
+	if err := os.WriteFile(path, existing, 0o644); err != nil {
+		t.Fatalf("seed mcp config: %v", err)
+	}
+
+	action, err := InstallMCPConfigAtPath(path)
+	if err != nil || action != "updated" {
+		t.Fatalf("first mcp install = %q, %v", action, err)
+	}
+	action, err = InstallMCPConfigAtPath(path)
+	if err != nil || action != "unchanged" {
+		t.Fatalf("second mcp install = %q, %v", action, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mcp config: %v", err)
+	}
+	var config struct {
+		Theme      string `json:"theme"`
+		MCPServers map[string]struct {
+			Command string   `json:"command"`
+			Args    []string `json:"args"`
+		} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("parse mcp config: %v", err)
+	}
+	if config.Theme != "dark" || config.MCPServers["other"].Command != "other-server" {
+		t.Fatalf("expected existing settings preserved, got %s", data)
+	}
+	faz := config.MCPServers["faz"]
+	if faz.Command != "faz" || len(faz.Args) != 1 || faz.Args[0] != "mcp" {
+		t.Fatalf("unexpected faz server entry: %s", data)
+	}
+}
+
+func TestInstallCodexMCPConfigAtPathAppendsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("model = \"o3\""), 0o644); err != nil {
+		t.Fatalf("seed codex config: %v", err)
+	}
+
+	action, err := InstallCodexMCPConfigAtPath(path)
+	if err != nil || action != "updated" {
+		t.Fatalf("first codex mcp install = %q, %v", action, err)
+	}
+	action, err = InstallCodexMCPConfigAtPath(path)
+	if err != nil || action != "unchanged" {
+		t.Fatalf("second codex mcp install = %q, %v", action, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read codex config: %v", err)
+	}
+	text := string(data)
+	if !strings.HasPrefix(text, "model = \"o3\"\n\n[mcp_servers.faz]\n") {
+		t.Fatalf("expected table appended after existing settings, got %q", text)
+	}
+	if count := strings.Count(text, codexMCPTable); count != 1 {
+		t.Fatalf("expected one faz table, got %d in %s", count, text)
+	}
+}
+
 func assertInstalledSharedSkill(t *testing.T, skillPath string) {
 	t.Helper()
 
@@ -0,0 +1,161 @@
+package skillinstaller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const mcpServerName = "faz"
+
+// codexMCPTable is the TOML table header Codex reads MCP servers from.
+const codexMCPTable = "[mcp_servers." + mcpServerName + "]"
+
+// InstallMCPConfigAtPath registers `faz mcp` under mcpServers in a Claude JSON config.
+func InstallMCPConfigAtPath(path string) (string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("read mcp config %s: %w", path, err)
+	}
+
+	updated, action, err := upsertMCPConfig(existing)
+	if err != nil {
+		return "", err
+	}
+	if action == "unchanged" {
+		return action, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create mcp config directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return "", fmt.Errorf("write mcp config %s: %w", path, err)
+	}
+	return action, nil
+}
+
+// upsertMCPConfig sets the managed faz server entry while keeping other servers and settings.
+func upsertMCPConfig(existing []byte) ([]byte, string, error) {
+	current := make(map[string]any)
+	if len(existing) > 0 {
+		if err := json.Unmarshal(existing, &current); err != nil {
+			return nil, "", fmt.Errorf("parse current mcp config: %w", err)
+		}
+	}
+
+	servers, ok := current["mcpServers"].(map[string]any)
+	if !ok || servers == nil {
+		servers = make(map[string]any)
+		current["mcpServers"] = servers
+	}
+	managed := map[string]any{
+		"type":    "stdio",
+		"command": "faz",
+		"args":    []any{"mcp"},
+	}
+	before := canonicalJSON(servers[mcpServerName])
+	servers[mcpServerName] = managed
+
+	after, err := marshalIndent(current)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal mcp config: %w", err)
+	}
+	action := "updated"
+	if len(existing) == 0 {
+		action = "created"
+	} else if before == canonicalJSON(managed) {
+		action = "unchanged"
+	}
+	return after, action, nil
+}
+
+// InstallCodexMCPConfigAtPath appends the faz MCP server table to a Codex config.toml.
+// An existing [mcp_servers.faz] table is left alone so user edits survive.
+func InstallCodexMCPConfigAtPath(path string) (string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("read mcp config %s: %w", path, err)
+	}
+	if hasTOMLTable(string(existing), codexMCPTable) {
+		return "unchanged", nil
+	}
+
+	block := codexMCPTable + "\ncommand = \"faz\"\nargs = [\"mcp\"]\n"
+	content := string(existing)
+	action := "created"
+	if len(existing) > 0 {
+		action = "updated"
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += "\n"
+	}
+	content += block
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create mcp config directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("write mcp config %s: %w", path, err)
+	}
+	return action, nil
+}
+
+// hasTOMLTable reports whether content declares the given table header on its own line.
+func hasTOMLTable(content, header string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == header {
+			return true
+		}
+	}
+	return false
+}
+
+// mcpConfigPath resolves where a provider reads MCP server registrations.
+func mcpConfigPath(options InstallOptions) (string, error) {
+	if options.Local {
+		switch options.Provider {
+		case ProviderCodex:
+			return filepath.Join(options.LocalRoot, ".codex", "config.toml"), nil
+		case ProviderClaude:
+			return filepath.Join(options.LocalRoot, ".mcp.json"), nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	switch options.Provider {
+	case ProviderCodex:
+		codexHome := os.Getenv("CODEX_HOME")
+		if codexHome == "" {
+			codexHome = filepath.Join(home, ".codex")
+		}
+		return filepath.Join(codexHome, "config.toml"), nil
+	case ProviderClaude:
+		return filepath.Join(home, ".claude.json"), nil
+	default:
+		return "", fmt.Errorf("unsupported install provider %q", options.Provider)
+	}
+}
+
+// installMCPConfig registers the faz MCP server in the provider's config format.
+func installMCPConfig(options InstallOptions) (string, string, error) {
+	path, err := mcpConfigPath(options)
+	if err != nil {
+		return "", "", err
+	}
+	var action string
+	if options.Provider == ProviderCodex {
+		action, err = InstallCodexMCPConfigAtPath(path)
+	} else {
+		action, err = InstallMCPConfigAtPath(path)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return path, action, nil
+}
@@ -0,0 +1,287 @@
+package kanban
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
+)
+
+// toastDuration is how long an action result stays in the header.
+const toastDuration = 4 * time.Second
+
+// cardAction names one mutation the board can apply to the selected card.
+type cardAction string
+
+const (
+	actionClaim        cardAction = "claim"
+	actionRelease      cardAction = "release"
+	actionClose        cardAction = "close"
+	actionReopen       cardAction = "reopen"
+	actionPriorityUp   cardAction = "priority_up"
+	actionPriorityDown cardAction = "priority_down"
+)
+
+// pendingAction is a card action waiting for confirmation or for the service to answer.
+type pendingAction struct {
+	action   cardAction
+	original model.Issue
+	patched  model.Issue
+}
+
+// actionDoneMsg reports the outcome of a card action. issue holds the stored
+// state after the call when it could be read back.
+type actionDoneMsg struct {
+	pending pendingAction
+	issue   *model.Issue
+	err     error
+}
+
+// toastExpiredMsg clears the toast it was scheduled for, unless a newer one replaced it.
+type toastExpiredMsg struct {
+	seq int
+}
+
+// hookWarningMsg carries one failed notification hook reported by the service's runner.
+type hookWarningMsg struct {
+	text string
+}
+
+// toast is a short-lived action result shown in the header.
+type toast struct {
+	text  string
+	isErr bool
+	seq   int
+}
+
+// WithClaimLease sets the lease used when claiming from the board.
+func WithClaimLease(lease time.Duration) Option {
+	return func(m *Model) {
+		if lease > 0 {
+			m.claimLease = lease
+		}
+	}
+}
+
+// WithHookWarnings shows each failed notification hook received on warnings as an
+// error toast. Hook output cannot reach the terminal while the board owns it.
+func WithHookWarnings(warnings <-chan string) Option {
+	return func(m *Model) { m.hookWarnings = warnings }
+}
+
+// label returns the verb shown in prompts and toasts.
+func (a cardAction) label() string {
+	switch a {
+	case actionPriorityUp:
+		return "Raise priority of"
+	case actionPriorityDown:
+		return "Lower priority of"
+	default:
+		return strings.ToUpper(string(a[:1])) + string(a[1:])
+	}
+}
+
+// planAction checks that an action applies to issue and builds the optimistic copy.
+func planAction(action cardAction, issue model.Issue) (pendingAction, error) {
+	patched := issue
+	switch action {
+	case actionClaim:
+		if issue.Status == "closed" {
+			return pendingAction{}, fmt.Errorf("%s is closed; reopen it before claiming", issue.ID)
+		}
+		patched.Status = "in_progress"
+	case actionRelease:
+		if issue.Status != "in_progress" {
+			return pendingAction{}, fmt.Errorf("%s is not claimed", issue.ID)
+		}
+		patched.Status = "open"
+		patched.ClaimedBy = nil
+		patched.ClaimedAt = nil
+		patched.ClaimExpiresAt = nil
+	case actionClose:
+		if issue.Status == "closed" {
+			return pendingAction{}, fmt.Errorf("%s is already closed", issue.ID)
+		}
+		now := time.Now().UTC()
+		patched.Status = "closed"
+		patched.ClosedAt = &now
+		patched.ClaimedBy = nil
+	case actionReopen:
+		if issue.Status != "closed" {
+			return pendingAction{}, fmt.Errorf("%s is not closed", issue.ID)
+		}
+		patched.Status = "open"
+		patched.ClosedAt = nil
+	case actionPriorityUp:
+		if issue.Priority <= 0 {
+			return pendingAction{}, fmt.Errorf("%s is already P0", issue.ID)
+		}
+		patched.Priority--
+	case actionPriorityDown:
+		if issue.Priority >= 3 {
+			return pendingAction{}, fmt.Errorf("%s is already P3", issue.ID)
+		}
+		patched.Priority++
+	default:
+		return pendingAction{}, fmt.Errorf("unknown action %q", action)
+	}
+	return pendingAction{action: action, original: issue, patched: patched}, nil
+}
+
+// needsConfirmation reports whether an action asks before running. Priority
+// bumps are undone by the opposite key, so they apply immediately.
+func (a cardAction) needsConfirmation() bool {
+	return a != actionPriorityUp && a != actionPriorityDown
+}
+
+// requestAction plans an action on the selected card and either prompts for it or starts it.
+func (m *Model) requestAction(action cardAction) tea.Cmd {
+	issue := m.currentIssue()
+	if issue == nil {
+		return nil
+	}
+	pending, err := planAction(action, *issue)
+	if err != nil {
+		return m.showToast(err.Error(), true)
+	}
+	if action.needsConfirmation() {
+		m.confirm = &pending
+		return nil
+	}
+	return m.startAction(pending)
+}
+
+// startAction applies the optimistic board update and runs the action in the background.
+func (m *Model) startAction(pending pendingAction) tea.Cmd {
+	m.patchIssue(pending.patched)
+	return m.actionCmd(pending)
+}
+
+// actionCmd calls the service for one action and reads the issue back.
+func (m Model) actionCmd(pending pendingAction) tea.Cmd {
+	svc := m.svc
+	lease := m.claimLease
+	return func() tea.Msg {
+		id := pending.original.ID
+		var err error
+		switch pending.action {
+		case actionClaim:
+			err = svc.Claim(id, lease)
+		case actionRelease:
+			err = svc.Release(id)
+		case actionClose:
+			err = svc.Close(id)
+		case actionReopen:
+			err = svc.Reopen(id)
+		case actionPriorityUp, actionPriorityDown:
+			err = svc.Update(id, map[string]any{"priority": pending.patched.Priority})
+		}
+		msg := actionDoneMsg{pending: pending, err: err}
+		if issue, getErr := svc.Get(id); getErr == nil {
+			msg.issue = &issue
+		}
+		return msg
+	}
+}
+
+// finishAction settles an action: the stored issue replaces the optimistic
+// copy, or the board rolls back when the call failed.
+func (m *Model) finishAction(msg actionDoneMsg) tea.Cmd {
+	id := msg.pending.original.ID
+	switch {
+	case msg.issue != nil:
+		m.patchIssue(*msg.issue)
+	case msg.err != nil:
+		m.patchIssue(msg.pending.original)
+	}
+	delete(m.details, id)
+	if msg.err != nil {
+		return m.showToast(actionErrorText(msg), true)
+	}
+	return m.showToast(fmt.Sprintf("%s %s: done", msg.pending.action.label(), id), false)
+}
+
+// actionErrorText explains a failed action, naming the current owner of a contested claim.
+func actionErrorText(msg actionDoneMsg) string {
+	id := msg.pending.original.ID
+	if errors.Is(msg.err, repo.ErrIssueAlreadyClaimed) {
+		if msg.issue != nil && msg.issue.ClaimedBy != nil {
+			return fmt.Sprintf("%s is already claimed by @%s", id, *msg.issue.ClaimedBy)
+		}
+		return fmt.Sprintf("%s is already claimed", id)
+	}
+	return fmt.Sprintf("%s %s failed: %v", msg.pending.action.label(), id, msg.err)
+}
+
+// patchIssue swaps one issue into the catalog and keeps the cursor on it.
+func (m *Model) patchIssue(issue model.Issue) {
+	m.rebuildCatalog(mergeIssues(m.catalog.Issues, []model.Issue{issue}))
+	if m.scopeIndex >= len(m.catalog.Scopes) {
+		m.scopeIndex = 0
+	}
+	if pos, ok := m.locateIssueInCurrentScope(issue.ID); ok {
+		m.selectPosition(pos)
+		return
+	}
+	m.ensureSelection()
+}
+
+// showToast replaces the current toast and schedules it to clear.
+func (m *Model) showToast(text string, isErr bool) tea.Cmd {
+	m.toastSeq++
+	seq := m.toastSeq
+	m.toast = &toast{text: text, isErr: isErr, seq: seq}
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{seq: seq}
+	})
+}
+
+// waitForHookWarning waits for the next failed hook; Update re-arms it after each one.
+func (m Model) waitForHookWarning() tea.Cmd {
+	if m.hookWarnings == nil {
+		return nil
+	}
+	warnings := m.hookWarnings
+	return func() tea.Msg {
+		text, ok := <-warnings
+		if !ok {
+			return nil
+		}
+		return hookWarningMsg{text: text}
+	}
+}
+
+// updateConfirm answers the confirmation prompt.
+func (m Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		pending := *m.confirm
+		m.confirm = nil
+		return m, m.startAction(pending)
+	case "n", "esc", "q":
+		m.confirm = nil
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderConfirm draws the confirmation prompt for the pending action.
+func (m Model) renderConfirm() string {
+	width := minInt(60, maxInt(minModalWidth, m.width-10))
+	issue := m.confirm.original
+	lines := m.wrapModalLines([]string{
+		fmt.Sprintf("%s %s?", m.confirm.action.label(), issue.ID),
+		"",
+		issue.Title,
+		"",
+		"y / Enter confirm • n / Esc cancel",
+	}, maxInt(1, width-6))
+	box := m.theme.modalStyle(width).Render(strings.Join(lines, "\n"))
+	return box
+}
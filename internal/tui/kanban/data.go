@@ -13,11 +13,24 @@ const (
 	scopeNoEpic = "__no_epic__"
 )
 
-// Service defines the read-only operations needed by the kanban TUI.
+// Service defines the reads and card actions needed by the kanban TUI.
 type Service interface {
 	List(filter model.ListFilter) ([]model.Issue, error)
+	Get(publicID string) (model.Issue, error)
 	Dependencies(publicID string) ([]model.Issue, error)
 	Dependents(publicID string) ([]model.Issue, error)
+	DependencyEdges() ([]model.Dependency, error)
+	Comments(publicID string, limit int) ([]model.Comment, error)
+	Search(query string, filter model.ListFilter, limit int) ([]model.SearchResult, error)
+	Changes(after int64, limit int) ([]model.IssueEvent, error)
+	LatestChange() (int64, error)
+	Claim(publicID string, lease time.Duration) error
+	Release(publicID string) error
+	Close(publicID string) error
+	Reopen(publicID string) error
+	Update(publicID string, fields map[string]any) error
+	Create(issue model.Issue) (string, error)
+	Types() []string
 }
 
 // Scope identifies one kanban grouping target for the TUI.
@@ -27,42 +40,48 @@ type Scope struct {
 	CreatedAt time.Time
 }
 
-type scopeColumns struct {
-	Todo    []model.Issue
-	Claimed []model.Issue
-	Done    []model.Issue
-}
-
-// Catalog stores pre-grouped tasks for each selectable scope.
+// Catalog stores the tasks of each selectable scope; the board splits them into columns.
 type Catalog struct {
-	Scopes     []Scope
-	Columns    map[string]scopeColumns
-	EpicTitles map[string]string
-	Epics      map[string]model.Issue
+	Scopes      []Scope
+	ScopeIssues map[string][]model.Issue
+	EpicTitles  map[string]string
+	Epics       map[string]model.Issue
+	Labels      []string
+	Issues      []model.Issue
+
+	// Dependencies holds every dependency edge; Blocked marks open issues that
+	// still wait on an open blocker.
+	Dependencies []model.Dependency
+	Blocked      map[string]struct{}
 }
 
-// LoadCatalog fetches all issues and groups them into kanban scopes.
+// LoadCatalog fetches all issues and dependency edges and groups them into kanban scopes.
 func LoadCatalog(svc Service) (Catalog, error) {
 	issues, err := svc.List(model.ListFilter{All: true})
 	if err != nil {
 		return Catalog{}, err
 	}
-	return buildCatalog(issues), nil
+	edges, err := svc.DependencyEdges()
+	if err != nil {
+		return Catalog{}, err
+	}
+	return withDependencies(buildCatalog(issues), edges), nil
 }
 
-// buildCatalog derives epic scopes and kanban columns from a flat issue list.
+// buildCatalog derives epic scopes and the tasks in each from a flat issue list.
 func buildCatalog(issues []model.Issue) Catalog {
 	catalog := Catalog{
 		Scopes: []Scope{
 			{Key: scopeAll, Title: "All Epics"},
 			{Key: scopeNoEpic, Title: "Tasks with No Epic"},
 		},
-		Columns: map[string]scopeColumns{
-			scopeAll:    {},
-			scopeNoEpic: {},
+		ScopeIssues: map[string][]model.Issue{
+			scopeAll:    nil,
+			scopeNoEpic: nil,
 		},
 		EpicTitles: make(map[string]string),
 		Epics:      make(map[string]model.Issue),
+		Issues:     issues,
 	}
 
 	epicScopes := make([]Scope, 0)
@@ -75,7 +94,7 @@ func buildCatalog(issues []model.Issue) Catalog {
 			Title:     issue.Title,
 			CreatedAt: issue.CreatedAt,
 		})
-		catalog.Columns[issue.ID] = scopeColumns{}
+		catalog.ScopeIssues[issue.ID] = nil
 		catalog.EpicTitles[issue.ID] = issue.Title
 		catalog.Epics[issue.ID] = issue
 	}
@@ -84,44 +103,54 @@ func buildCatalog(issues []model.Issue) Catalog {
 	})
 	catalog.Scopes = append(catalog.Scopes, epicScopes...)
 
+	labelSeen := make(map[string]struct{})
 	for _, issue := range issues {
 		if issue.Type == "epic" {
 			continue
 		}
-		columnTarget := scopeColumnForStatus(issue.Status)
-		columns := catalog.Columns[scopeAll]
-		assignIssue(&columns, columnTarget, issue)
-		catalog.Columns[scopeAll] = columns
+		for _, label := range issue.Labels {
+			if _, ok := labelSeen[label]; ok {
+				continue
+			}
+			labelSeen[label] = struct{}{}
+			catalog.Labels = append(catalog.Labels, label)
+		}
+		catalog.ScopeIssues[scopeAll] = append(catalog.ScopeIssues[scopeAll], issue)
 		if issue.ParentID == nil {
-			noEpicColumns := catalog.Columns[scopeNoEpic]
-			assignIssue(&noEpicColumns, columnTarget, issue)
-			catalog.Columns[scopeNoEpic] = noEpicColumns
+			catalog.ScopeIssues[scopeNoEpic] = append(catalog.ScopeIssues[scopeNoEpic], issue)
 			continue
 		}
-		if _, ok := catalog.Columns[*issue.ParentID]; ok {
-			parentColumns := catalog.Columns[*issue.ParentID]
-			assignIssue(&parentColumns, columnTarget, issue)
-			catalog.Columns[*issue.ParentID] = parentColumns
+		if scoped, ok := catalog.ScopeIssues[*issue.ParentID]; ok {
+			catalog.ScopeIssues[*issue.ParentID] = append(scoped, issue)
 		}
 	}
 
-	for key, columns := range catalog.Columns {
-		sortColumnsNewestFirst(&columns)
-		catalog.Columns[key] = columns
-	}
+	sort.Strings(catalog.Labels)
 
 	return catalog
 }
 
-func assignIssue(columns *scopeColumns, status string, issue model.Issue) {
-	switch status {
-	case "closed":
-		columns.Done = append(columns.Done, issue)
-	case "in_progress":
-		columns.Claimed = append(columns.Claimed, issue)
-	default:
-		columns.Todo = append(columns.Todo, issue)
+// mergeIssues replaces issues whose IDs appear in updated and appends new ones.
+func mergeIssues(issues, updated []model.Issue) []model.Issue {
+	merged := make([]model.Issue, 0, len(issues)+len(updated))
+	replacements := make(map[string]model.Issue, len(updated))
+	for _, issue := range updated {
+		replacements[issue.ID] = issue
 	}
+	for _, issue := range issues {
+		if replacement, ok := replacements[issue.ID]; ok {
+			merged = append(merged, replacement)
+			delete(replacements, issue.ID)
+			continue
+		}
+		merged = append(merged, issue)
+	}
+	for _, issue := range updated {
+		if _, ok := replacements[issue.ID]; ok {
+			merged = append(merged, issue)
+		}
+	}
+	return merged
 }
 
 func scopeColumnForStatus(status string) string {
@@ -131,13 +160,6 @@ func scopeColumnForStatus(status string) string {
 	return status
 }
 
-// sortColumnsNewestFirst keeps each kanban column ordered by newest issue first.
-func sortColumnsNewestFirst(columns *scopeColumns) {
-	sortIssuesNewestFirst(columns.Todo)
-	sortIssuesNewestFirst(columns.Claimed)
-	sortClosedIssuesNewestFirst(columns.Done)
-}
-
 // sortIssuesNewestFirst orders issues by creation time descending with ID fallback.
 func sortIssuesNewestFirst(issues []model.Issue) {
 	sort.SliceStable(issues, func(i, j int) bool {
@@ -1,6 +1,7 @@
 package kanban
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -63,34 +64,34 @@ func TestBuildCatalogGroupsEpicAndNoEpicScopes(t *testing.T) {
 		t.Fatalf("expected third scope to be epic %q, got %q", parentID, catalog.Scopes[2].Key)
 	}
 
-	all := catalog.Columns[scopeAll]
-	if len(all.Todo) != 1 || all.Todo[0].ID != parentID+".0" {
-		t.Fatalf("unexpected all/todo contents: %#v", all.Todo)
+	all := scopeBoard(catalog, scopeAll)
+	if len(all["todo"]) != 1 || all["todo"][0].ID != parentID+".0" {
+		t.Fatalf("unexpected all/todo contents: %#v", all["todo"])
 	}
-	if len(all.Claimed) != 1 || all.Claimed[0].ID != parentID+".1" {
-		t.Fatalf("unexpected all/claimed contents: %#v", all.Claimed)
+	if len(all["claimed"]) != 1 || all["claimed"][0].ID != parentID+".1" {
+		t.Fatalf("unexpected all/claimed contents: %#v", all["claimed"])
 	}
-	if len(all.Done) != 1 || all.Done[0].ID != "proj-r123" {
-		t.Fatalf("unexpected all/done contents: %#v", all.Done)
+	if len(all["done"]) != 1 || all["done"][0].ID != "proj-r123" {
+		t.Fatalf("unexpected all/done contents: %#v", all["done"])
 	}
 
-	noEpic := catalog.Columns[scopeNoEpic]
-	if len(noEpic.Todo) != 0 || len(noEpic.Claimed) != 0 {
-		t.Fatalf("expected no open or claimed no-epic tasks, got todo=%d claimed=%d", len(noEpic.Todo), len(noEpic.Claimed))
+	noEpic := scopeBoard(catalog, scopeNoEpic)
+	if len(noEpic["todo"]) != 0 || len(noEpic["claimed"]) != 0 {
+		t.Fatalf("expected no open or claimed no-epic tasks, got todo=%d claimed=%d", len(noEpic["todo"]), len(noEpic["claimed"]))
 	}
-	if len(noEpic.Done) != 1 || noEpic.Done[0].ID != "proj-r123" {
-		t.Fatalf("unexpected no-epic done contents: %#v", noEpic.Done)
+	if len(noEpic["done"]) != 1 || noEpic["done"][0].ID != "proj-r123" {
+		t.Fatalf("unexpected no-epic done contents: %#v", noEpic["done"])
 	}
 
-	epic := catalog.Columns[parentID]
-	if len(epic.Todo) != 1 || epic.Todo[0].ID != parentID+".0" {
-		t.Fatalf("unexpected epic/todo contents: %#v", epic.Todo)
+	epic := scopeBoard(catalog, parentID)
+	if len(epic["todo"]) != 1 || epic["todo"][0].ID != parentID+".0" {
+		t.Fatalf("unexpected epic/todo contents: %#v", epic["todo"])
 	}
-	if len(epic.Claimed) != 1 || epic.Claimed[0].ID != parentID+".1" {
-		t.Fatalf("unexpected epic/claimed contents: %#v", epic.Claimed)
+	if len(epic["claimed"]) != 1 || epic["claimed"][0].ID != parentID+".1" {
+		t.Fatalf("unexpected epic/claimed contents: %#v", epic["claimed"])
 	}
-	if len(epic.Done) != 0 {
-		t.Fatalf("expected no epic done tasks, got %#v", epic.Done)
+	if len(epic["done"]) != 0 {
+		t.Fatalf("expected no epic done tasks, got %#v", epic["done"])
 	}
 }
 
@@ -201,15 +202,23 @@ func TestBuildCatalogOrdersColumnTasksNewestFirst(t *testing.T) {
 	}
 
 	catalog := buildCatalog(issues)
-	epic := catalog.Columns[parentID]
+	epic := scopeBoard(catalog, parentID)
 
-	if len(epic.Todo) != 2 || epic.Todo[0].ID != parentID+".1" || epic.Todo[1].ID != parentID+".0" {
-		t.Fatalf("expected open tasks newest first, got %#v", epic.Todo)
+	if len(epic["todo"]) != 2 || epic["todo"][0].ID != parentID+".1" || epic["todo"][1].ID != parentID+".0" {
+		t.Fatalf("expected open tasks newest first, got %#v", epic["todo"])
 	}
-	if len(epic.Claimed) != 2 || epic.Claimed[0].ID != parentID+".3" || epic.Claimed[1].ID != parentID+".2" {
-		t.Fatalf("expected claimed tasks newest first, got %#v", epic.Claimed)
+	if len(epic["claimed"]) != 2 || epic["claimed"][0].ID != parentID+".3" || epic["claimed"][1].ID != parentID+".2" {
+		t.Fatalf("expected claimed tasks newest first, got %#v", epic["claimed"])
 	}
-	if len(epic.Done) != 2 || epic.Done[0].ID != parentID+".5" || epic.Done[1].ID != parentID+".4" {
-		t.Fatalf("expected done tasks newest closed first, got %#v", epic.Done)
+	if len(epic["done"]) != 2 || epic["done"][0].ID != parentID+".5" || epic["done"][1].ID != parentID+".4" {
+		t.Fatalf("expected done tasks newest closed first, got %#v", epic["done"])
 	}
 }
+
+// scopeBoard splits one scope of catalog into the default board columns.
+func scopeBoard(catalog Catalog, scope string) map[string][]model.Issue {
+	board := NewModel(nil)
+	board.catalog = catalog
+	board.scopeIndex = slices.IndexFunc(catalog.Scopes, func(s Scope) bool { return s.Key == scope })
+	return boardIssues(board)
+}
@@ -0,0 +1,382 @@
+package kanban
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// Form fields in focus order.
+const (
+	formFieldTitle = iota
+	formFieldType
+	formFieldPriority
+	formFieldParent
+	formFieldDescription
+	formFieldCount
+)
+
+// issueForm holds the create/edit modal state. An empty issueID means create.
+type issueForm struct {
+	issueID     string
+	original    model.Issue
+	focus       int
+	title       string
+	types       []string
+	typeIndex   int
+	priority    int
+	parents     []Scope
+	parentIndex int
+	description string
+	saving      bool
+	err         error
+}
+
+// formSavedMsg reports a create or update from the form. issue holds the
+// stored copy when it could be read back.
+type formSavedMsg struct {
+	id      string
+	created bool
+	issue   *model.Issue
+	err     error
+}
+
+// WithIssueDefaults sets the type and priority a new issue starts with.
+func WithIssueDefaults(issueType string, priority int) Option {
+	return func(m *Model) {
+		m.defaultType = issueType
+		m.defaultPriority = priority
+	}
+}
+
+// openCreateForm starts a new issue under the current scope's epic.
+func (m *Model) openCreateForm() {
+	form := m.newForm(model.Issue{Type: m.defaultType, Priority: m.defaultPriority})
+	if _, ok := m.catalog.Epics[m.currentScope().Key]; ok {
+		form.parentIndex = parentOptionIndex(form.parents, m.currentScope().Key)
+	}
+	m.form = &form
+}
+
+// openEditForm loads the selected card into the form.
+func (m *Model) openEditForm() {
+	issue := m.currentIssue()
+	if issue == nil {
+		return
+	}
+	form := m.newForm(*issue)
+	form.issueID = issue.ID
+	form.original = *issue
+	form.title = issue.Title
+	form.description = issue.Description
+	if issue.ParentID != nil {
+		if _, ok := m.catalog.Epics[*issue.ParentID]; !ok {
+			// Keep a non-epic parent selectable so saving does not drop it.
+			form.parents = append(form.parents, Scope{Key: *issue.ParentID, Title: *issue.ParentID})
+		}
+		form.parentIndex = parentOptionIndex(form.parents, *issue.ParentID)
+	}
+	m.form = &form
+}
+
+// newForm builds form choices from the service types and the catalog epics.
+func (m Model) newForm(issue model.Issue) issueForm {
+	form := issueForm{
+		types:    m.svc.Types(),
+		priority: issue.Priority,
+		parents:  []Scope{{Title: "None"}},
+	}
+	for i, typ := range form.types {
+		if typ == issue.Type {
+			form.typeIndex = i
+		}
+	}
+	for _, scope := range m.catalog.Scopes {
+		if _, ok := m.catalog.Epics[scope.Key]; ok {
+			form.parents = append(form.parents, scope)
+		}
+	}
+	return form
+}
+
+// parentOptionIndex returns the parent choice for an issue ID, or 0 for none.
+func parentOptionIndex(parents []Scope, issueID string) int {
+	for i, parent := range parents {
+		if parent.Key == issueID {
+			return i
+		}
+	}
+	return 0
+}
+
+// parentID returns the selected parent, or nil for none.
+func (f issueForm) parentID() *string {
+	if f.parentIndex <= 0 || f.parentIndex >= len(f.parents) {
+		return nil
+	}
+	id := f.parents[f.parentIndex].Key
+	return &id
+}
+
+// issueType returns the selected type name.
+func (f issueForm) issueType() string {
+	if f.typeIndex < 0 || f.typeIndex >= len(f.types) {
+		return ""
+	}
+	return f.types[f.typeIndex]
+}
+
+// cleanDescription drops trailing blank lines and spaces left by typing.
+func (f issueForm) cleanDescription() string {
+	return strings.TrimRight(f.description, " \n")
+}
+
+// changedFields lists the fields an edit changes, in the shape IssueService.Update takes.
+func (f issueForm) changedFields() map[string]any {
+	fields := make(map[string]any)
+	if f.title != f.original.Title {
+		fields["title"] = f.title
+	}
+	if f.cleanDescription() != f.original.Description {
+		fields["description"] = f.cleanDescription()
+	}
+	if f.issueType() != f.original.Type {
+		fields["type"] = f.issueType()
+	}
+	if f.priority != f.original.Priority {
+		fields["priority"] = f.priority
+	}
+	parent := f.parentID()
+	if (parent == nil) != (f.original.ParentID == nil) || (parent != nil && *parent != *f.original.ParentID) {
+		fields["parent_public_id"] = parent
+	}
+	return fields
+}
+
+// updateForm edits the focused field; Ctrl+S saves and Esc cancels.
+func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	form := m.form
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+	if form.saving {
+		return m, nil
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.form = nil
+		return m, nil
+	case tea.KeyCtrlS:
+		return m, m.submitForm()
+	case tea.KeyTab:
+		form.focus = (form.focus + 1) % formFieldCount
+		return m, nil
+	case tea.KeyShiftTab:
+		form.focus = (form.focus + formFieldCount - 1) % formFieldCount
+		return m, nil
+	}
+
+	switch form.focus {
+	case formFieldTitle:
+		form.title = editText(form.title, msg, false)
+		if msg.Type == tea.KeyEnter {
+			form.focus++
+		}
+	case formFieldDescription:
+		form.description = editText(form.description, msg, true)
+	default:
+		switch msg.Type {
+		case tea.KeyLeft:
+			form.cycleChoice(-1)
+		case tea.KeyRight:
+			form.cycleChoice(1)
+		case tea.KeyUp:
+			form.focus--
+		case tea.KeyDown, tea.KeyEnter:
+			form.focus++
+		}
+	}
+	return m, nil
+}
+
+// cycleChoice steps the focused choice field, wrapping at either end.
+func (f *issueForm) cycleChoice(step int) {
+	switch f.focus {
+	case formFieldType:
+		if len(f.types) > 0 {
+			f.typeIndex = (f.typeIndex + step + len(f.types)) % len(f.types)
+		}
+	case formFieldPriority:
+		f.priority = (f.priority + step + 4) % 4
+	case formFieldParent:
+		f.parentIndex = (f.parentIndex + step + len(f.parents)) % len(f.parents)
+	}
+}
+
+// editText applies one key press to a text field. Enter adds a line break only
+// in multi-line fields.
+func editText(value string, msg tea.KeyMsg, multiline bool) string {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if runes := []rune(value); len(runes) > 0 {
+			return string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		if !multiline {
+			return ""
+		}
+		if cut := strings.LastIndex(value, "\n"); cut >= 0 {
+			return value[:cut+1]
+		}
+		return ""
+	case tea.KeySpace:
+		return value + " "
+	case tea.KeyEnter:
+		if multiline {
+			return value + "\n"
+		}
+	case tea.KeyRunes:
+		return value + string(msg.Runes)
+	}
+	return value
+}
+
+// submitForm saves the form through the service. An edit without changes just closes it.
+func (m *Model) submitForm() tea.Cmd {
+	form := m.form
+	svc := m.svc
+	if form.issueID != "" {
+		fields := form.changedFields()
+		if len(fields) == 0 {
+			m.form = nil
+			return nil
+		}
+		form.saving = true
+		id := form.issueID
+		return func() tea.Msg {
+			if err := svc.Update(id, fields); err != nil {
+				return formSavedMsg{id: id, err: err}
+			}
+			return readBackSaved(svc, id, false)
+		}
+	}
+
+	form.saving = true
+	issue := model.Issue{
+		Title:       form.title,
+		Description: form.cleanDescription(),
+		Type:        form.issueType(),
+		Priority:    form.priority,
+		ParentID:    form.parentID(),
+	}
+	return func() tea.Msg {
+		id, err := svc.Create(issue)
+		if err != nil {
+			return formSavedMsg{created: true, err: err}
+		}
+		return readBackSaved(svc, id, true)
+	}
+}
+
+// readBackSaved fetches a saved issue so the board can show it before the next change poll.
+func readBackSaved(svc Service, id string, created bool) formSavedMsg {
+	msg := formSavedMsg{id: id, created: created}
+	if issue, err := svc.Get(id); err == nil {
+		msg.issue = &issue
+	}
+	return msg
+}
+
+// finishForm closes the form after a save, or keeps it open with the service error inline.
+func (m *Model) finishForm(msg formSavedMsg) tea.Cmd {
+	if m.form == nil {
+		return nil
+	}
+	if msg.err != nil {
+		m.form.saving = false
+		m.form.err = msg.err
+		return nil
+	}
+	m.form = nil
+	delete(m.details, msg.id)
+	verb := "Updated"
+	if msg.created {
+		verb = "Created"
+	}
+	toast := m.showToast(fmt.Sprintf("%s %s", verb, msg.id), false)
+	if msg.issue == nil {
+		return tea.Batch(toast, m.loadCatalogCmd())
+	}
+	m.patchIssue(*msg.issue)
+	return toast
+}
+
+// renderForm draws the create/edit modal.
+func (m Model) renderForm() string {
+	form := m.form
+	width, _, bodyHeight := m.modalDimensions(false)
+	contentWidth := m.modalContentWidth(width)
+
+	heading := "New issue"
+	if form.issueID != "" {
+		heading = "Edit " + form.issueID
+	}
+	// The heading and any validation error stay pinned above the scrolling fields.
+	pinned := m.wrapModalLines([]string{heading, ""}, contentWidth)
+	if form.err != nil {
+		errorStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.errorText)
+		for _, line := range m.wrapModalLines([]string{"Error: " + form.err.Error()}, contentWidth) {
+			pinned = append(pinned, errorStyle.Render(line))
+		}
+		pinned = append(pinned, "")
+	}
+
+	field := func(index int, label, value string) string {
+		prefix := "  "
+		if form.focus == index {
+			prefix = "> "
+		}
+		return prefix + label + value
+	}
+	choice := func(index int, value string) string {
+		if form.focus == index {
+			return "◂ " + value + " ▸"
+		}
+		return value
+	}
+	cursor := func(index int) string {
+		if form.focus == index && !form.saving {
+			return "▏"
+		}
+		return ""
+	}
+	lines := []string{
+		field(formFieldTitle, "Title: ", form.title+cursor(formFieldTitle)),
+		field(formFieldType, "Type: ", choice(formFieldType, form.issueType())),
+		field(formFieldPriority, "Priority: ", choice(formFieldPriority, fmt.Sprintf("P%d", form.priority))),
+		field(formFieldParent, "Epic: ", choice(formFieldParent, form.parents[form.parentIndex].Title)),
+		field(formFieldDescription, "Description:", ""),
+	}
+	for _, line := range strings.Split(form.description+cursor(formFieldDescription), "\n") {
+		lines = append(lines, "    "+line)
+	}
+	status := "Tab next field • ←/→ change • Enter new line in description • Ctrl+S save • Esc cancel"
+	if form.saving {
+		status = "Saving..."
+	}
+	lines = append(lines, "", status)
+
+	wrapped := m.wrapModalLines(lines, contentWidth)
+	height := maxInt(1, bodyHeight-len(pinned))
+	scroll := 0
+	if form.focus == formFieldDescription {
+		// Follow the end of the description, where typing happens.
+		scroll = len(wrapped) - height
+	}
+	visible := append(pinned, viewportLines(wrapped, scroll, height)...)
+	box := m.theme.modalStyle(width).Render(strings.Join(visible, "\n"))
+	return box
+}
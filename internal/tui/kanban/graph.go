@@ -0,0 +1,391 @@
+package kanban
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// graphNodeLimit caps how many issues the dependency graph pane loads.
+const graphNodeLimit = 150
+
+// depGraph is the transitive blocker/dependent neighborhood of one issue.
+// Levels are negative above the root for blockers and positive below it for
+// dependents, so every edge points down the page.
+type depGraph struct {
+	rootID    string
+	nodes     map[string]model.Issue
+	levels    map[string]int
+	blockers  map[string][]string
+	blocks    map[string][]string
+	truncated bool
+}
+
+// graphPane holds the dependency graph modal state.
+type graphPane struct {
+	rootID     string
+	graph      depGraph
+	loading    bool
+	err        error
+	selectedID string
+	scroll     int
+}
+
+// graphLoadedMsg carries a freshly loaded dependency graph.
+type graphLoadedMsg struct {
+	rootID string
+	graph  depGraph
+	err    error
+}
+
+// loadDepGraph walks blockers upward and dependents downward from root.
+func loadDepGraph(svc Service, root model.Issue) (depGraph, error) {
+	graph := depGraph{
+		rootID:   root.ID,
+		nodes:    map[string]model.Issue{root.ID: root},
+		levels:   map[string]int{root.ID: 0},
+		blockers: make(map[string][]string),
+		blocks:   make(map[string][]string),
+	}
+	edges := make(map[[2]string]struct{})
+	addEdge := func(blocker, dependent string) {
+		key := [2]string{blocker, dependent}
+		if _, ok := edges[key]; ok {
+			return
+		}
+		edges[key] = struct{}{}
+		graph.blockers[dependent] = append(graph.blockers[dependent], blocker)
+		graph.blocks[blocker] = append(graph.blocks[blocker], dependent)
+	}
+
+	for _, side := range []struct {
+		next func(string) ([]model.Issue, error)
+		step int
+	}{{svc.Dependencies, -1}, {svc.Dependents, 1}} {
+		queue := []string{root.ID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			neighbors, err := side.next(id)
+			if err != nil {
+				return depGraph{}, err
+			}
+			for _, neighbor := range neighbors {
+				if _, ok := graph.nodes[neighbor.ID]; !ok {
+					if len(graph.nodes) >= graphNodeLimit {
+						graph.truncated = true
+						continue
+					}
+					graph.nodes[neighbor.ID] = neighbor
+					graph.levels[neighbor.ID] = graph.levels[id] + side.step
+					queue = append(queue, neighbor.ID)
+				}
+				if side.step < 0 {
+					addEdge(neighbor.ID, id)
+				} else {
+					addEdge(id, neighbor.ID)
+				}
+			}
+		}
+	}
+
+	// Push each blocker above everything it blocks and each dependent below
+	// everything blocking it. The round cap keeps a cyclic import finite.
+	for round := 0; round < len(graph.nodes); round++ {
+		changed := false
+		for edge := range edges {
+			blocker, dependent := edge[0], edge[1]
+			if graph.levels[blocker] < 0 && graph.levels[blocker] >= graph.levels[dependent] {
+				graph.levels[blocker] = graph.levels[dependent] - 1
+				changed = true
+			}
+			if graph.levels[dependent] > 0 && graph.levels[dependent] <= graph.levels[blocker] {
+				graph.levels[dependent] = graph.levels[blocker] + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	for _, ids := range graph.blockers {
+		sort.Strings(ids)
+	}
+	for _, ids := range graph.blocks {
+		sort.Strings(ids)
+	}
+	return graph, nil
+}
+
+// layers groups node IDs by level from the farthest blocker down, sorted by ID within a level.
+func (g depGraph) layers() [][]string {
+	byLevel := make(map[int][]string)
+	levels := make([]int, 0)
+	for id, level := range g.levels {
+		if _, ok := byLevel[level]; !ok {
+			levels = append(levels, level)
+		}
+		byLevel[level] = append(byLevel[level], id)
+	}
+	sort.Ints(levels)
+	layers := make([][]string, 0, len(levels))
+	for _, level := range levels {
+		ids := byLevel[level]
+		sort.Strings(ids)
+		layers = append(layers, ids)
+	}
+	return layers
+}
+
+// graphCmd loads the dependency graph for one issue in the background.
+func (m Model) graphCmd(root model.Issue) tea.Cmd {
+	svc := m.svc
+	return func() tea.Msg {
+		graph, err := loadDepGraph(svc, root)
+		return graphLoadedMsg{rootID: root.ID, graph: graph, err: err}
+	}
+}
+
+// openGraph shows the dependency graph pane for the selected card.
+func (m *Model) openGraph() tea.Cmd {
+	issue := m.currentIssue()
+	if issue == nil {
+		return nil
+	}
+	m.graph = &graphPane{rootID: issue.ID, selectedID: issue.ID, loading: true}
+	return m.graphCmd(*issue)
+}
+
+// refreshGraph reloads the open graph when a change touched one of its issues.
+func (m *Model) refreshGraph(touched map[string]struct{}) tea.Cmd {
+	if m.graph == nil || m.graph.loading {
+		return nil
+	}
+	for id := range touched {
+		if _, ok := m.graph.graph.nodes[id]; ok {
+			root := m.graph.graph.nodes[m.graph.rootID]
+			if latest := m.findIssueByID(root.ID); latest != nil {
+				root = *latest
+			}
+			return m.graphCmd(root)
+		}
+	}
+	return nil
+}
+
+// finishGraph stores a loaded graph, keeping the selection when the issue is still in it.
+func (m *Model) finishGraph(msg graphLoadedMsg) {
+	if m.graph == nil || m.graph.rootID != msg.rootID {
+		return
+	}
+	m.graph.loading = false
+	m.graph.err = msg.err
+	m.graph.graph = msg.graph
+	if _, ok := msg.graph.nodes[m.graph.selectedID]; !ok {
+		m.graph.selectedID = msg.rootID
+	}
+	m.ensureGraphVisible()
+}
+
+// updateGraph moves along graph edges: up to a blocker, down to a dependent,
+// and left/right within a layer. Enter jumps the board to the chosen issue.
+func (m Model) updateGraph(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pane := m.graph
+	switch msg.String() {
+	case "esc", "q", "g":
+		m.graph = nil
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	if pane.loading || pane.err != nil {
+		return m, nil
+	}
+	graph := pane.graph
+	switch msg.String() {
+	case "up", "k":
+		pane.selectedID = graph.nearestNeighbor(pane.selectedID, graph.blockers[pane.selectedID])
+	case "down", "j":
+		pane.selectedID = graph.nearestNeighbor(pane.selectedID, graph.blocks[pane.selectedID])
+	case "left", "h":
+		pane.selectedID = graph.layerSibling(pane.selectedID, -1)
+	case "right", "l":
+		pane.selectedID = graph.layerSibling(pane.selectedID, 1)
+	case "enter":
+		return m, m.jumpToGraphSelection()
+	}
+	m.ensureGraphVisible()
+	return m, nil
+}
+
+// nearestNeighbor picks the candidate on the closest layer to id, or id itself when there is none.
+func (g depGraph) nearestNeighbor(id string, candidates []string) string {
+	best := id
+	bestDistance := -1
+	for _, candidate := range candidates {
+		distance := g.levels[candidate] - g.levels[id]
+		if distance < 0 {
+			distance = -distance
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// layerSibling steps through the issues on the same layer as id, wrapping around.
+func (g depGraph) layerSibling(id string, step int) string {
+	for _, layer := range g.layers() {
+		for i, candidate := range layer {
+			if candidate == id {
+				return layer[(i+step+len(layer))%len(layer)]
+			}
+		}
+	}
+	return id
+}
+
+// jumpToGraphSelection closes the pane and selects the chosen issue on the board,
+// widening to all epics when the current scope does not show it.
+func (m *Model) jumpToGraphSelection() tea.Cmd {
+	id := m.graph.selectedID
+	pos, ok := m.locateIssueInCurrentScope(id)
+	if !ok && m.currentScope().Key != scopeAll {
+		scopeIndex := m.scopeIndex
+		m.selectScopeByKey(scopeAll)
+		if pos, ok = m.locateIssueInCurrentScope(id); !ok {
+			m.scopeIndex = scopeIndex
+			m.ensureSelection()
+		}
+	}
+	if !ok {
+		return m.showToast(fmt.Sprintf("%s is not shown on the board with the current filters", id), true)
+	}
+	m.graph = nil
+	m.selectPosition(pos)
+	return nil
+}
+
+// graphLines renders the layered graph and reports the line index of each issue.
+func (m Model) graphLines(width int) ([]string, map[string]int) {
+	graph := m.graph.graph
+	lines := make([]string, 0, len(graph.nodes)*2)
+	positions := make(map[string]int, len(graph.nodes))
+	for layerIndex, layer := range graph.layers() {
+		if layerIndex > 0 {
+			lines = append(lines, "    ▼")
+		}
+		for _, id := range layer {
+			positions[id] = len(lines)
+			lines = append(lines, m.renderGraphNode(graph.nodes[id], id == m.graph.selectedID, id == graph.rootID, graph.blocks[id], width))
+		}
+	}
+	return lines, positions
+}
+
+// renderGraphNode draws one issue line with a status-colored symbol and its outgoing edges.
+func (m Model) renderGraphNode(issue model.Issue, selected, root bool, blocks []string, width int) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+	marker := " "
+	if root {
+		marker = "◆"
+	}
+	text := fmt.Sprintf("%s%s %s %s  %s", prefix, marker, statusSymbol(issue.Status), issue.ID, issue.Title)
+	if len(blocks) > 0 {
+		text = fmt.Sprintf("%s  → %s", text, strings.Join(blocks, ", "))
+	}
+	text = truncateLine(text, width)
+
+	style := lipgloss.NewStyle().Foreground(m.theme.statusColor(issue.Status))
+	if selected {
+		style = style.Bold(true).Background(m.theme.selectedBackground)
+	}
+	return style.Render(text)
+}
+
+// ensureGraphVisible scrolls the graph body so the selected issue stays on screen.
+func (m *Model) ensureGraphVisible() {
+	if m.graph == nil || m.graph.loading {
+		return
+	}
+	width, _, bodyHeight := m.modalDimensions(false)
+	lines, positions := m.graphLines(m.modalContentWidth(width))
+	height := maxInt(1, bodyHeight-graphChromeLines)
+	line := positions[m.graph.selectedID]
+	if line < m.graph.scroll {
+		m.graph.scroll = line
+	}
+	if line >= m.graph.scroll+height {
+		m.graph.scroll = line - height + 1
+	}
+	m.graph.scroll = clampScrollOffset(m.graph.scroll, len(lines), height)
+}
+
+// graphChromeLines counts the heading and hint lines pinned around the graph body.
+const graphChromeLines = 4
+
+// renderGraph draws the dependency graph modal.
+func (m Model) renderGraph() string {
+	pane := m.graph
+	width, _, bodyHeight := m.modalDimensions(false)
+	contentWidth := m.modalContentWidth(width)
+
+	heading := "Dependency graph: " + pane.rootID
+	if root, ok := pane.graph.nodes[pane.rootID]; ok {
+		heading = fmt.Sprintf("%s %s", heading, root.Title)
+	}
+	hint := "↑ blocker • ↓ dependent • ←/→ same layer • Enter jump • Esc close"
+	if pane.graph.truncated {
+		hint = fmt.Sprintf("Showing the nearest %d issues. %s", graphNodeLimit, hint)
+	}
+
+	var body []string
+	switch {
+	case pane.loading:
+		body = []string{"Loading dependency graph..."}
+	case pane.err != nil:
+		body = m.wrapModalLines([]string{fmt.Sprintf("Failed to load dependency graph: %v", pane.err)}, contentWidth)
+	case len(pane.graph.nodes) == 1:
+		body = []string{"No blockers or dependents."}
+	default:
+		lines, _ := m.graphLines(contentWidth)
+		body = viewportLines(lines, pane.scroll, maxInt(1, bodyHeight-graphChromeLines))
+	}
+
+	lines := []string{truncateLine(heading, contentWidth), ""}
+	lines = append(lines, body...)
+	for len(lines) < bodyHeight-2 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, "", truncateLine(hint, contentWidth))
+	if len(lines) > bodyHeight {
+		lines = lines[len(lines)-bodyHeight:]
+	}
+	box := m.theme.modalStyle(width).
+		Height(bodyHeight).
+		Render(strings.Join(lines, "\n"))
+	return box
+}
+
+// statusSymbol returns the list-output glyph for an issue status.
+func statusSymbol(status string) string {
+	switch status {
+	case "open":
+		return "○"
+	case "in_progress":
+		return "◐"
+	case "closed":
+		return "✓"
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,252 @@
+package kanban
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// keyAction names one remappable board command; the names are the keys of [kanban.keys].
+type keyAction string
+
+const (
+	keyNextScope     keyAction = "next_scope"
+	keyPreviousScope keyAction = "previous_scope"
+	keyEpicList      keyAction = "epic_list"
+	keyAllEpics      keyAction = "all_epics"
+	keyFilter        keyAction = "filter"
+	keySearch        keyAction = "search"
+	keyEpicDetails   keyAction = "epic_details"
+	keyLeft          keyAction = "left"
+	keyRight         keyAction = "right"
+	keyUp            keyAction = "up"
+	keyDown          keyAction = "down"
+	keyDetails       keyAction = "details"
+	keyGraph         keyAction = "graph"
+	keyNew           keyAction = "new"
+	keyEdit          keyAction = "edit"
+	keyClaim         keyAction = "claim"
+	keyRelease       keyAction = "release"
+	keyClose         keyAction = "close"
+	keyReopen        keyAction = "reopen"
+	keyRaisePriority keyAction = "raise_priority"
+	keyLowerPriority keyAction = "lower_priority"
+	keySwimlanes     keyAction = "swimlanes"
+	keyRefresh       keyAction = "refresh"
+	keyHelp          keyAction = "help"
+	keyQuit          keyAction = "quit"
+)
+
+// reservedKeys always keep their meaning on the board and cannot be rebound.
+var reservedKeys = map[string]string{
+	"ctrl+c": "quit",
+	"esc":    "clear search",
+}
+
+// keyBinding is one board action with its keys and help text.
+type keyBinding struct {
+	action keyAction
+	keys   []string
+	help   string
+}
+
+// defaultBindings lists the board actions in help order.
+var defaultBindings = []keyBinding{
+	{keyNextScope, []string{"tab"}, "next epic scope"},
+	{keyPreviousScope, []string{"shift+tab"}, "previous epic scope"},
+	{keyEpicList, []string{"e"}, "open epic list"},
+	{keyAllEpics, []string{"a"}, "all epics view"},
+	{keyFilter, []string{"f"}, "issue type / label filter"},
+	{keySearch, []string{"/"}, "search titles, descriptions, notes"},
+	{keyEpicDetails, []string{"d"}, "epic details"},
+	{keyLeft, []string{"left", "h"}, "move left"},
+	{keyRight, []string{"right", "l"}, "move right"},
+	{keyUp, []string{"up", "k"}, "move up"},
+	{keyDown, []string{"down", "j"}, "move down"},
+	{keyDetails, []string{"enter"}, "open task details"},
+	{keyGraph, []string{"g"}, "dependency graph of selected task"},
+	{keyNew, []string{"n"}, "new task in this epic"},
+	{keyEdit, []string{"i"}, "edit selected task"},
+	{keyClaim, []string{"c"}, "claim selected task"},
+	{keyRelease, []string{"u"}, "release selected claim"},
+	{keyClose, []string{"x"}, "close selected task"},
+	{keyReopen, []string{"R"}, "reopen selected task"},
+	{keyRaisePriority, []string{"+", "="}, "raise priority"},
+	{keyLowerPriority, []string{"-"}, "lower priority"},
+	{keySwimlanes, []string{"w"}, "cycle swimlanes: none, priority, type, label"},
+	{keyRefresh, []string{"r"}, "refresh now"},
+	{keyHelp, []string{"o"}, "keybinding help"},
+	{keyQuit, []string{"q"}, "quit"},
+}
+
+// Keymap maps key presses on the board to actions.
+type Keymap struct {
+	bindings []keyBinding
+	actions  map[string]keyAction
+}
+
+// DefaultKeymap returns the built-in board bindings.
+func DefaultKeymap() Keymap {
+	keymap, _ := NewKeymap(nil)
+	return keymap
+}
+
+// NewKeymap applies [kanban.keys] overrides to the default bindings. Each
+// override replaces every key of its action, and default bindings give up keys
+// an override takes. Unknown actions, reserved keys and a key claimed by two
+// overrides are rejected.
+func NewKeymap(overrides map[string][]string) (Keymap, error) {
+	known := make(map[keyAction]struct{}, len(defaultBindings))
+	for _, binding := range defaultBindings {
+		known[binding.action] = struct{}{}
+	}
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		if _, ok := known[keyAction(name)]; !ok {
+			return Keymap{}, fmt.Errorf("kanban.keys: unknown action %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	overridden := make(map[keyAction][]string, len(overrides))
+	taken := make(map[string]keyAction)
+	for _, name := range names {
+		action := keyAction(name)
+		keys := make([]string, 0, len(overrides[name]))
+		for _, raw := range overrides[name] {
+			key := normalizeKey(raw)
+			if use, ok := reservedKeys[key]; ok {
+				return Keymap{}, fmt.Errorf("kanban.keys.%s: %q is reserved for %s", name, key, use)
+			}
+			if other, ok := taken[key]; ok && other != action {
+				return Keymap{}, fmt.Errorf("kanban.keys.%s: %q is already bound to %s", name, key, other)
+			}
+			taken[key] = action
+			keys = append(keys, key)
+		}
+		overridden[action] = keys
+	}
+
+	keymap := Keymap{
+		bindings: make([]keyBinding, 0, len(defaultBindings)),
+		actions:  make(map[string]keyAction),
+	}
+	for _, binding := range defaultBindings {
+		if keys, ok := overridden[binding.action]; ok {
+			binding.keys = keys
+		} else {
+			kept := make([]string, 0, len(binding.keys))
+			for _, key := range binding.keys {
+				if _, ok := taken[key]; !ok {
+					kept = append(kept, key)
+				}
+			}
+			binding.keys = kept
+		}
+		for _, key := range binding.keys {
+			keymap.actions[key] = binding.action
+		}
+		keymap.bindings = append(keymap.bindings, binding)
+	}
+	return keymap, nil
+}
+
+// WithKeymap sets the board key bindings.
+func WithKeymap(keymap Keymap) Option {
+	return func(m *Model) { m.keymap = keymap }
+}
+
+// normalizeKey converts a configured key to the form tea.KeyMsg.String reports.
+// Named keys are lowercase; single characters keep their case.
+func normalizeKey(key string) string {
+	key = strings.TrimSpace(key)
+	if len([]rune(key)) <= 1 {
+		return key
+	}
+	key = strings.ToLower(key)
+	if key == "space" {
+		return " "
+	}
+	return key
+}
+
+// action returns the board action bound to a key press, or "" when unbound.
+func (k Keymap) action(key string) keyAction {
+	return k.actions[key]
+}
+
+// keys returns the keys bound to an action.
+func (k Keymap) keys(action keyAction) []string {
+	for _, binding := range k.bindings {
+		if binding.action == action {
+			return binding.keys
+		}
+	}
+	return nil
+}
+
+// label returns every key of an action for the help view, e.g. "+ / =".
+func (k Keymap) label(action keyAction) string {
+	keys := k.keys(action)
+	if len(keys) == 0 {
+		return "unbound"
+	}
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, keyLabel(key))
+	}
+	return strings.Join(labels, " / ")
+}
+
+// short returns the first key of an action for the footer.
+func (k Keymap) short(action keyAction) string {
+	keys := k.keys(action)
+	if len(keys) == 0 {
+		return "-"
+	}
+	return keyLabel(keys[0])
+}
+
+// moveLabel names the movement keys in the footer: "arrows" while the arrow
+// keys lead each binding, the first key of each direction otherwise.
+func (k Keymap) moveLabel() string {
+	directions := []keyAction{keyLeft, keyDown, keyUp, keyRight}
+	arrows := true
+	labels := make([]string, 0, len(directions))
+	for _, direction := range directions {
+		keys := k.keys(direction)
+		arrows = arrows && len(keys) > 0 && keys[0] == string(direction)
+		labels = append(labels, k.short(direction))
+	}
+	if arrows {
+		return "arrows"
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyLabel renders a tea key string for display, e.g. "shift+tab" as "Shift+Tab".
+func keyLabel(key string) string {
+	switch key {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	if len([]rune(key)) <= 1 {
+		return key
+	}
+	parts := strings.Split(key, "+")
+	for i, part := range parts {
+		if len([]rune(part)) > 1 || i < len(parts)-1 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
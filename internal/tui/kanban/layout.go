@@ -0,0 +1,312 @@
+package kanban
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// noLabelLane is the swimlane key for issues without labels.
+const noLabelLane = "\x00none"
+
+// boardColumn is one visible column of the board.
+type boardColumn struct {
+	key    string
+	issues []model.Issue
+}
+
+// swimlane is one horizontal band of the board. Without swimlanes the board
+// is a single untitled lane.
+type swimlane struct {
+	key     string
+	title   string
+	columns []boardColumn
+}
+
+// laneKey identifies a swimlane; order sorts lanes before their keys do.
+type laneKey struct {
+	key   string
+	title string
+	order int
+}
+
+// cardPosition locates a card on the board.
+type cardPosition struct {
+	lane int
+	col  int
+	row  int
+}
+
+// WithLayout sets the board columns, in display order, and the swimlane grouping.
+// Unknown column names are ignored; an empty list keeps the default columns.
+func WithLayout(columns []string, swimlanes string) Option {
+	return func(m *Model) {
+		known := make([]string, 0, len(columns))
+		for _, column := range columns {
+			if _, ok := columnTitles[column]; ok {
+				known = append(known, column)
+			}
+		}
+		if len(known) > 0 {
+			m.columns = known
+		}
+		m.swimlanes = swimlanes
+	}
+}
+
+// columnTitles maps column keys to their header titles.
+var columnTitles = map[string]string{
+	model.KanbanColumnTodo:    "TO DO",
+	model.KanbanColumnBlocked: "BLOCKED",
+	model.KanbanColumnReady:   "READY",
+	model.KanbanColumnClaimed: "CLAIMED",
+	model.KanbanColumnDone:    "DONE",
+}
+
+// withDependencies records dependency edges and marks open issues that still
+// wait on an open blocker.
+func withDependencies(catalog Catalog, edges []model.Dependency) Catalog {
+	status := make(map[string]string, len(catalog.Issues))
+	for _, issue := range catalog.Issues {
+		status[issue.ID] = issue.Status
+	}
+	catalog.Dependencies = edges
+	catalog.Blocked = make(map[string]struct{})
+	for _, edge := range edges {
+		blockerStatus, ok := status[edge.DependsOnID]
+		if ok && blockerStatus != "closed" {
+			catalog.Blocked[edge.IssueID] = struct{}{}
+		}
+	}
+	return catalog
+}
+
+// columnKey returns the board column an issue falls in. Open issues go to
+// BLOCKED or READY when those columns are shown, and to TO DO otherwise.
+func (m Model) columnKey(issue model.Issue) string {
+	switch scopeColumnForStatus(issue.Status) {
+	case "closed":
+		return model.KanbanColumnDone
+	case "in_progress":
+		return model.KanbanColumnClaimed
+	}
+	_, blocked := m.catalog.Blocked[issue.ID]
+	switch {
+	case blocked && m.showsColumn(model.KanbanColumnBlocked):
+		return model.KanbanColumnBlocked
+	case !blocked && m.showsColumn(model.KanbanColumnReady):
+		return model.KanbanColumnReady
+	}
+	return model.KanbanColumnTodo
+}
+
+// showsColumn reports whether a column key is part of the configured board.
+func (m Model) showsColumn(key string) bool {
+	for _, column := range m.columns {
+		if column == key {
+			return true
+		}
+	}
+	return false
+}
+
+// boardColumns splits filtered scope issues into the configured board columns,
+// newest first, with DONE ordered by close time.
+func (m Model) boardColumns(issues []model.Issue) []boardColumn {
+	grouped := make(map[string][]model.Issue, len(columnTitles))
+	for _, issue := range issues {
+		key := m.columnKey(issue)
+		grouped[key] = append(grouped[key], issue)
+	}
+	board := make([]boardColumn, 0, len(m.columns))
+	for _, key := range m.columns {
+		column := grouped[key]
+		if key == model.KanbanColumnDone {
+			sortClosedIssuesNewestFirst(column)
+		} else {
+			sortIssuesNewestFirst(column)
+		}
+		board = append(board, boardColumn{key: key, issues: column})
+	}
+	return board
+}
+
+// lanes returns the swimlanes of the current scope, skipping empty ones.
+// There is always at least one lane so the board can render its columns.
+func (m Model) lanes() []swimlane {
+	columns := m.boardColumns(m.currentIssues())
+	if !m.hasSwimlanes() {
+		return []swimlane{{columns: columns}}
+	}
+
+	keys := make(map[string]laneKey)
+	for _, column := range columns {
+		for _, issue := range column.issues {
+			for _, lane := range m.issueLanes(issue) {
+				keys[lane.key] = lane
+			}
+		}
+	}
+	ordered := make([]laneKey, 0, len(keys))
+	for _, lane := range keys {
+		ordered = append(ordered, lane)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].order != ordered[j].order {
+			return ordered[i].order < ordered[j].order
+		}
+		return ordered[i].key < ordered[j].key
+	})
+
+	lanes := make([]swimlane, 0, len(ordered))
+	for _, key := range ordered {
+		lane := swimlane{key: key.key, title: key.title, columns: make([]boardColumn, 0, len(columns))}
+		for _, column := range columns {
+			filtered := boardColumn{key: column.key}
+			for _, issue := range column.issues {
+				for _, candidate := range m.issueLanes(issue) {
+					if candidate.key == key.key {
+						filtered.issues = append(filtered.issues, issue)
+						break
+					}
+				}
+			}
+			lane.columns = append(lane.columns, filtered)
+		}
+		lanes = append(lanes, lane)
+	}
+	if len(lanes) == 0 {
+		return []swimlane{{columns: columns}}
+	}
+	return lanes
+}
+
+// issueLanes returns the swimlanes an issue belongs to; a card with several
+// labels appears in each label's lane.
+func (m Model) issueLanes(issue model.Issue) []laneKey {
+	switch m.swimlanes {
+	case model.KanbanSwimlanesPriority:
+		return []laneKey{{key: fmt.Sprintf("P%d", issue.Priority), title: fmt.Sprintf("P%d", issue.Priority), order: issue.Priority}}
+	case model.KanbanSwimlanesType:
+		return []laneKey{{key: issue.Type, title: issue.Type}}
+	case model.KanbanSwimlanesLabel:
+		if len(issue.Labels) == 0 {
+			return []laneKey{{key: noLabelLane, title: "No label", order: 1}}
+		}
+		lanes := make([]laneKey, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			lanes = append(lanes, laneKey{key: label, title: "#" + label})
+		}
+		return lanes
+	}
+	return nil
+}
+
+// hasSwimlanes reports whether the board is split into horizontal lanes.
+func (m Model) hasSwimlanes() bool {
+	return m.swimlanes != "" && m.swimlanes != model.KanbanSwimlanesNone
+}
+
+// currentLane returns the lane holding the selection.
+func (m Model) currentLane() swimlane {
+	lanes := m.lanes()
+	if m.selectedLane < 0 || m.selectedLane >= len(lanes) {
+		return lanes[0]
+	}
+	return lanes[m.selectedLane]
+}
+
+// cycleSwimlanes switches to the next swimlane grouping.
+func (m *Model) cycleSwimlanes() {
+	current := 0
+	for i, grouping := range model.KanbanSwimlanes {
+		if grouping == m.swimlanes {
+			current = i
+		}
+	}
+	m.swimlanes = model.KanbanSwimlanes[(current+1)%len(model.KanbanSwimlanes)]
+	m.selectedLane = 0
+	m.laneScroll = 0
+	m.selectedRow = 0
+	m.scrollRow = 0
+	m.ensureSelection()
+}
+
+// moveLane steps the selection into the nearest lane in direction step whose
+// selected column has cards, entering at the edge next to the old lane.
+func (m *Model) moveLane(step int) bool {
+	lanes := m.lanes()
+	for index := m.selectedLane + step; index >= 0 && index < len(lanes); index += step {
+		if m.selectedCol >= len(lanes[index].columns) {
+			return false
+		}
+		issues := lanes[index].columns[m.selectedCol].issues
+		if len(issues) == 0 {
+			continue
+		}
+		m.selectedLane = index
+		m.selectedRow = 0
+		if step < 0 {
+			m.selectedRow = len(issues) - 1
+		}
+		m.scrollRow = 0
+		m.adjustScroll()
+		return true
+	}
+	return false
+}
+
+// laneHeight returns the lines a swimlane takes: its title bar plus the
+// tallest column, capped at the visible rows.
+func (m Model) laneHeight(lane swimlane) int {
+	rows := 1
+	for _, column := range lane.columns {
+		rows = maxInt(rows, len(column.issues))
+	}
+	return laneTitleLines + minInt(rows, maxInt(1, m.visibleRows()))*cardOuterHeight
+}
+
+// ensureLaneVisible scrolls the lanes so the selected one fits below the column headers.
+func (m *Model) ensureLaneVisible() {
+	if !m.hasSwimlanes() {
+		m.laneScroll = 0
+		return
+	}
+	lanes := m.lanes()
+	if m.selectedLane < m.laneScroll {
+		m.laneScroll = m.selectedLane
+	}
+	budget := m.boardHeightBudget() - columnHeaderLines
+	for m.laneScroll < m.selectedLane {
+		used := 0
+		for index := m.laneScroll; index <= m.selectedLane && index < len(lanes); index++ {
+			used += m.laneHeight(lanes[index])
+		}
+		if used <= budget {
+			break
+		}
+		m.laneScroll++
+	}
+}
+
+// renderLaneTitle draws the bar above one swimlane with its card count and position.
+func (m Model) renderLaneTitle(lane swimlane, index, total, width int) string {
+	cards := make(map[string]struct{})
+	for _, column := range lane.columns {
+		for _, issue := range column.issues {
+			cards[issue.ID] = struct{}{}
+		}
+	}
+	title := fmt.Sprintf("%s (%d)  lane %d/%d", lane.title, len(cards), index+1, total)
+	style := lipgloss.NewStyle().
+		Width(maxInt(1, width)).
+		Padding(0, 1).
+		Foreground(m.theme.laneText).
+		Background(m.theme.laneBackground)
+	if index == m.selectedLane {
+		style = style.Bold(true).Foreground(m.theme.laneSelected)
+	}
+	return style.Render(truncateLine(title, maxInt(1, width-2)))
+}
@@ -3,11 +3,12 @@ package kanban
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/fsnotify/fsnotify"
 	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/service"
 )
 
 const (
@@ -17,6 +18,7 @@ const (
 	maxPickerRows       = 13
 	headerLines         = 2
 	columnHeaderLines   = 1
+	laneTitleLines      = 1
 	cardOuterHeight     = 7
 	modalFrameHeight    = 4
 	modalScrollStep     = 5
@@ -27,6 +29,7 @@ var typeFilterOptions = []string{"all", "task", "bug", "feature", "chore", "deci
 
 type catalogLoadedMsg struct {
 	catalog Catalog
+	seq     int64
 	err     error
 }
 
@@ -37,21 +40,52 @@ type detailsLoadedMsg struct {
 	err     error
 }
 
-type dbChangedMsg struct{}
-type watchErrMsg struct{ err error }
+// searchLoadedMsg carries the issue IDs matching a full-text board search.
+type searchLoadedMsg struct {
+	query   string
+	matches map[string]struct{}
+	err     error
+}
+
+// changesLoadedMsg carries one change-log poll and the refreshed issues it touched.
+// reload asks for a full catalog load when the events cannot be applied in place;
+// rewound reports a change log that moved back, as after `faz restore`.
+type changesLoadedMsg struct {
+	seq     int64
+	issues  []model.Issue
+	touched map[string]struct{}
+	reload  bool
+	rewound bool
+	err     error
+}
+
+// detailsCommentLimit caps how many work-log notes the detail modal loads.
+const detailsCommentLimit = 5
 
-// issueDetails holds dependency context for the kanban detail modal.
+// issueDetails holds dependency and note context for the kanban detail modal.
 type issueDetails struct {
 	Dependencies []model.Issue
 	Dependents   []model.Issue
+	Comments     []model.Comment
 	Loading      bool
 	Err          error
 }
 
-// Model manages the read-only kanban TUI state.
+// Model manages the kanban TUI state.
 type Model struct {
-	svc     Service
-	watcher *fsnotify.Watcher
+	svc        Service
+	live       bool
+	watching   bool
+	changeSeq  int64
+	claimLease time.Duration
+
+	hookWarnings <-chan string
+
+	defaultType     string
+	defaultPriority int
+
+	columns   []string
+	swimlanes string
 
 	width  int
 	height int
@@ -60,19 +94,29 @@ type Model struct {
 	catalog Catalog
 	err     error
 
-	scopeIndex  int
-	selectedCol int
-	selectedRow int
-	scrollRow   int
+	scopeIndex   int
+	selectedLane int
+	selectedCol  int
+	selectedRow  int
+	scrollRow    int
+	laneScroll   int
 
 	showPicker   bool
 	pickerIndex  int
 	pickerScroll int
 	showHelp     bool
+	helpScroll   int
 	showEpic     bool
 	showType     bool
 	typeIndex    int
 	typeFilter   string
+	labelFilter  string
+
+	showSearch    bool
+	searchInput   string
+	searchQuery   string
+	searchMatches map[string]struct{}
+	searchErr     error
 
 	showDetails      bool
 	inspectedIssueID string
@@ -80,6 +124,15 @@ type Model struct {
 	details          map[string]issueDetails
 	detailsScroll    int
 	epicScroll       int
+
+	confirm  *pendingAction
+	form     *issueForm
+	graph    *graphPane
+	toast    *toast
+	toastSeq int
+
+	theme  Theme
+	keymap Keymap
 }
 
 // Option configures a kanban Model at construction time.
@@ -90,23 +143,34 @@ func WithPicker() Option {
 	return func(m *Model) { m.showPicker = true }
 }
 
-// WithWatcher enables event-driven refresh via a filesystem watcher.
-func WithWatcher(w *fsnotify.Watcher) Option {
-	return func(m *Model) { m.watcher = w }
+// WithLiveUpdates keeps the board current by following the change log.
+func WithLiveUpdates() Option {
+	return func(m *Model) { m.live = true }
 }
 
 // NewModel builds a new kanban TUI model.
 func NewModel(svc Service, opts ...Option) Model {
-	m := Model{svc: svc, typeFilter: typeFilterOptions[0]}
+	defaults := service.DefaultSettings()
+	m := Model{
+		svc:             svc,
+		typeFilter:      typeFilterOptions[0],
+		claimLease:      defaults.DefaultClaimTTL,
+		defaultType:     defaults.DefaultType,
+		defaultPriority: defaults.DefaultPriority,
+		columns:         model.DefaultKanbanColumns,
+		swimlanes:       model.KanbanSwimlanesNone,
+		theme:           DefaultTheme(),
+		keymap:          DefaultKeymap(),
+	}
 	for _, o := range opts {
 		o(&m)
 	}
 	return m
 }
 
-// Init starts the first data load and listens for database changes.
+// Init starts the first data load; change-log polling begins once it lands.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadCatalogCmd(), m.watchCmd())
+	return tea.Batch(m.loadCatalogCmd(), m.waitForHookWarning())
 }
 
 // Update handles input, resizing, refreshes, and modal state transitions.
@@ -124,6 +188,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.err = nil
 		m.catalog = msg.catalog
+		m.changeSeq = max(m.changeSeq, msg.seq)
 		if m.scopeIndex >= len(m.catalog.Scopes) {
 			m.scopeIndex = 0
 		}
@@ -132,6 +197,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.ensureSelection()
 		}
+		if m.live && !m.watching {
+			m.watching = true
+			return m, m.watchCmd()
+		}
 		return m, nil
 
 	case detailsLoadedMsg:
@@ -145,14 +214,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.details[msg.issueID] = msg.details
 		return m, nil
 
-	case dbChangedMsg:
-		return m, tea.Batch(m.loadCatalogCmd(), m.watchCmd())
+	case searchLoadedMsg:
+		if msg.query != m.searchQuery {
+			return m, nil
+		}
+		m.searchErr = msg.err
+		m.searchMatches = msg.matches
+		m.selectedLane = 0
+		m.selectedRow = 0
+		m.scrollRow = 0
+		m.laneScroll = 0
+		m.ensureSelection()
+		return m, nil
+
+	case changesLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.watchCmd()
+		}
+		if msg.rewound {
+			m.changeSeq = msg.seq
+			m.details = nil
+			return m, tea.Batch(m.loadCatalogCmd(), m.searchCmd(m.searchQuery), m.watchCmd())
+		}
+		if len(msg.touched) == 0 {
+			return m, m.watchCmd()
+		}
+		m.changeSeq = max(m.changeSeq, msg.seq)
+		if msg.reload {
+			m.details = nil
+			var details tea.Cmd
+			if m.showDetails {
+				details = m.prepareDetailsForIssue(m.inspectedIssueID)
+			}
+			return m, tea.Batch(m.loadCatalogCmd(), details, m.searchCmd(m.searchQuery), m.refreshGraph(msg.touched), m.watchCmd())
+		}
+		return m, tea.Batch(m.applyChanges(msg), m.searchCmd(m.searchQuery), m.refreshGraph(msg.touched), m.watchCmd())
+
+	case actionDoneMsg:
+		return m, m.finishAction(msg)
+
+	case formSavedMsg:
+		return m, m.finishForm(msg)
+
+	case graphLoadedMsg:
+		m.finishGraph(msg)
+		return m, nil
+
+	case toastExpiredMsg:
+		if m.toast != nil && m.toast.seq == msg.seq {
+			m.toast = nil
+		}
+		return m, nil
+
+	case hookWarningMsg:
+		return m, tea.Batch(m.showToast("Hook failed: "+msg.text, true), m.waitForHookWarning())
 
-	case watchErrMsg:
-		m.err = msg.err
-		return m, m.watchCmd()
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
 
 	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.confirm != nil {
+			return m.updateConfirm(msg)
+		}
+		if m.graph != nil {
+			return m.updateGraph(msg)
+		}
 		if m.showDetails {
 			switch msg.String() {
 			case "enter", "esc", "q":
@@ -183,6 +313,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.showSearch {
+			return m.updateSearch(msg)
+		}
 		if m.showType {
 			return m.updateTypePicker(msg)
 		}
@@ -194,60 +327,87 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch msg.String() {
-		case "q", "ctrl+c":
+		case "ctrl+c":
 			return m, tea.Quit
-		case "tab":
-			m.cycleScope(1)
-			return m, nil
-		case "shift+tab":
-			m.cycleScope(-1)
-			return m, nil
-		case "a":
-			m.selectScopeByKey(scopeAll)
-			return m, nil
-		case "e":
-			m.showPicker = true
-			m.pickerIndex = 0
-			m.pickerScroll = 0
-			return m, nil
-		case "o":
-			m.showHelp = true
-			return m, nil
-		case "d":
-			m.showEpic = true
-			m.epicScroll = 0
-			return m, nil
-		case "f":
-			m.showType = true
-			m.typeIndex = 0
-			return m, nil
-		case "left", "h":
-			m.moveCol(-1)
-			return m, nil
-		case "right", "l":
-			m.moveCol(1)
-			return m, nil
-		case "up", "k":
-			m.moveRow(-1)
-			return m, nil
-		case "down", "j":
-			m.moveRow(1)
-			return m, nil
-		case "enter":
-			if issue := m.currentIssue(); issue != nil {
-				m.setInspectedIssue(*issue)
-				m.showDetails = true
-				return m, m.prepareDetailsForIssue(issue.ID)
+		case "esc":
+			if m.searchQuery != "" {
+				m.clearSearch()
 			}
 			return m, nil
-		case "r":
-			return m, m.loadCatalogCmd()
 		}
+		return m.runBoardAction(m.keymap.action(msg.String()))
 	}
 
 	return m, nil
 }
 
+// runBoardAction performs the board command bound to a key press or mouse gesture.
+func (m Model) runBoardAction(action keyAction) (tea.Model, tea.Cmd) {
+	switch action {
+	case keyQuit:
+		return m, tea.Quit
+	case keyNextScope:
+		m.cycleScope(1)
+	case keyPreviousScope:
+		m.cycleScope(-1)
+	case keyAllEpics:
+		m.selectScopeByKey(scopeAll)
+	case keyEpicList:
+		m.showPicker = true
+		m.pickerIndex = 0
+		m.pickerScroll = 0
+	case keyHelp:
+		m.showHelp = true
+		m.helpScroll = 0
+	case keyEpicDetails:
+		m.showEpic = true
+		m.epicScroll = 0
+	case keyFilter:
+		m.showType = true
+		m.typeIndex = 0
+	case keySearch:
+		m.showSearch = true
+		m.searchInput = m.searchQuery
+	case keyLeft:
+		m.moveCol(-1)
+	case keyRight:
+		m.moveCol(1)
+	case keyUp:
+		m.moveRow(-1)
+	case keyDown:
+		m.moveRow(1)
+	case keyDetails:
+		if issue := m.currentIssue(); issue != nil {
+			m.setInspectedIssue(*issue)
+			m.showDetails = true
+			return m, m.prepareDetailsForIssue(issue.ID)
+		}
+	case keyRefresh:
+		return m, m.loadCatalogCmd()
+	case keySwimlanes:
+		m.cycleSwimlanes()
+	case keyClaim:
+		return m, m.requestAction(actionClaim)
+	case keyRelease:
+		return m, m.requestAction(actionRelease)
+	case keyClose:
+		return m, m.requestAction(actionClose)
+	case keyReopen:
+		return m, m.requestAction(actionReopen)
+	case keyGraph:
+		return m, m.openGraph()
+	case keyNew:
+		m.openCreateForm()
+	case keyEdit:
+		m.openEditForm()
+	case keyRaisePriority:
+		return m, m.requestAction(actionPriorityUp)
+	case keyLowerPriority:
+		return m, m.requestAction(actionPriorityDown)
+	}
+	return m, nil
+}
+
 // View renders the kanban layout, modals, and help footer.
 func (m Model) View() string {
 	if !m.ready {
@@ -267,6 +427,15 @@ func (m Model) View() string {
 		m.renderFooter(),
 	)
 
+	if m.form != nil {
+		return m.overlay(content, m.renderForm())
+	}
+	if m.confirm != nil {
+		return m.overlay(content, m.renderConfirm())
+	}
+	if m.graph != nil {
+		return m.overlay(content, m.renderGraph())
+	}
 	if m.showPicker {
 		return m.overlay(content, m.renderPicker())
 	}
@@ -276,6 +445,9 @@ func (m Model) View() string {
 	if m.showType {
 		return m.overlay(content, m.renderTypePicker())
 	}
+	if m.showSearch {
+		return m.overlay(content, m.renderSearch())
+	}
 	if m.showEpic {
 		return m.overlay(content, m.renderEpicDetails())
 	}
@@ -319,9 +491,11 @@ func (m Model) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		m.scopeIndex = m.pickerIndex
 		m.showPicker = false
+		m.selectedLane = 0
 		m.selectedCol = 0
 		m.selectedRow = 0
 		m.scrollRow = 0
+		m.laneScroll = 0
 		m.ensureSelection()
 		return m, nil
 	case "a":
@@ -337,12 +511,17 @@ func (m Model) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "esc", "enter", "o":
-		m.showHelp = false
-		return m, nil
-	case "ctrl+c":
+	key := msg.String()
+	switch {
+	case key == "ctrl+c":
 		return m, tea.Quit
+	case key == "q" || key == "esc" || key == "enter" || m.keymap.action(key) == keyHelp:
+		m.showHelp = false
+		m.helpScroll = 0
+	case key == "up" || key == "k":
+		m.scrollHelp(-modalScrollStep)
+	case key == "down" || key == "j":
+		m.scrollHelp(modalScrollStep)
 	}
 	return m, nil
 }
@@ -360,30 +539,87 @@ func (m Model) updateTypePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "down", "j":
-		if m.typeIndex < len(typeFilterOptions)-1 {
+		if m.typeIndex < m.filterOptionCount()-1 {
 			m.typeIndex++
 		}
 		return m, nil
 	case "enter":
-		m.typeFilter = typeFilterOptions[m.typeIndex]
+		if m.typeIndex < len(typeFilterOptions) {
+			m.typeFilter = typeFilterOptions[m.typeIndex]
+		} else if labelIndex := m.typeIndex - len(typeFilterOptions); labelIndex < len(m.catalog.Labels) {
+			m.labelFilter = m.catalog.Labels[labelIndex]
+		}
 		m.showType = false
+		m.selectedLane = 0
 		m.selectedCol = 0
 		m.selectedRow = 0
 		m.scrollRow = 0
+		m.laneScroll = 0
 		m.ensureSelection()
 		return m, nil
 	case "a":
 		m.typeFilter = typeFilterOptions[0]
+		m.labelFilter = ""
 		m.showType = false
+		m.selectedLane = 0
 		m.selectedCol = 0
 		m.selectedRow = 0
 		m.scrollRow = 0
+		m.laneScroll = 0
 		m.ensureSelection()
 		return m, nil
 	}
 	return m, nil
 }
 
+// updateSearch edits the search box; Enter runs the query and an empty query clears it.
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		m.showSearch = false
+		return m, nil
+	case tea.KeyEnter:
+		m.showSearch = false
+		query := strings.TrimSpace(m.searchInput)
+		if query == "" {
+			m.clearSearch()
+			return m, nil
+		}
+		m.searchQuery = query
+		return m, m.searchCmd(query)
+	case tea.KeyBackspace:
+		if runes := []rune(m.searchInput); len(runes) > 0 {
+			m.searchInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+	case tea.KeyCtrlU:
+		m.searchInput = ""
+		return m, nil
+	case tea.KeySpace:
+		m.searchInput += " "
+		return m, nil
+	case tea.KeyRunes:
+		m.searchInput += string(msg.Runes)
+		return m, nil
+	}
+	return m, nil
+}
+
+// clearSearch drops the active board search.
+func (m *Model) clearSearch() {
+	m.searchInput = ""
+	m.searchQuery = ""
+	m.searchMatches = nil
+	m.searchErr = nil
+	m.selectedLane = 0
+	m.selectedRow = 0
+	m.scrollRow = 0
+	m.laneScroll = 0
+	m.ensureSelection()
+}
+
 // applyWindowSize records usable dimensions and ignores transient invalid resize events.
 func (m *Model) applyWindowSize(width, height int) {
 	if width <= 0 || height <= 0 {
@@ -406,9 +642,11 @@ func (m *Model) cycleScope(step int) {
 		return
 	}
 	m.scopeIndex = (m.scopeIndex + step + len(m.catalog.Scopes)) % len(m.catalog.Scopes)
+	m.selectedLane = 0
 	m.selectedCol = 0
 	m.selectedRow = 0
 	m.scrollRow = 0
+	m.laneScroll = 0
 	m.ensureSelection()
 }
 
@@ -418,9 +656,11 @@ func (m *Model) selectScopeByKey(key string) {
 			continue
 		}
 		m.scopeIndex = i
+		m.selectedLane = 0
 		m.selectedCol = 0
 		m.selectedRow = 0
 		m.scrollRow = 0
+		m.laneScroll = 0
 		m.ensureSelection()
 		return
 	}
@@ -428,15 +668,20 @@ func (m *Model) selectScopeByKey(key string) {
 
 func (m *Model) moveCol(delta int) {
 	next := m.selectedCol + delta
-	if next < 0 || next > 2 {
+	if next < 0 || next >= len(m.columns) {
 		return
 	}
 	m.selectedCol = next
 	m.ensureSelection()
 }
 
+// moveRow moves within the selected column, continuing into the next swimlane
+// past either end.
 func (m *Model) moveRow(delta int) {
 	column := m.currentColumn()
+	if next := m.selectedRow + delta; (next < 0 || next >= len(column)) && m.moveLane(delta) {
+		return
+	}
 	if len(column) == 0 {
 		m.selectedRow = 0
 		m.scrollRow = 0
@@ -454,10 +699,17 @@ func (m *Model) moveRow(delta int) {
 }
 
 func (m *Model) ensureSelection() {
+	if lanes := m.lanes(); m.selectedLane >= len(lanes) {
+		m.selectedLane = len(lanes) - 1
+	}
+	if m.selectedCol >= len(m.columns) {
+		m.selectedCol = len(m.columns) - 1
+	}
 	column := m.currentColumn()
 	if len(column) == 0 {
 		m.selectedRow = 0
 		m.scrollRow = 0
+		m.ensureLaneVisible()
 		return
 	}
 	if m.selectedRow >= len(column) {
@@ -484,10 +736,17 @@ func (m *Model) adjustScroll() {
 	if m.scrollRow < 0 {
 		m.scrollRow = 0
 	}
+	m.ensureLaneVisible()
 }
 
+// visibleRows returns how many cards fit in one column, or in one swimlane
+// when the board is split into lanes.
 func (m Model) visibleRows() int {
-	rows := (m.boardHeightBudget() - columnHeaderLines) / cardOuterHeight
+	budget := m.boardHeightBudget() - columnHeaderLines
+	if m.hasSwimlanes() {
+		budget -= laneTitleLines
+	}
+	rows := budget / cardOuterHeight
 	if rows < 1 {
 		return 0
 	}
@@ -501,33 +760,36 @@ func (m Model) currentScope() Scope {
 	return m.catalog.Scopes[m.scopeIndex]
 }
 
-func (m Model) currentColumns() scopeColumns {
-	scope := m.currentScope()
-	return m.applyTypeFilter(m.catalog.Columns[scope.Key])
+// currentIssues returns the tasks of the current scope that pass the search, label and type filters.
+func (m Model) currentIssues() []model.Issue {
+	return m.applyTypeFilter(m.catalog.ScopeIssues[m.currentScope().Key])
 }
 
-func (m Model) applyTypeFilter(columns scopeColumns) scopeColumns {
+func (m Model) applyTypeFilter(issues []model.Issue) []model.Issue {
+	if m.searchQuery != "" {
+		issues = filterBySearch(issues, m.searchMatches)
+	}
+	if m.labelFilter != "" {
+		issues = filterByLabel(issues, m.labelFilter)
+	}
 	selected := strings.TrimSpace(strings.ToLower(m.typeFilter))
 	if selected == "" || selected == typeFilterOptions[0] {
-		return columns
-	}
-	return scopeColumns{
-		Todo:    filterByType(columns.Todo, selected),
-		Claimed: filterByType(columns.Claimed, selected),
-		Done:    filterByType(columns.Done, selected),
+		return issues
 	}
+	return filterByType(issues, selected)
+}
+
+// filterOptionCount returns the number of type plus label entries in the filter picker.
+func (m Model) filterOptionCount() int {
+	return len(typeFilterOptions) + len(m.catalog.Labels)
 }
 
 func (m Model) currentColumn() []model.Issue {
-	columns := m.currentColumns()
-	switch m.selectedCol {
-	case 1:
-		return columns.Claimed
-	case 2:
-		return columns.Done
-	default:
-		return columns.Todo
+	columns := m.currentLane().columns
+	if m.selectedCol < 0 || m.selectedCol >= len(columns) {
+		return nil
 	}
+	return columns[m.selectedCol].issues
 }
 
 func (m Model) currentIssue() *model.Issue {
@@ -562,65 +824,63 @@ func (m Model) inspectedIssueRef() *model.Issue {
 	return nil
 }
 
-// inspectedColumnIndex reports the active modal column for the inspected issue.
-func (m Model) inspectedColumnIndex() int {
-	if col, _, ok := m.locateIssueInCurrentScope(m.inspectedIssueID); ok {
-		return col
+// inspectedColumnKey reports the board column of the inspected issue.
+func (m Model) inspectedColumnKey() string {
+	if pos, ok := m.locateIssueInCurrentScope(m.inspectedIssueID); ok {
+		return m.lanes()[pos.lane].columns[pos.col].key
 	}
-	issue := m.inspectedIssueRef()
-	if issue == nil {
-		return m.selectedCol
+	if issue := m.inspectedIssueRef(); issue != nil {
+		return m.columnKey(*issue)
 	}
-	switch issue.Status {
-	case "in_progress":
-		return 1
-	case "closed":
-		return 2
-	default:
-		return 0
+	if m.selectedCol >= 0 && m.selectedCol < len(m.columns) {
+		return m.columns[m.selectedCol]
 	}
+	return model.KanbanColumnTodo
 }
 
 // inspectedColumnTitle returns the board column title for the inspected issue.
 func (m Model) inspectedColumnTitle() string {
-	switch m.inspectedColumnIndex() {
-	case 1:
-		return "CLAIMED"
-	case 2:
-		return "DONE"
-	default:
-		return "TO DO"
-	}
+	return columnTitles[m.inspectedColumnKey()]
 }
 
-// locateIssueInCurrentScope returns the issue position in the currently visible board scope.
-func (m Model) locateIssueInCurrentScope(issueID string) (int, int, bool) {
+// locateIssueInCurrentScope returns the issue position in the currently visible
+// board scope, preferring the selected swimlane when a card appears in several.
+func (m Model) locateIssueInCurrentScope(issueID string) (cardPosition, bool) {
 	if issueID == "" {
-		return 0, 0, false
+		return cardPosition{}, false
 	}
-	columns := m.currentColumns()
-	if row, ok := findIssueIndex(columns.Todo, issueID); ok {
-		return 0, row, true
+	lanes := m.lanes()
+	order := make([]int, 0, len(lanes))
+	if m.selectedLane < len(lanes) {
+		order = append(order, m.selectedLane)
 	}
-	if row, ok := findIssueIndex(columns.Claimed, issueID); ok {
-		return 1, row, true
+	for i := range lanes {
+		if i != m.selectedLane {
+			order = append(order, i)
+		}
 	}
-	if row, ok := findIssueIndex(columns.Done, issueID); ok {
-		return 2, row, true
+	for _, lane := range order {
+		for col, column := range lanes[lane].columns {
+			if row, ok := findIssueIndex(column.issues, issueID); ok {
+				return cardPosition{lane: lane, col: col, row: row}, true
+			}
+		}
 	}
-	return 0, 0, false
+	return cardPosition{}, false
+}
+
+// selectPosition moves the cursor to a located card.
+func (m *Model) selectPosition(pos cardPosition) {
+	m.selectedLane = pos.lane
+	m.selectedCol = pos.col
+	m.selectedRow = pos.row
+	m.adjustScroll()
 }
 
 // findIssueByID locates an issue across the current catalog snapshot.
 func (m Model) findIssueByID(issueID string) *model.Issue {
-	for _, columns := range m.catalog.Columns {
-		if issue := findIssueInColumn(columns.Todo, issueID); issue != nil {
-			return issue
-		}
-		if issue := findIssueInColumn(columns.Claimed, issueID); issue != nil {
-			return issue
-		}
-		if issue := findIssueInColumn(columns.Done, issueID); issue != nil {
+	for _, issues := range m.catalog.ScopeIssues {
+		if issue := findIssueInColumn(issues, issueID); issue != nil {
 			return issue
 		}
 	}
@@ -629,8 +889,12 @@ func (m Model) findIssueByID(issueID string) *model.Issue {
 
 func (m Model) loadCatalogCmd() tea.Cmd {
 	return func() tea.Msg {
+		seq, err := m.svc.LatestChange()
+		if err != nil {
+			return catalogLoadedMsg{err: err}
+		}
 		catalog, err := LoadCatalog(m.svc)
-		return catalogLoadedMsg{catalog: catalog, err: err}
+		return catalogLoadedMsg{catalog: catalog, seq: seq, err: err}
 	}
 }
 
@@ -644,11 +908,16 @@ func (m Model) loadDetailsCmd(issueID string) tea.Cmd {
 		if err != nil {
 			return detailsLoadedMsg{issueID: issueID, err: err}
 		}
+		comments, err := m.svc.Comments(issueID, detailsCommentLimit)
+		if err != nil {
+			return detailsLoadedMsg{issueID: issueID, err: err}
+		}
 		return detailsLoadedMsg{
 			issueID: issueID,
 			details: issueDetails{
 				Dependencies: dependencies,
 				Dependents:   dependents,
+				Comments:     comments,
 			},
 		}
 	}
@@ -660,7 +929,7 @@ func (m *Model) prepareDetailsForIssue(issueID string) tea.Cmd {
 		m.details = make(map[string]issueDetails)
 	}
 	if details, ok := m.details[issueID]; ok {
-		if details.Loading || details.Err != nil || details.Dependencies != nil || details.Dependents != nil {
+		if details.Loading || details.Err != nil || details.Dependencies != nil || details.Dependents != nil || details.Comments != nil {
 			return nil
 		}
 	}
@@ -684,34 +953,117 @@ func (m *Model) clearInspectedIssue() {
 
 // syncSelectionToInspectedIssue keeps board cursor state aligned with the modal target when possible.
 func (m *Model) syncSelectionToInspectedIssue() {
-	col, row, ok := m.locateIssueInCurrentScope(m.inspectedIssueID)
+	pos, ok := m.locateIssueInCurrentScope(m.inspectedIssueID)
 	if !ok {
 		m.ensureSelection()
 		return
 	}
-	m.selectedCol = col
-	m.selectedRow = row
-	m.adjustScroll()
+	m.selectPosition(pos)
 }
 
-func (m Model) watchCmd() tea.Cmd {
-	if m.watcher == nil {
+// searchCmd runs a full-text query over every issue, including closed ones for the DONE column.
+func (m Model) searchCmd(query string) tea.Cmd {
+	if query == "" {
 		return nil
 	}
 	return func() tea.Msg {
-		select {
-		case _, ok := <-m.watcher.Events:
-			if !ok {
-				return nil
+		results, err := m.svc.Search(query, model.ListFilter{All: true}, 0)
+		if err != nil {
+			return searchLoadedMsg{query: query, err: err}
+		}
+		matches := make(map[string]struct{}, len(results))
+		for _, result := range results {
+			matches[result.Issue.ID] = struct{}{}
+		}
+		return searchLoadedMsg{query: query, matches: matches}
+	}
+}
+
+// watchCmd polls the change log after the current sequence and refetches only the touched issues.
+func (m Model) watchCmd() tea.Cmd {
+	if !m.live {
+		return nil
+	}
+	after := m.changeSeq
+	return tea.Tick(model.ChangePollInterval, func(time.Time) tea.Msg {
+		events, err := m.svc.Changes(after, model.ChangeBatchSize)
+		if err != nil {
+			return changesLoadedMsg{err: err}
+		}
+		if len(events) == 0 {
+			if latest, err := m.svc.LatestChange(); err == nil && latest < after {
+				// A restore rewound the change log; reload and follow it from its new end.
+				return changesLoadedMsg{seq: latest, rewound: true}
+			}
+		}
+		msg := changesLoadedMsg{seq: after, touched: make(map[string]struct{})}
+		for _, event := range events {
+			msg.seq = event.ID
+			msg.touched[event.IssueID] = struct{}{}
+			switch event.Action {
+			case model.EventDependencyAdded, model.EventDependencyRemoved:
+				// The blocker's dependents changed too.
+				for _, other := range []*string{event.OldValue, event.NewValue} {
+					if other != nil && *other != "" {
+						msg.touched[*other] = struct{}{}
+					}
+				}
+				msg.reload = true
+			case model.EventDeleted:
+				// Deletions change more than the touched issue.
+				msg.reload = true
+			}
+		}
+		if msg.reload {
+			return msg
+		}
+		for issueID := range msg.touched {
+			issue, err := m.svc.Get(issueID)
+			if err != nil {
+				return changesLoadedMsg{seq: msg.seq, touched: msg.touched, reload: true}
 			}
-			return dbChangedMsg{}
-		case err, ok := <-m.watcher.Errors:
-			if !ok {
-				return nil
+			msg.issues = append(msg.issues, issue)
+		}
+		return msg
+	})
+}
+
+// rebuildCatalog regroups the board from issues, keeping the loaded dependency edges.
+func (m *Model) rebuildCatalog(issues []model.Issue) {
+	m.catalog = withDependencies(buildCatalog(issues), m.catalog.Dependencies)
+}
+
+// applyChanges patches refetched issues into the board and drops stale detail caches.
+func (m *Model) applyChanges(msg changesLoadedMsg) tea.Cmd {
+	selected := m.currentIssue()
+	m.rebuildCatalog(mergeIssues(m.catalog.Issues, msg.issues))
+	if m.scopeIndex >= len(m.catalog.Scopes) {
+		m.scopeIndex = 0
+	}
+	for issueID := range msg.touched {
+		delete(m.details, issueID)
+	}
+
+	if m.showDetails {
+		for _, issue := range msg.issues {
+			if issue.ID == m.inspectedIssueID {
+				m.inspectedIssue = issue
 			}
-			return watchErrMsg{err: err}
+		}
+		m.syncSelectionToInspectedIssue()
+		if _, ok := msg.touched[m.inspectedIssueID]; ok {
+			return m.prepareDetailsForIssue(m.inspectedIssueID)
+		}
+		return nil
+	}
+	if selected != nil {
+		if pos, ok := m.locateIssueInCurrentScope(selected.ID); ok {
+			m.selectPosition(pos)
+			return nil
 		}
 	}
+	m.ensureSelection()
+	return nil
 }
 
 func (m Model) renderHeader() string {
@@ -721,17 +1073,36 @@ func (m Model) renderHeader() string {
 		Width(maxInt(1, m.width)).
 		Padding(0, 1).
 		Bold(true).
-		Foreground(lipgloss.Color("230")).
-		Background(lipgloss.Color("24"))
+		Foreground(m.theme.headerText).
+		Background(m.theme.headerBackground)
 
 	subtitleStyle := lipgloss.NewStyle().
 		Width(maxInt(1, m.width)).
 		Padding(0, 1).
-		Foreground(lipgloss.Color("245"))
+		Foreground(m.theme.subtitle)
 
 	title := "faz kanban"
 	scopeTitle := "Epic: " + scope.Title
 	subtitle := fmt.Sprintf("Live refresh. Type: %s.", strings.ToUpper(m.typeFilter))
+	if m.labelFilter != "" {
+		subtitle = fmt.Sprintf("%s Label: %s.", subtitle, m.labelFilter)
+	}
+	if m.hasSwimlanes() {
+		subtitle = fmt.Sprintf("%s Lanes: %s.", subtitle, m.swimlanes)
+	}
+	switch {
+	case m.toast != nil:
+		subtitle = m.toast.text
+		if m.toast.isErr {
+			subtitleStyle = subtitleStyle.Bold(true).Foreground(m.theme.errorText)
+		} else {
+			subtitleStyle = subtitleStyle.Foreground(m.theme.successText)
+		}
+	case m.searchErr != nil:
+		subtitle = fmt.Sprintf("%s Search %q failed: %v.", subtitle, m.searchQuery, m.searchErr)
+	case m.searchQuery != "":
+		subtitle = fmt.Sprintf("%s Search: %q (%d matches, Esc clears).", subtitle, m.searchQuery, len(m.searchMatches))
+	}
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		headerStyle.Render(truncateLine(fmt.Sprintf("%s  |  %s", title, scopeTitle), contentWidth)),
@@ -740,32 +1111,81 @@ func (m Model) renderHeader() string {
 }
 
 func (m Model) renderBoard() string {
-	columns := m.currentColumns()
 	if m.visibleRows() == 0 {
 		return m.renderCompactBoardNotice()
 	}
-	widths, gap := boardColumnLayout(m.width)
-	todo := m.renderColumn("TO DO", columns.Todo, 0, widths[0], lipgloss.Color("178"))
-	claimed := m.renderColumn("CLAIMED", columns.Claimed, 1, widths[1], lipgloss.Color("39"))
-	done := m.renderColumn("DONE", columns.Done, 2, widths[2], lipgloss.Color("71"))
+	widths, gap := boardColumnLayout(m.width, len(m.columns))
+	spacer := strings.Repeat(" ", gap)
+	if !m.hasSwimlanes() {
+		lane := m.currentLane()
+		blocks := make([]string, 0, 2*len(lane.columns))
+		for i, column := range lane.columns {
+			if i > 0 {
+				blocks = append(blocks, spacer)
+			}
+			blocks = append(blocks, lipgloss.JoinVertical(
+				lipgloss.Left,
+				m.renderColumnHeader(column.key, len(column.issues), widths[i]),
+				m.renderColumnBody(column.issues, 0, i, widths[i], false),
+			))
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Top, blocks...)
+		return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, row)
+	}
 
-	row := lipgloss.JoinHorizontal(lipgloss.Top, todo, strings.Repeat(" ", gap), claimed, strings.Repeat(" ", gap), done)
-	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, row)
+	// Column headers count the whole scope; each lane below shows its own slice.
+	headers := make([]string, 0, 2*len(m.columns))
+	for i, column := range m.boardColumns(m.currentIssues()) {
+		if i > 0 {
+			headers = append(headers, spacer)
+		}
+		headers = append(headers, m.renderColumnHeader(column.key, len(column.issues), widths[i]))
+	}
+	headerRow := lipgloss.JoinHorizontal(lipgloss.Top, headers...)
+	rowWidth := lipgloss.Width(headerRow)
+	rows := []string{headerRow}
+
+	lanes := m.lanes()
+	budget := m.boardHeightBudget() - columnHeaderLines
+	for index := m.laneScroll; index < len(lanes); index++ {
+		lane := lanes[index]
+		height := m.laneHeight(lane)
+		if height > budget {
+			break
+		}
+		budget -= height
+		rows = append(rows, m.renderLaneTitle(lane, index, len(lanes), rowWidth))
+		bodies := make([]string, 0, 2*len(lane.columns))
+		for i, column := range lane.columns {
+			if i > 0 {
+				bodies = append(bodies, spacer)
+			}
+			bodies = append(bodies, m.renderColumnBody(column.issues, index, i, widths[i], true))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, bodies...))
+	}
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, lipgloss.JoinVertical(lipgloss.Left, rows...))
 }
 
-func (m Model) renderColumn(title string, issues []model.Issue, colIndex, width int, accent lipgloss.Color) string {
+// renderColumnHeader draws the colored title bar of one board column.
+func (m Model) renderColumnHeader(key string, count, width int) string {
 	headerWidth := maxInt(1, width-2)
-	header := lipgloss.NewStyle().
+	return lipgloss.NewStyle().
 		Width(maxInt(1, width)).
 		Bold(true).
 		Padding(0, 1).
-		Foreground(lipgloss.Color("230")).
-		Background(accent).
-		Render(truncateLine(fmt.Sprintf("%s (%d)", title, len(issues)), headerWidth))
+		Foreground(m.theme.columnText).
+		Background(m.theme.columnAccent(key)).
+		Render(truncateLine(fmt.Sprintf("%s (%d)", columnTitles[key], count), headerWidth))
+}
 
+// renderColumnBody draws the visible cards of one column. Inside a swimlane an
+// empty column stays blank instead of showing a placeholder card.
+func (m Model) renderColumnBody(issues []model.Issue, laneIndex, colIndex, width int, inLane bool) string {
 	rowsVisible := m.visibleRows()
+	selectedColumn := laneIndex == m.selectedLane && colIndex == m.selectedCol
 	start := 0
-	if colIndex == m.selectedCol {
+	if selectedColumn {
 		start = m.scrollRow
 	}
 	if start > len(issues) {
@@ -776,33 +1196,35 @@ func (m Model) renderColumn(title string, issues []model.Issue, colIndex, width
 		end = len(issues)
 	}
 
-	body := make([]string, 0, rowsVisible)
 	if len(issues) == 0 {
+		if inLane {
+			return lipgloss.NewStyle().Width(maxInt(1, width)).Render("")
+		}
 		empty := lipgloss.NewStyle().
 			Width(maxInt(1, width-2)).
 			Height(5).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderForeground(m.theme.cardBorder).
 			Padding(1, 1).
-			Foreground(lipgloss.Color("244")).
+			Foreground(m.theme.emptyText).
 			Render("No tasks")
-		body = append(body, lipgloss.PlaceHorizontal(width, lipgloss.Center, empty))
-	} else {
-		for rowIndex := start; rowIndex < end; rowIndex++ {
-			body = append(body, m.renderCard(issues[rowIndex], colIndex == m.selectedCol && rowIndex == m.selectedRow, width))
-		}
+		return lipgloss.PlaceHorizontal(width, lipgloss.Center, empty)
+	}
+	body := make([]string, 0, end-start)
+	for rowIndex := start; rowIndex < end; rowIndex++ {
+		body = append(body, m.renderCard(issues[rowIndex], selectedColumn && rowIndex == m.selectedRow, width))
 	}
-	return lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, body...)...)
+	return lipgloss.JoinVertical(lipgloss.Left, body...)
 }
 
 func (m Model) renderCard(issue model.Issue, selected bool, width int) string {
-	borderColor := lipgloss.Color("238")
-	bgColor := lipgloss.Color("235")
-	titleColor := lipgloss.Color("252")
+	borderColor := m.theme.cardBorder
+	bgColor := m.theme.cardBackground
+	titleColor := m.theme.cardTitle
 	if selected {
-		borderColor = lipgloss.Color("246")
-		bgColor = lipgloss.Color("240")
-		titleColor = lipgloss.Color("230")
+		borderColor = m.theme.selectedBorder
+		bgColor = m.theme.selectedBackground
+		titleColor = m.theme.selectedTitle
 	}
 
 	meta := fmt.Sprintf("P%d • %s", issue.Priority, issue.Type)
@@ -829,16 +1251,20 @@ func (m Model) renderCard(issue model.Issue, selected bool, width int) string {
 		MaxWidth(contentWidth).
 		Background(bgColor)
 	titleStyle := lineStyle.Foreground(titleColor).Bold(selected)
-	metaColor := lipgloss.Color("243")
+	metaColor := m.theme.cardMeta
 	if selected {
-		metaColor = lipgloss.Color("251")
+		metaColor = m.theme.selectedMeta
 	}
 	metaStyle := lineStyle.Foreground(metaColor)
 
+	owner := ""
+	if issue.Status == "in_progress" && issue.ClaimedBy != nil {
+		owner = truncateLine("@"+*issue.ClaimedBy, contentWidth)
+	}
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		titleStyle.Render(title),
-		lineStyle.Render(""),
+		metaStyle.Render(owner),
 		metaStyle.Render(truncateLine(meta, contentWidth)),
 	)
 	return lipgloss.PlaceHorizontal(width, lipgloss.Center, cardStyle.Render(content))
@@ -854,7 +1280,7 @@ func (m Model) renderFooter() string {
 	return lipgloss.NewStyle().
 		Width(maxInt(1, m.width)).
 		Padding(0, 1).
-		Foreground(lipgloss.Color("244")).
+		Foreground(m.theme.footer).
 		Render(strings.Join(rendered, "\n"))
 }
 
@@ -877,7 +1303,7 @@ func (m Model) renderUndersized() string {
 func (m Model) renderCompactBoardNotice() string {
 	return lipgloss.NewStyle().
 		Width(maxInt(1, m.width)).
-		Foreground(lipgloss.Color("244")).
+		Foreground(m.theme.footer).
 		Render(truncateLine("Window height too small for kanban cards.", maxInt(1, m.width)))
 }
 
@@ -900,13 +1326,7 @@ func (m Model) renderPicker() string {
 		lines = append(lines, prefix+scope.Title)
 	}
 	lines = append(lines, "", "Enter select • Esc close • a all")
-	box := lipgloss.NewStyle().
-		Width(width).
-		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("69")).
-		Padding(1, 2).
-		Background(lipgloss.Color("235")).
-		Render(strings.Join(lines, "\n"))
+	box := m.theme.modalStyle(width).Render(strings.Join(lines, "\n"))
 	return box
 }
 
@@ -945,43 +1365,58 @@ func (m *Model) ensurePickerVisible() {
 }
 
 func (m Model) renderHelp() string {
-	width := minInt(72, maxInt(minModalWidth, m.width-10))
-	lines := []string{
-		"Keybindings",
-		"",
-		"Board:",
-		"  Tab / Shift+Tab  cycle epic scope",
-		"  e                open epic list",
-		"  a                all epics view",
-		"  f                issue type filter",
-		"  d                epic details",
-		"  arrows / h j k l move selection",
-		"  Enter            open task details",
-		"  r                refresh now",
-		"  o                keybinding help",
-		"  q                quit",
+	width, _, bodyHeight := m.modalDimensions(false)
+	wrapped := m.wrapModalLines(m.helpLines(), m.modalContentWidth(width))
+	scroll := clampScrollOffset(m.helpScroll, len(wrapped), bodyHeight)
+	visible := viewportLines(wrapped, scroll, bodyHeight)
+	return m.theme.modalStyle(width).Render(strings.Join(visible, "\n"))
+}
+
+// helpLines builds the keybinding reference; the board section follows the keymap.
+func (m Model) helpLines() []string {
+	lines := []string{"Keybindings", "", "Board:"}
+	for _, binding := range m.keymap.bindings {
+		lines = append(lines, fmt.Sprintf("  %-16s %s", m.keymap.label(binding.action), binding.help))
+	}
+	return append(lines,
+		"  Esc              clear search",
+		"  click / wheel    select a card (click again for details) / move selection",
 		"",
 		"Task details modal:",
 		"  up/down          move within current column",
 		"  left/right       move to adjacent column",
 		"  Enter / Esc / q  close details",
 		"",
+		"Claim, release, close and reopen ask y/n first.",
+		"",
+		"Dependency graph:",
+		"  up / down        follow edge to blocker / dependent",
+		"  left/right       move within a layer",
+		"  Enter            jump board to issue",
+		"  Esc / q / g      close graph",
+		"",
+		"New / edit form:",
+		"  Tab / Shift+Tab  next / previous field",
+		"  left/right       change type, priority, epic",
+		"  Ctrl+S           save",
+		"  Esc              cancel",
+		"",
 		"Epic picker:",
 		"  up/down          move",
 		"  Enter            select",
 		"  a                all epics view",
 		"  Esc / q          close picker",
 		"",
-		"Enter, Esc, q, or o closes this view.",
-	}
-	box := lipgloss.NewStyle().
-		Width(width).
-		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("69")).
-		Padding(1, 2).
-		Background(lipgloss.Color("235")).
-		Render(strings.Join(lines, "\n"))
-	return box
+		"The mouse wheel scrolls modals and pickers.",
+		fmt.Sprintf("Up/down scroll. Enter, Esc, q, or %s closes this view.", m.keymap.label(keyHelp)),
+	)
+}
+
+// scrollHelp scrolls the keybinding reference when it overflows the viewport.
+func (m *Model) scrollHelp(delta int) {
+	width, _, bodyHeight := m.modalDimensions(false)
+	lines := m.wrapModalLines(m.helpLines(), m.modalContentWidth(width))
+	m.helpScroll = clampScrollOffset(m.helpScroll+delta, len(lines), bodyHeight)
 }
 
 func (m Model) renderTypePicker() string {
@@ -994,14 +1429,37 @@ func (m Model) renderTypePicker() string {
 		}
 		lines = append(lines, prefix+strings.ToUpper(issueType))
 	}
+	if len(m.catalog.Labels) > 0 {
+		lines = append(lines, "", "Labels")
+		start, end := labelPickerWindow(m.typeIndex-len(typeFilterOptions), len(m.catalog.Labels), maxPickerRows)
+		for i := start; i < end; i++ {
+			prefix := "  "
+			if i+len(typeFilterOptions) == m.typeIndex {
+				prefix = "> "
+			}
+			label := "#" + m.catalog.Labels[i]
+			if m.catalog.Labels[i] == m.labelFilter {
+				label += " (active)"
+			}
+			lines = append(lines, prefix+label)
+		}
+	}
 	lines = append(lines, "", "Enter select • Esc close • a all")
-	box := lipgloss.NewStyle().
-		Width(width).
-		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("69")).
-		Padding(1, 2).
-		Background(lipgloss.Color("235")).
-		Render(strings.Join(lines, "\n"))
+	box := m.theme.modalStyle(width).Render(strings.Join(lines, "\n"))
+	return box
+}
+
+// renderSearch draws the full-text search input box.
+func (m Model) renderSearch() string {
+	width := minInt(60, maxInt(minModalWidth, m.width-10))
+	lines := []string{
+		"Search titles, descriptions and notes",
+		"",
+		"/ " + m.searchInput + "▏",
+		"",
+		"Enter search • Esc cancel • empty Enter clears",
+	}
+	box := m.theme.modalStyle(width).Render(strings.Join(lines, "\n"))
 	return box
 }
 
@@ -1016,19 +1474,14 @@ func (m Model) renderDetails() string {
 	columnLabel := lipgloss.NewStyle().
 		Padding(0, 2).
 		Bold(true).
-		Foreground(lipgloss.Color("230")).
-		Background(lipgloss.Color("60")).
+		Foreground(m.theme.labelText).
+		Background(m.theme.labelBackground).
 		Render(m.inspectedColumnTitle())
 	lines := m.wrapModalLines(m.detailsModalLines(*issue, details), contentWidth)
 	scroll := clampScrollOffset(m.detailsScroll, len(lines), bodyHeight)
 	visible := viewportLines(lines, scroll, bodyHeight)
-	box := lipgloss.NewStyle().
-		Width(width).
+	box := m.theme.modalStyle(width).
 		Height(bodyHeight).
-		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("69")).
-		Padding(1, 2).
-		Background(lipgloss.Color("235")).
 		Render(strings.Join(visible, "\n"))
 	if boxHeight < modalFrameHeight {
 		return lipgloss.JoinVertical(lipgloss.Center, columnLabel, "", box)
@@ -1046,10 +1499,10 @@ func (m Model) renderEpicDetails() string {
 		Width(width).
 		Height(bodyHeight).
 		Border(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("33")).
+		BorderForeground(m.theme.epicBorder).
 		Padding(1, 2).
-		Background(lipgloss.Color("24")).
-		Foreground(lipgloss.Color("230")).
+		Background(m.theme.epicBackground).
+		Foreground(m.theme.epicText).
 		Render(strings.Join(visible, "\n"))
 	return box
 }
@@ -1068,10 +1521,14 @@ func (m Model) detailsModalLines(issue model.Issue, details issueDetails) []stri
 		fmt.Sprintf("Priority: P%d", issue.Priority),
 		fmt.Sprintf("Status: %s", issue.Status),
 		fmt.Sprintf("Epic: %s", parentTitle),
-		"",
-		issue.Description,
-		"",
 	}
+	if len(issue.Labels) > 0 {
+		lines = append(lines, fmt.Sprintf("Labels: %s", strings.Join(issue.Labels, ", ")))
+	}
+	if issue.Status == "in_progress" && issue.ClaimedBy != nil {
+		lines = append(lines, fmt.Sprintf("Claimed by: %s", *issue.ClaimedBy))
+	}
+	lines = append(lines, "", issue.Description, "")
 	switch {
 	case details.Loading:
 		lines = append(lines, "Loading dependencies...")
@@ -1081,6 +1538,12 @@ func (m Model) detailsModalLines(issue model.Issue, details issueDetails) []stri
 		lines = append(lines, m.renderIssueLinks("Blocked by", details.Dependencies)...)
 		lines = append(lines, "")
 		lines = append(lines, m.renderIssueLinks("Blocks", details.Dependents)...)
+		if len(details.Comments) > 0 {
+			lines = append(lines, "", "Notes:")
+			for _, comment := range details.Comments {
+				lines = append(lines, fmt.Sprintf("  [%s] %s: %s", comment.CreatedAt.Format("2006-01-02 15:04"), comment.Author, comment.Body))
+			}
+		}
 	}
 	lines = append(lines, "", "Up/down scroll 5 lines when available. Left/right moves across columns. Enter, Esc, or q closes this view.")
 	return lines
@@ -1313,18 +1776,23 @@ func (m Model) footerLineCount() int {
 	return len(lines)
 }
 
-func boardColumnLayout(totalWidth int) ([3]int, int) {
+// boardColumnLayout splits the terminal width into count columns and the gap between them.
+func boardColumnLayout(totalWidth, count int) ([]int, int) {
+	count = maxInt(1, count)
 	gap := 2
 	if totalWidth < 72 {
 		gap = 1
 	}
-	available := totalWidth - 2*gap
-	if available < 3 {
-		available = 3
+	available := totalWidth - (count-1)*gap
+	if available < count {
+		available = count
+	}
+	base := available / count
+	remainder := available % count
+	widths := make([]int, count)
+	for i := range widths {
+		widths[i] = base
 	}
-	base := available / 3
-	remainder := available % 3
-	widths := [3]int{base, base, base}
 	for i := 0; i < remainder; i++ {
 		widths[i]++
 	}
@@ -1336,39 +1804,78 @@ func boardColumnLayout(totalWidth int) ([3]int, int) {
 
 // footerLines builds a responsive footer and reports whether condensed mode is active.
 func (m Model) footerLines(width int) ([]string, bool) {
+	keys := m.keymap
 	fullItems := []string{
-		"Tab next epic",
-		"Shift+Tab previous",
-		"e epic list",
-		"a all",
-		"f type filter",
-		"d epic details",
-		"arrows move",
-		"Enter details",
-		"r refresh",
-		"q quit",
+		keys.short(keyNextScope) + " next epic",
+		keys.short(keyPreviousScope) + " previous",
+		keys.short(keyEpicList) + " epic list",
+		keys.short(keyAllEpics) + " all",
+		keys.short(keyFilter) + " type filter",
+		keys.short(keyEpicDetails) + " epic details",
+		keys.moveLabel() + " move",
+		keys.short(keyDetails) + " details",
+		keys.short(keyRefresh) + " refresh",
+		keys.short(keyQuit) + " quit",
 	}
 	full, ok := wrapFooterItems(fullItems, width, 2)
 	if ok {
 		return full, false
 	}
 	condensedItems := []string{
-		"Tab",
-		"e",
-		"a",
-		"f",
-		"d",
-		"arrows",
-		"Enter " + string(rune(0x23CE)),
-		"q",
-		"o all keys",
+		keys.short(keyNextScope),
+		keys.short(keyEpicList),
+		keys.short(keyAllEpics),
+		keys.short(keyFilter),
+		keys.short(keyEpicDetails),
+		keys.moveLabel(),
+		keys.short(keyDetails) + " " + string(rune(0x23CE)),
+		keys.short(keyQuit),
+		keys.short(keyHelp) + " all keys",
 	}
 	condensed, ok := wrapFooterItems(condensedItems, width, 2)
 	if ok {
 		return condensed, true
 	}
 	// At extreme widths keep core controls visible in one safe line.
-	return []string{truncateLine("Tab • e • a • q • o", width)}, true
+	core := []string{keys.short(keyNextScope), keys.short(keyEpicList), keys.short(keyAllEpics), keys.short(keyQuit), keys.short(keyHelp)}
+	return []string{truncateLine(strings.Join(core, footerGap), width)}, true
+}
+
+// labelPickerWindow returns the visible label range that keeps the selected label on screen.
+func labelPickerWindow(selected, total, height int) (int, int) {
+	if total <= height {
+		return 0, total
+	}
+	start := 0
+	if selected >= height {
+		start = selected - height + 1
+	}
+	return start, minInt(total, start+height)
+}
+
+// filterByLabel keeps only issues carrying one label.
+func filterByLabel(issues []model.Issue, label string) []model.Issue {
+	filtered := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		for _, candidate := range issue.Labels {
+			if candidate == label {
+				filtered = append(filtered, issue)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// filterBySearch keeps only issues found by the active full-text search.
+func filterBySearch(issues []model.Issue, matches map[string]struct{}) []model.Issue {
+	filtered := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if _, ok := matches[issue.ID]; ok {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
 }
 
 // filterByType keeps only issues matching one issue type.
@@ -2,6 +2,7 @@ package kanban
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
 	"github.com/rpcarvs/faz/internal/model"
+	"github.com/rpcarvs/faz/internal/repo"
 )
 
 // stubService supplies deterministic kanban reads for tests.
@@ -17,8 +19,12 @@ type stubService struct {
 	issues        []model.Issue
 	dependencies  map[string][]model.Issue
 	dependents    map[string][]model.Issue
+	comments      map[string][]model.Comment
+	events        []model.IssueEvent
 	dependencyErr error
 	dependentErr  error
+	actionErr     error
+	actions       *[]string
 }
 
 // List returns the configured issue set.
@@ -26,6 +32,16 @@ func (s stubService) List(filter model.ListFilter) ([]model.Issue, error) {
 	return s.issues, nil
 }
 
+// Get returns the configured issue with the given ID.
+func (s stubService) Get(publicID string) (model.Issue, error) {
+	for _, issue := range s.issues {
+		if issue.ID == publicID {
+			return issue, nil
+		}
+	}
+	return model.Issue{}, fmt.Errorf("issue %q not found", publicID)
+}
+
 // Dependencies returns the configured blockers for an issue.
 func (s stubService) Dependencies(publicID string) ([]model.Issue, error) {
 	if s.dependencyErr != nil {
@@ -42,6 +58,129 @@ func (s stubService) Dependents(publicID string) ([]model.Issue, error) {
 	return s.dependents[publicID], nil
 }
 
+// DependencyEdges derives edges from the configured blockers.
+func (s stubService) DependencyEdges() ([]model.Dependency, error) {
+	var edges []model.Dependency
+	for issueID, blockers := range s.dependencies {
+		for _, blocker := range blockers {
+			edges = append(edges, model.Dependency{IssueID: issueID, DependsOnID: blocker.ID})
+		}
+	}
+	return edges, nil
+}
+
+// Comments returns the configured work-log notes for an issue.
+func (s stubService) Comments(publicID string, limit int) ([]model.Comment, error) {
+	return s.comments[publicID], nil
+}
+
+// Search returns issues whose title contains the query, ignoring case.
+func (s stubService) Search(query string, filter model.ListFilter, limit int) ([]model.SearchResult, error) {
+	results := make([]model.SearchResult, 0)
+	for _, issue := range s.issues {
+		if strings.Contains(strings.ToLower(issue.Title), strings.ToLower(query)) {
+			results = append(results, model.SearchResult{Issue: issue})
+		}
+	}
+	return results, nil
+}
+
+// Changes returns configured change-log events after the given sequence.
+func (s stubService) Changes(after int64, limit int) ([]model.IssueEvent, error) {
+	events := make([]model.IssueEvent, 0)
+	for _, event := range s.events {
+		if event.ID > after {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// LatestChange returns the newest configured change-log sequence.
+func (s stubService) LatestChange() (int64, error) {
+	if len(s.events) == 0 {
+		return 0, nil
+	}
+	return s.events[len(s.events)-1].ID, nil
+}
+
+// Claim records the call and marks the stored issue claimed unless actionErr is set.
+func (s stubService) Claim(publicID string, lease time.Duration) error {
+	return s.apply("claim "+publicID, publicID, func(issue *model.Issue) {
+		owner := "board"
+		issue.Status = "in_progress"
+		issue.ClaimedBy = &owner
+	})
+}
+
+// Release records the call and reopens the stored issue unless actionErr is set.
+func (s stubService) Release(publicID string) error {
+	return s.apply("release "+publicID, publicID, func(issue *model.Issue) {
+		issue.Status = "open"
+		issue.ClaimedBy = nil
+	})
+}
+
+// Close records the call and closes the stored issue unless actionErr is set.
+func (s stubService) Close(publicID string) error {
+	return s.apply("close "+publicID, publicID, func(issue *model.Issue) {
+		issue.Status = "closed"
+	})
+}
+
+// Reopen records the call and reopens the stored issue unless actionErr is set.
+func (s stubService) Reopen(publicID string) error {
+	return s.apply("reopen "+publicID, publicID, func(issue *model.Issue) {
+		issue.Status = "open"
+	})
+}
+
+// Update records the call and applies a priority change unless actionErr is set.
+func (s stubService) Update(publicID string, fields map[string]any) error {
+	return s.apply(fmt.Sprintf("update %s %v", publicID, fields), publicID, func(issue *model.Issue) {
+		if priority, ok := fields["priority"].(int); ok {
+			issue.Priority = priority
+		}
+	})
+}
+
+// Create records the call and rejects a blank title the way IssueService does.
+func (s stubService) Create(issue model.Issue) (string, error) {
+	parent := "none"
+	if issue.ParentID != nil {
+		parent = *issue.ParentID
+	}
+	if s.actions != nil {
+		*s.actions = append(*s.actions, fmt.Sprintf("create %q %s P%d parent=%s desc=%q", issue.Title, issue.Type, issue.Priority, parent, issue.Description))
+	}
+	if strings.TrimSpace(issue.Title) == "" {
+		return "", fmt.Errorf("title is required")
+	}
+	return "proj-n000", nil
+}
+
+// Types returns the built-in issue types.
+func (s stubService) Types() []string {
+	return []string{"bug", "chore", "decision", "epic", "feature", "task"}
+}
+
+// apply records one action call and edits the shared issue slice in place.
+func (s stubService) apply(call, publicID string, edit func(*model.Issue)) error {
+	if s.actions != nil {
+		*s.actions = append(*s.actions, call)
+	}
+	if s.actionErr != nil {
+		return s.actionErr
+	}
+	for i := range s.issues {
+		if s.issues[i].ID == publicID {
+			edit(&s.issues[i])
+			return nil
+		}
+	}
+	return fmt.Errorf("issue %q not found", publicID)
+}
+
 func TestModelLoadDetailsCmdLoadsDependenciesAndDependents(t *testing.T) {
 	now := time.Now()
 	issueID := "proj-e1.0"
@@ -111,6 +250,11 @@ func TestRenderDetailsShowsDependenciesAndDependents(t *testing.T) {
 				{ID: parentID + ".2", Title: "First dependent", CreatedAt: now, UpdatedAt: now},
 			},
 		},
+		comments: map[string][]model.Comment{
+			issueID: []model.Comment{
+				{ID: 1, IssueID: issueID, Author: "agent-a", Body: "Tried the cache fix", CreatedAt: now},
+			},
+		},
 	}
 
 	catalog, err := LoadCatalog(svc)
@@ -148,6 +292,9 @@ func TestRenderDetailsShowsDependenciesAndDependents(t *testing.T) {
 	if !strings.Contains(view, "First dependent") {
 		t.Fatalf("expected dependent title in details view: %s", view)
 	}
+	if !strings.Contains(view, "agent-a: Tried the cache fix") {
+		t.Fatalf("expected work-log note in details view: %s", view)
+	}
 }
 
 func TestRenderDetailsShowsLoadingStateBeforeDependencyFetchCompletes(t *testing.T) {
@@ -206,10 +353,15 @@ func TestRenderDetailsShowsLoadingStateBeforeDependencyFetchCompletes(t *testing
 }
 
 func TestBoardColumnLayoutFitsWithinAvailableWidth(t *testing.T) {
-	widths, gap := boardColumnLayout(60)
-	total := widths[0] + widths[1] + widths[2] + 2*gap
-	if total > 60 {
-		t.Fatalf("expected board width to fit in 60 columns, got %d", total)
+	for count := 1; count <= len(model.KanbanColumns); count++ {
+		widths, gap := boardColumnLayout(60, count)
+		total := (count - 1) * gap
+		for _, width := range widths {
+			total += width
+		}
+		if len(widths) != count || total > 60 {
+			t.Fatalf("expected %d columns to fit in 60 columns, got %d columns totalling %d", count, len(widths), total)
+		}
 	}
 }
 
@@ -283,6 +435,25 @@ func TestRenderCardSelectedStylesTextRowsWithSelectedBackground(t *testing.T) {
 	assertMarkerStyleContains(t, card, "P1", "48;5;240")
 }
 
+func TestRenderCardShowsClaimOwner(t *testing.T) {
+	now := time.Now()
+	owner := "agent-7"
+	issue := model.Issue{
+		ID:        "proj-e1.0",
+		Title:     "Claimed task",
+		Type:      "task",
+		Priority:  1,
+		Status:    "in_progress",
+		ClaimedBy: &owner,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	card := NewModel(stubService{}).renderCard(issue, false, 32)
+	if !strings.Contains(card, "@agent-7") {
+		t.Fatalf("expected claim owner on card: %s", card)
+	}
+}
+
 func TestApplyWindowSizeIgnoresTransientZeroDimensions(t *testing.T) {
 	model := NewModel(stubService{})
 
@@ -624,15 +795,52 @@ func TestTypePickerEnterAppliesTypeFilterToBoardColumns(t *testing.T) {
 		t.Fatal("expected no command when selecting type filter")
 	}
 
-	columns := model.currentColumns()
-	if len(columns.Todo) != 1 || columns.Todo[0].Type != "bug" {
-		t.Fatalf("expected only bug todo items, got %#v", columns.Todo)
+	columns := boardIssues(model)
+	if len(columns["todo"]) != 1 || columns["todo"][0].Type != "bug" {
+		t.Fatalf("expected only bug todo items, got %#v", columns["todo"])
+	}
+	if len(columns["claimed"]) != 1 || columns["claimed"][0].Type != "bug" {
+		t.Fatalf("expected only bug claimed items, got %#v", columns["claimed"])
+	}
+	if len(columns["done"]) != 1 || columns["done"][0].Type != "bug" {
+		t.Fatalf("expected only bug done items, got %#v", columns["done"])
+	}
+}
+
+func TestTypePickerSelectsLabelFilterAfterTypes(t *testing.T) {
+	now := time.Now()
+	catalog := buildCatalog([]model.Issue{
+		{ID: "proj-a1", Title: "Frontend task", Type: "task", Status: "open", Labels: []string{"frontend"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "proj-a2", Title: "DB task", Type: "task", Status: "open", Labels: []string{"db"}, CreatedAt: now.Add(time.Minute), UpdatedAt: now},
+		{ID: "proj-a3", Title: "Unlabeled", Type: "task", Status: "closed", CreatedAt: now.Add(2 * time.Minute), UpdatedAt: now},
+	})
+	if got := strings.Join(catalog.Labels, ","); got != "db,frontend" {
+		t.Fatalf("catalog labels = %q, want db,frontend", got)
+	}
+
+	model := NewModel(stubService{})
+	model.catalog = catalog
+	model.ready = true
+	model.width = 100
+	model.height = 30
+	model.showType = true
+	model.typeIndex = len(typeFilterOptions) + 1 // frontend
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if model.labelFilter != "frontend" {
+		t.Fatalf("expected label filter frontend, got %q", model.labelFilter)
 	}
-	if len(columns.Claimed) != 1 || columns.Claimed[0].Type != "bug" {
-		t.Fatalf("expected only bug claimed items, got %#v", columns.Claimed)
+	columns := boardIssues(model)
+	if len(columns["todo"]) != 1 || columns["todo"][0].ID != "proj-a1" || len(columns["done"]) != 0 {
+		t.Fatalf("expected only frontend issue, got todo=%#v done=%#v", columns["todo"], columns["done"])
 	}
-	if len(columns.Done) != 1 || columns.Done[0].Type != "bug" {
-		t.Fatalf("expected only bug done items, got %#v", columns.Done)
+
+	model.showType = true
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	model = updated.(Model)
+	if model.labelFilter != "" {
+		t.Fatalf("expected a to clear label filter, got %q", model.labelFilter)
 	}
 }
 
@@ -1486,3 +1694,815 @@ func assertMarkerStyleContains(t *testing.T, rendered, marker, stylePart string)
 		t.Fatalf("expected style before %q to contain %q, got %q", marker, stylePart, style)
 	}
 }
+
+func TestSearchBoxFiltersBoardToMatches(t *testing.T) {
+	now := time.Now()
+	svc := stubService{
+		issues: []model.Issue{
+			{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now},
+			{ID: "proj-b222", Title: "Checkout tax rounding", Type: "task", Status: "open", CreatedAt: now, UpdatedAt: now},
+			{ID: "proj-c333", Title: "Old session bug", Type: "bug", Status: "closed", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	catalog, err := LoadCatalog(svc)
+	if err != nil {
+		t.Fatalf("load catalog: %v", err)
+	}
+
+	model := NewModel(svc)
+	model.catalog = catalog
+	model.ready = true
+	model.width = 120
+	model.height = 40
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	model = updated.(Model)
+	if !model.showSearch {
+		t.Fatal("expected / to open the search box")
+	}
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("sessx")},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("ion")},
+	} {
+		updated, _ = model.Update(key)
+		model = updated.(Model)
+	}
+	if model.searchInput != "session" {
+		t.Fatalf("expected typed query, got %q", model.searchInput)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if cmd == nil || model.showSearch {
+		t.Fatalf("expected enter to close the box and run the search")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+
+	columns := boardIssues(model)
+	if len(columns["todo"]) != 1 || columns["todo"][0].ID != "proj-a111" {
+		t.Fatalf("unexpected TO DO matches: %#v", columns["todo"])
+	}
+	if len(columns["done"]) != 1 || columns["done"][0].ID != "proj-c333" {
+		t.Fatalf("expected closed match in DONE: %#v", columns["done"])
+	}
+	if header := model.renderHeader(); !strings.Contains(header, `Search: "session"`) {
+		t.Fatalf("expected active search in header: %s", header)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	if model.searchQuery != "" || len(boardIssues(model)["todo"]) != 2 {
+		t.Fatalf("expected esc to clear the search, got query %q", model.searchQuery)
+	}
+}
+
+func TestLiveUpdatesPatchOnlyIssuesNamedInChangeLog(t *testing.T) {
+	now := time.Now()
+	claimant := "agent-7"
+	before := []model.Issue{
+		{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now},
+		{ID: "proj-b222", Title: "Checkout tax rounding", Type: "task", Status: "open", CreatedAt: now.Add(time.Minute), UpdatedAt: now},
+	}
+	svc := stubService{
+		issues: []model.Issue{
+			{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "in_progress", ClaimedBy: &claimant, CreatedAt: now, UpdatedAt: now},
+			{ID: "proj-b222", Title: "Renamed but not in the log", Type: "task", Status: "open", CreatedAt: now.Add(time.Minute), UpdatedAt: now},
+			{ID: "proj-c333", Title: "Brand new task", Type: "task", Status: "open", CreatedAt: now.Add(2 * time.Minute), UpdatedAt: now},
+		},
+		events: []model.IssueEvent{
+			{ID: 7, IssueID: "proj-b222", Action: model.EventCreated},
+			{ID: 8, IssueID: "proj-a111", Action: model.EventClaimed},
+			{ID: 9, IssueID: "proj-c333", Action: model.EventCreated},
+		},
+	}
+
+	model := NewModel(svc, WithLiveUpdates())
+	model.ready = true
+	model.width = 120
+	model.height = 40
+	updated, cmd := model.Update(catalogLoadedMsg{catalog: buildCatalog(before), seq: 7})
+	model = updated.(Model)
+	if cmd == nil || !model.watching {
+		t.Fatal("expected the first catalog load to start following the change log")
+	}
+
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if model.changeSeq != 9 {
+		t.Fatalf("expected cursor to advance to 9, got %d", model.changeSeq)
+	}
+	columns := boardIssues(model)
+	if len(columns["claimed"]) != 1 || columns["claimed"][0].ID != "proj-a111" {
+		t.Fatalf("expected claimed issue moved to CLAIMED: %#v", columns["claimed"])
+	}
+	if len(columns["todo"]) != 2 || columns["todo"][0].ID != "proj-c333" || columns["todo"][1].Title != "Checkout tax rounding" {
+		t.Fatalf("expected new issue added and untouched issue kept as loaded: %#v", columns["todo"])
+	}
+}
+
+func TestLiveDependencyChangesTouchBothEndpoints(t *testing.T) {
+	now := time.Now()
+	blocker := "proj-b222"
+	issues := []model.Issue{
+		{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now},
+		{ID: "proj-b222", Title: "Checkout tax rounding", Type: "task", Status: "open", CreatedAt: now.Add(time.Minute), UpdatedAt: now},
+	}
+	svc := stubService{
+		issues: issues,
+		events: []model.IssueEvent{{ID: 8, IssueID: "proj-a111", Action: model.EventDependencyAdded, Field: "depends_on", NewValue: &blocker}},
+	}
+
+	board := NewModel(svc, WithLiveUpdates())
+	board.ready = true
+	board.width = 120
+	board.height = 40
+	board.catalog = buildCatalog(issues)
+	board.changeSeq = 7
+	board.showDetails = true
+	board.setInspectedIssue(issues[1])
+	board.details = map[string]issueDetails{blocker: {Dependents: []model.Issue{}}}
+
+	msg, ok := board.watchCmd()().(changesLoadedMsg)
+	if !ok {
+		t.Fatal("expected a change-log poll result")
+	}
+	if _, touched := msg.touched[blocker]; !touched || !msg.reload {
+		t.Fatalf("expected the blocker touched and a reload, got %+v", msg)
+	}
+
+	updated, cmd := board.Update(msg)
+	board = updated.(Model)
+	if cmd == nil || !board.details[blocker].Loading {
+		t.Fatalf("expected the open blocker details to reload, got %+v", board.details[blocker])
+	}
+}
+
+func TestLiveUpdatesFollowARewoundChangeLog(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now}}
+	svc := stubService{
+		issues: issues,
+		events: []model.IssueEvent{{ID: 3, IssueID: "proj-a111", Action: model.EventCreated}},
+	}
+
+	board := NewModel(svc, WithLiveUpdates())
+	board.ready = true
+	board.catalog = buildCatalog(issues)
+	board.changeSeq = 9
+
+	msg, ok := board.watchCmd()().(changesLoadedMsg)
+	if !ok || !msg.rewound || msg.seq != 3 {
+		t.Fatalf("expected a rewound change log at 3, got %+v", msg)
+	}
+	updated, cmd := board.Update(msg)
+	board = updated.(Model)
+	if board.changeSeq != 3 || cmd == nil {
+		t.Fatalf("expected the cursor to rewind to 3 and the board to reload, got %d", board.changeSeq)
+	}
+}
+
+// newActionTestModel builds a sized board over a private copy of issues.
+func newActionTestModel(t *testing.T, svc stubService) Model {
+	t.Helper()
+	issues := append([]model.Issue(nil), svc.issues...)
+	board := NewModel(svc)
+	board.catalog = buildCatalog(issues)
+	board.ready = true
+	board.width = 120
+	board.height = 40
+	board.ensureSelection()
+	return board
+}
+
+func TestClaimAsksForConfirmationAndMovesCardOptimistically(t *testing.T) {
+	now := time.Now()
+	var actions []string
+	svc := stubService{
+		issues:  []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", Priority: 2, CreatedAt: now, UpdatedAt: now}},
+		actions: &actions,
+	}
+	model := newActionTestModel(t, svc)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	model = updated.(Model)
+	if model.confirm == nil || cmd != nil {
+		t.Fatal("expected c to ask for confirmation before claiming")
+	}
+	if view := model.View(); !strings.Contains(view, "Claim proj-a111?") {
+		t.Fatalf("expected confirmation prompt in view:\n%s", view)
+	}
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	model = updated.(Model)
+	if cmd == nil || model.confirm != nil {
+		t.Fatal("expected y to close the prompt and start the claim")
+	}
+	if claimed := boardIssues(model)["claimed"]; len(claimed) != 1 || model.selectedCol != 1 {
+		t.Fatalf("expected card to move to CLAIMED before the service answers, got col %d: %#v", model.selectedCol, claimed)
+	}
+	if len(actions) != 0 {
+		t.Fatalf("expected the service call to run in the command, got %v", actions)
+	}
+
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if len(actions) != 1 || actions[0] != "claim proj-a111" {
+		t.Fatalf("unexpected service calls: %v", actions)
+	}
+	issue := model.currentIssue()
+	if issue == nil || issue.ClaimedBy == nil || *issue.ClaimedBy != "board" {
+		t.Fatalf("expected stored claim to replace the optimistic card: %#v", issue)
+	}
+	if model.toast == nil || model.toast.isErr {
+		t.Fatalf("expected a success toast, got %#v", model.toast)
+	}
+}
+
+func TestClaimConflictRollsBackAndShowsOwnerToast(t *testing.T) {
+	now := time.Now()
+	owner := "agent-7"
+	svc := stubService{
+		issues:    []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now}},
+		actionErr: repo.ErrIssueAlreadyClaimed,
+	}
+	model := newActionTestModel(t, svc)
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	model = updated.(Model)
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+
+	// Another agent wins the race before the board's claim lands.
+	svc.issues[0].Status = "in_progress"
+	svc.issues[0].ClaimedBy = &owner
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+
+	if model.toast == nil || !model.toast.isErr || !strings.Contains(model.toast.text, "already claimed by @agent-7") {
+		t.Fatalf("expected already-claimed toast, got %#v", model.toast)
+	}
+	if header := model.renderHeader(); !strings.Contains(header, "already claimed by @agent-7") {
+		t.Fatalf("expected toast in header: %s", header)
+	}
+
+	updated, _ = model.Update(toastExpiredMsg{seq: model.toastSeq})
+	model = updated.(Model)
+	if model.toast != nil {
+		t.Fatal("expected the toast to clear once it expires")
+	}
+}
+
+func TestFailedCloseRollsBackOptimisticUpdate(t *testing.T) {
+	now := time.Now()
+	vetoed := fmt.Errorf("pre_close hook vetoed")
+	model := newActionTestModel(t, stubService{
+		issues: []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now}},
+	})
+	// Reads fail too, so the board can only restore its own copy.
+	model.svc = stubService{actionErr: vetoed}
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	model = updated.(Model)
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	model = updated.(Model)
+	if len(boardIssues(model)["done"]) != 1 {
+		t.Fatal("expected close to move the card to DONE optimistically")
+	}
+
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	columns := boardIssues(model)
+	if len(columns["todo"]) != 1 || len(columns["done"]) != 0 || model.selectedCol != 0 {
+		t.Fatalf("expected rollback to TO DO, got %#v", columns)
+	}
+	if model.toast == nil || !strings.Contains(model.toast.text, "pre_close hook vetoed") {
+		t.Fatalf("expected failure toast, got %#v", model.toast)
+	}
+}
+
+func TestHookWarningsShowAsErrorToasts(t *testing.T) {
+	now := time.Now()
+	model := newActionTestModel(t, stubService{
+		issues: []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", CreatedAt: now, UpdatedAt: now}},
+	})
+	warnings := make(chan string, 1)
+	WithHookWarnings(warnings)(&model)
+
+	warnings <- `on_close hook "notify" for proj-a111: exit status 1`
+	updated, cmd := model.Update(model.waitForHookWarning()())
+	model = updated.(Model)
+	if model.toast == nil || !model.toast.isErr || !strings.Contains(model.toast.text, "Hook failed: on_close hook") {
+		t.Fatalf("expected hook failure toast, got %#v", model.toast)
+	}
+	if cmd == nil {
+		t.Fatal("expected the board to keep listening for hook warnings")
+	}
+}
+
+func TestCancelledConfirmationLeavesBoardUntouched(t *testing.T) {
+	now := time.Now()
+	var actions []string
+	svc := stubService{
+		issues:  []model.Issue{{ID: "proj-c333", Title: "Old session bug", Type: "bug", Status: "closed", CreatedAt: now, UpdatedAt: now}},
+		actions: &actions,
+	}
+	model := newActionTestModel(t, svc)
+	model.selectedCol = 2
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	model = updated.(Model)
+	if model.confirm == nil || model.confirm.action != actionReopen {
+		t.Fatal("expected R to ask before reopening")
+	}
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	if cmd != nil || model.confirm != nil || len(boardIssues(model)["done"]) != 1 || len(actions) != 0 {
+		t.Fatalf("expected esc to cancel without changes, calls %v", actions)
+	}
+}
+
+func TestPriorityKeysApplyImmediatelyWithinBounds(t *testing.T) {
+	now := time.Now()
+	var actions []string
+	svc := stubService{
+		issues:  []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", Priority: 1, CreatedAt: now, UpdatedAt: now}},
+		actions: &actions,
+	}
+	model := newActionTestModel(t, svc)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	model = updated.(Model)
+	if model.confirm != nil || cmd == nil || model.currentIssue().Priority != 0 {
+		t.Fatalf("expected + to raise priority without a prompt, got P%d", model.currentIssue().Priority)
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if len(actions) != 1 || actions[0] != "update proj-a111 map[priority:0]" {
+		t.Fatalf("unexpected service calls: %v", actions)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	model = updated.(Model)
+	if len(actions) != 1 || model.toast == nil || !model.toast.isErr || !strings.Contains(model.toast.text, "already P0") {
+		t.Fatalf("expected a P0 card to refuse another raise, got %#v", model.toast)
+	}
+}
+
+// typeKeys feeds text into the model one key press at a time.
+func typeKeys(m Model, text string) Model {
+	for _, r := range text {
+		key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		switch r {
+		case ' ':
+			key = tea.KeyMsg{Type: tea.KeySpace}
+		case '\n':
+			key = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestCreateFormAddsChildOfScopeEpicAndShowsValidationInline(t *testing.T) {
+	now := time.Now()
+	epicID := "proj-e000"
+	var actions []string
+	svc := stubService{
+		issues: []model.Issue{
+			{ID: epicID, Title: "Checkout revamp", Type: "epic", Status: "open", CreatedAt: now, UpdatedAt: now},
+			{ID: "proj-e000.1", Title: "Address validation", Type: "task", Status: "open", ParentID: &epicID, CreatedAt: now, UpdatedAt: now},
+		},
+		actions: &actions,
+	}
+	model := newActionTestModel(t, svc)
+	model.selectScopeByKey(epicID)
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	model = updated.(Model)
+	if model.form == nil || model.form.issueID != "" {
+		t.Fatal("expected n to open an empty create form")
+	}
+	if parent := model.form.parentID(); parent == nil || *parent != epicID {
+		t.Fatalf("expected the scope epic as parent, got %v", parent)
+	}
+	if model.form.issueType() != "task" || model.form.priority != 2 {
+		t.Fatalf("expected default type and priority, got %s P%d", model.form.issueType(), model.form.priority)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(Model)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if model.form == nil || model.form.err == nil || model.form.saving {
+		t.Fatal("expected the form to stay open with the service error")
+	}
+	if view := model.View(); !strings.Contains(view, "Error: title is required") {
+		t.Fatalf("expected inline validation error:\n%s", view)
+	}
+
+	model = typeKeys(model, "Fix rounding\n")
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = updated.(Model)
+	for range 3 {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyTab})
+		model = updated.(Model)
+	}
+	model = typeKeys(model, "line one\nline two\n")
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(Model)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	want := `create "Fix rounding" feature P2 parent=proj-e000 desc="line one\nline two"`
+	if len(actions) != 2 || actions[1] != want {
+		t.Fatalf("unexpected create call:\n got %v\nwant %s", actions, want)
+	}
+	if model.form != nil || model.toast == nil || model.toast.text != "Created proj-n000" {
+		t.Fatalf("expected the form to close with a toast, got form %v toast %#v", model.form != nil, model.toast)
+	}
+}
+
+func TestEditFormSendsOnlyChangedFields(t *testing.T) {
+	now := time.Now()
+	var actions []string
+	svc := stubService{
+		issues:  []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Description: "Seen in prod", Type: "bug", Status: "open", Priority: 2, CreatedAt: now, UpdatedAt: now}},
+		actions: &actions,
+	}
+	model := newActionTestModel(t, svc)
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	model = updated.(Model)
+	if model.form == nil || model.form.title != "Session store timeout" || model.form.issueType() != "bug" {
+		t.Fatalf("expected edit form loaded from the card, got %#v", model.form)
+	}
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(Model)
+	if cmd != nil || model.form != nil || len(actions) != 0 {
+		t.Fatalf("expected saving an unchanged form to just close it, calls %v", actions)
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	model = updated.(Model)
+	for range len("timeout") {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		model = updated.(Model)
+	}
+	model = typeKeys(model, "leak\n")
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = updated.(Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	model = updated.(Model)
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	model = updated.(Model)
+	if !model.form.saving {
+		t.Fatal("expected the form to show it is saving")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	if len(actions) != 1 || actions[0] != "update proj-a111 map[priority:1 title:Session store leak]" {
+		t.Fatalf("unexpected update call: %v", actions)
+	}
+	if model.form != nil || model.currentIssue().Priority != 1 {
+		t.Fatalf("expected the saved card patched into the board, got %#v", model.currentIssue())
+	}
+}
+
+func TestGraphPaneLayersTransitiveNeighborhoodAndJumpsToNeighbor(t *testing.T) {
+	now := time.Now()
+	issue := func(id, status string) model.Issue {
+		return model.Issue{ID: id, Title: "Issue " + id, Type: "task", Status: status, CreatedAt: now, UpdatedAt: now}
+	}
+	a, b, c, d, x := issue("proj-a", "closed"), issue("proj-b", "open"), issue("proj-c", "in_progress"), issue("proj-d", "open"), issue("proj-x", "open")
+	svc := stubService{
+		issues: []model.Issue{a, b, c, d, x},
+		dependencies: map[string][]model.Issue{
+			"proj-c": {a, b, x},
+			"proj-b": {a},
+		},
+		dependents: map[string][]model.Issue{
+			"proj-c": {d},
+		},
+	}
+	model := newActionTestModel(t, svc)
+	model.selectedCol = 1
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	model = updated.(Model)
+	if model.graph == nil || !model.graph.loading || cmd == nil {
+		t.Fatal("expected g to open the graph pane and start loading")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+
+	layers := fmt.Sprint(model.graph.graph.layers())
+	if layers != "[[proj-a] [proj-b proj-x] [proj-c] [proj-d]]" {
+		t.Fatalf("expected proj-a above proj-b on the longest path, got %s", layers)
+	}
+	view := model.View()
+	for _, want := range []string{"Dependency graph: proj-c", "◆ ◐ proj-c", "✓ proj-a", "→ proj-b, proj-c"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in graph view:\n%s", want, view)
+		}
+	}
+
+	for _, step := range []struct {
+		key  tea.KeyMsg
+		want string
+	}{
+		{tea.KeyMsg{Type: tea.KeyUp}, "proj-b"},
+		{tea.KeyMsg{Type: tea.KeyRight}, "proj-x"},
+		{tea.KeyMsg{Type: tea.KeyUp}, "proj-x"},
+		{tea.KeyMsg{Type: tea.KeyDown}, "proj-c"},
+		{tea.KeyMsg{Type: tea.KeyDown}, "proj-d"},
+	} {
+		updated, _ = model.Update(step.key)
+		model = updated.(Model)
+		if model.graph.selectedID != step.want {
+			t.Fatalf("after %s expected %s, got %s", step.key, step.want, model.graph.selectedID)
+		}
+	}
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if model.graph != nil {
+		t.Fatal("expected enter to close the graph pane")
+	}
+	if selected := model.currentIssue(); selected == nil || selected.ID != "proj-d" {
+		t.Fatalf("expected board selection on proj-d, got %#v", selected)
+	}
+}
+
+func TestConfiguredColumnsSplitOpenIssuesIntoBlockedAndReady(t *testing.T) {
+	now := time.Now()
+	issue := func(id, status string) model.Issue {
+		return model.Issue{ID: id, Title: "Task " + id, Type: "task", Status: status, Priority: 2, CreatedAt: now, UpdatedAt: now}
+	}
+	a, b, c, d, e := issue("proj-a", "open"), issue("proj-b", "open"), issue("proj-c", "open"), issue("proj-d", "closed"), issue("proj-e", "in_progress")
+	svc := stubService{
+		issues: []model.Issue{a, b, c, d, e},
+		dependencies: map[string][]model.Issue{
+			"proj-b": {a},
+			"proj-c": {d},
+		},
+	}
+	catalog, err := LoadCatalog(svc)
+	if err != nil {
+		t.Fatalf("load catalog: %v", err)
+	}
+	board := NewModel(svc, WithLayout([]string{"blocked", "ready", "claimed", "done"}, "none"))
+	board.catalog = catalog
+	board.ready = true
+	board.width = 120
+	board.height = 40
+	board.ensureSelection()
+
+	columnIDs := func() string {
+		parts := make([]string, 0, len(board.columns))
+		for _, column := range board.boardColumns(board.currentIssues()) {
+			ids := make([]string, 0, len(column.issues))
+			for _, issue := range column.issues {
+				ids = append(ids, issue.ID)
+			}
+			sort.Strings(ids)
+			parts = append(parts, column.key+"="+strings.Join(ids, ","))
+		}
+		return strings.Join(parts, " ")
+	}
+	if got := columnIDs(); got != "blocked=proj-b ready=proj-a,proj-c claimed=proj-e done=proj-d" {
+		t.Fatalf("unexpected columns: %s", got)
+	}
+	view := board.View()
+	for _, want := range []string{"BLOCKED (1)", "READY (2)", "CLAIMED (1)", "DONE (1)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in board view:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "TO DO") {
+		t.Fatalf("expected no TO DO column when it is not configured:\n%s", view)
+	}
+
+	closed := a
+	closed.Status = "closed"
+	board.patchIssue(closed)
+	if got := columnIDs(); got != "blocked= ready=proj-b,proj-c claimed=proj-e done=proj-a,proj-d" {
+		t.Fatalf("expected proj-b to become ready once its blocker closed, got %s", got)
+	}
+}
+
+func TestSwimlanesGroupCardsAndMoveAcrossLanes(t *testing.T) {
+	now := time.Now()
+	issue := func(id, status, issueType string, priority int, minutes int) model.Issue {
+		created := now.Add(time.Duration(minutes) * time.Minute)
+		return model.Issue{ID: id, Title: "Task " + id, Type: issueType, Status: status, Priority: priority, CreatedAt: created, UpdatedAt: created}
+	}
+	svc := stubService{issues: []model.Issue{
+		issue("proj-a", "open", "bug", 0, 1),
+		issue("proj-b", "open", "task", 2, 2),
+		issue("proj-c", "in_progress", "task", 2, 3),
+		issue("proj-d", "open", "task", 2, 4),
+	}}
+	board := NewModel(svc, WithLayout(nil, "priority"))
+	board.catalog = buildCatalog(svc.issues)
+	board.ready = true
+	board.width = 120
+	board.height = 40
+	board.ensureSelection()
+
+	lanes := board.lanes()
+	if len(lanes) != 2 || lanes[0].title != "P0" || lanes[1].title != "P2" {
+		t.Fatalf("expected P0 and P2 lanes, got %#v", lanes)
+	}
+	if selected := board.currentIssue(); selected == nil || selected.ID != "proj-a" {
+		t.Fatalf("expected proj-a selected in the P0 lane, got %#v", selected)
+	}
+	view := board.View()
+	for _, want := range []string{"Lanes: priority.", "P0 (1)  lane 1/2", "P2 (3)  lane 2/2", "TO DO (3)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in board view:\n%s", want, view)
+		}
+	}
+
+	for _, want := range []string{"proj-d", "proj-b", "proj-b"} {
+		updated, _ := board.Update(tea.KeyMsg{Type: tea.KeyDown})
+		board = updated.(Model)
+		if selected := board.currentIssue(); selected == nil || selected.ID != want {
+			t.Fatalf("expected %s after moving down, got %#v", want, selected)
+		}
+	}
+	updated, _ := board.Update(tea.KeyMsg{Type: tea.KeyUp})
+	board = updated.(Model)
+	updated, _ = board.Update(tea.KeyMsg{Type: tea.KeyUp})
+	board = updated.(Model)
+	if selected := board.currentIssue(); selected == nil || selected.ID != "proj-a" || board.selectedLane != 0 {
+		t.Fatalf("expected up to return to the P0 lane, got lane %d %#v", board.selectedLane, selected)
+	}
+
+	updated, _ = board.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	board = updated.(Model)
+	if board.swimlanes != model.KanbanSwimlanesType {
+		t.Fatalf("expected w to switch to type lanes, got %q", board.swimlanes)
+	}
+	if lanes := board.lanes(); len(lanes) != 2 || lanes[0].title != "bug" || lanes[1].title != "task" {
+		t.Fatalf("expected bug and task lanes, got %#v", lanes)
+	}
+
+	for width := minRenderableWidth; width <= 120; width += 13 {
+		for height := minRenderableHeight; height <= 40; height += 3 {
+			board.width = width
+			board.height = height
+			board.selectedLane = 1
+			board.ensureSelection()
+			view := board.View()
+			if got := maxRenderedLineWidth(view); got > width {
+				t.Fatalf("expected rendered width <= %d, got %d at %dx%d\n%s", width, got, width, height, view)
+			}
+			if got := renderedLineCount(view); got > height {
+				t.Fatalf("expected rendered height <= %d, got %d at %dx%d\n%s", height, got, width, height, view)
+			}
+		}
+	}
+}
+
+func TestKeymapOverridesRebindBoardActionsHelpAndFooter(t *testing.T) {
+	keymap, err := NewKeymap(map[string][]string{"claim": {"C"}, "quit": {"Q"}, "graph": {"k"}})
+	if err != nil {
+		t.Fatalf("new keymap: %v", err)
+	}
+	if keymap.action("C") != keyClaim || keymap.action("c") != "" || keymap.action("k") != keyGraph || keymap.action("up") != keyUp {
+		t.Fatalf("unexpected bindings: C=%q c=%q k=%q up=%q", keymap.action("C"), keymap.action("c"), keymap.action("k"), keymap.action("up"))
+	}
+	for name, overrides := range map[string]map[string][]string{
+		"unknown":  {"teleport": {"t"}},
+		"reserved": {"claim": {"ctrl+c"}},
+		"conflict": {"claim": {"z"}, "close": {"z"}},
+	} {
+		if _, err := NewKeymap(overrides); err == nil {
+			t.Fatalf("%s: expected keymap error", name)
+		}
+	}
+
+	now := time.Now()
+	svc := stubService{issues: []model.Issue{{ID: "proj-a111", Title: "Session store timeout", Type: "bug", Status: "open", Priority: 2, CreatedAt: now, UpdatedAt: now}}}
+	board := newActionTestModel(t, svc)
+	board.keymap = keymap
+
+	updated, _ := board.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	board = updated.(Model)
+	if board.confirm != nil {
+		t.Fatal("expected the unbound default key to do nothing")
+	}
+	updated, _ = board.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	board = updated.(Model)
+	if board.confirm == nil || board.confirm.action != actionClaim {
+		t.Fatal("expected the rebound key to ask to claim")
+	}
+	board.confirm = nil
+	if _, cmd := board.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd != nil {
+		t.Fatal("expected q to stop quitting once quit is rebound")
+	}
+
+	lines, _ := board.footerLines(118)
+	if footer := strings.Join(lines, " "); !strings.Contains(footer, "Q quit") || strings.Contains(footer, "q quit") {
+		t.Fatalf("expected footer to show the rebound quit key: %s", footer)
+	}
+	help := strings.Join(board.helpLines(), "\n")
+	for _, want := range []string{"C                claim selected task", "↑                move up", "k                dependency graph"} {
+		if !strings.Contains(help, want) {
+			t.Fatalf("expected %q in help:\n%s", want, help)
+		}
+	}
+}
+
+func TestLoadThemeResolvesBuiltinsAndPalettes(t *testing.T) {
+	light, err := LoadTheme("light", nil)
+	if err != nil || light.cardBackground != "255" {
+		t.Fatalf("expected light theme, got %+v, %v", light, err)
+	}
+	palettes := map[string]map[string]string{
+		"solar": {"base": "light", "card_background": "#fdf6e3", "claimed": " #268bd2 "},
+		"dark":  {"todo": "220"},
+	}
+	solar, err := LoadTheme("solar", palettes)
+	if err != nil {
+		t.Fatalf("load palette: %v", err)
+	}
+	if solar.cardBackground != "#fdf6e3" || solar.claimed != "#268bd2" || solar.done != light.done {
+		t.Fatalf("expected palette over the light theme, got %+v", solar)
+	}
+	dark, err := LoadTheme("", palettes)
+	if err != nil || dark.todo != "220" || dark.done != DefaultTheme().done {
+		t.Fatalf("expected adjusted dark theme, got %+v, %v", dark, err)
+	}
+	if _, err := LoadTheme("neon", palettes); err == nil {
+		t.Fatal("expected unknown theme error")
+	}
+	if _, err := LoadTheme("bad", map[string]map[string]string{"bad": {"card_glow": "1"}}); err == nil || !strings.Contains(err.Error(), "card_glow") {
+		t.Fatalf("expected unknown color error, got %v", err)
+	}
+}
+
+func TestMouseSelectsCardsOpensDetailsAndScrollsModals(t *testing.T) {
+	now := time.Now()
+	svc := stubService{issues: []model.Issue{
+		{ID: "proj-a", Title: "Open task", Type: "task", Status: "open", Priority: 2, CreatedAt: now, UpdatedAt: now},
+		{ID: "proj-b", Title: "Older claimed task", Type: "task", Status: "in_progress", Priority: 2, CreatedAt: now, UpdatedAt: now},
+		{ID: "proj-c", Title: "Newer claimed task", Type: "task", Status: "in_progress", Priority: 2, CreatedAt: now.Add(time.Minute), UpdatedAt: now},
+	}}
+	board := newActionTestModel(t, svc)
+	click := func(x, y int) tea.Cmd {
+		t.Helper()
+		updated, cmd := board.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+		board = updated.(Model)
+		return cmd
+	}
+	wheel := func(button tea.MouseButton) {
+		t.Helper()
+		updated, _ := board.Update(tea.MouseMsg{X: 1, Y: 1, Action: tea.MouseActionPress, Button: button})
+		board = updated.(Model)
+	}
+
+	// At 120 columns the CLAIMED column starts at x=41; cards start below the header and column titles.
+	click(50, headerLines+columnHeaderLines+cardOuterHeight+1)
+	if selected := board.currentIssue(); selected == nil || selected.ID != "proj-b" || board.showDetails {
+		t.Fatalf("expected click to select proj-b, got %#v", selected)
+	}
+	click(50, 1)
+	if selected := board.currentIssue(); selected == nil || selected.ID != "proj-b" {
+		t.Fatalf("expected click on the header to keep the selection, got %#v", selected)
+	}
+	wheel(tea.MouseButtonWheelUp)
+	if selected := board.currentIssue(); selected == nil || selected.ID != "proj-c" {
+		t.Fatalf("expected wheel up to move the selection, got %#v", selected)
+	}
+	if cmd := click(50, headerLines+columnHeaderLines+1); cmd == nil || !board.showDetails || board.inspectedIssueID != "proj-c" {
+		t.Fatalf("expected click on the selected card to open details, got showDetails=%v id=%q", board.showDetails, board.inspectedIssueID)
+	}
+
+	board.showDetails = false
+	board.clearInspectedIssue()
+	board.height = 20
+	board.showHelp = true
+	wheel(tea.MouseButtonWheelDown)
+	if board.helpScroll != mouseScrollStep {
+		t.Fatalf("expected wheel to scroll help by %d, got %d", mouseScrollStep, board.helpScroll)
+	}
+	click(50, headerLines+columnHeaderLines+1)
+	if !board.showHelp || board.currentIssue().ID != "proj-c" {
+		t.Fatal("expected clicks to leave the board alone while a modal is open")
+	}
+}
+
+// boardIssues returns the cards of each board column in the current scope, keyed by column.
+func boardIssues(m Model) map[string][]model.Issue {
+	columns := make(map[string][]model.Issue, len(m.columns))
+	for _, column := range m.boardColumns(m.currentIssues()) {
+		columns[column.key] = column.issues
+	}
+	return columns
+}
@@ -0,0 +1,148 @@
+package kanban
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseScrollStep is how many lines one wheel notch scrolls a modal.
+const mouseScrollStep = 3
+
+// updateMouse handles clicks and the wheel. The wheel scrolls the open modal or
+// moves the board selection; a click selects a card and a click on the
+// selected card opens its details.
+func (m Model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || !m.ready || m.err != nil || m.isUndersized() {
+		return m, nil
+	}
+	step := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		step = -1
+	case tea.MouseButtonWheelDown:
+		step = 1
+	case tea.MouseButtonLeft:
+		if m.hasModal() {
+			return m, nil
+		}
+		return m.clickBoard(msg.X, msg.Y)
+	default:
+		return m, nil
+	}
+
+	switch {
+	case m.form != nil, m.confirm != nil, m.showSearch:
+	case m.graph != nil:
+		m.scrollGraph(step * mouseScrollStep)
+	case m.showDetails:
+		m.scrollDetails(step * mouseScrollStep)
+	case m.showEpic:
+		m.scrollEpic(step * mouseScrollStep)
+	case m.showHelp:
+		m.scrollHelp(step * mouseScrollStep)
+	case m.showType:
+		m.typeIndex = maxInt(0, minInt(m.filterOptionCount()-1, m.typeIndex+step))
+	case m.showPicker:
+		m.pickerIndex += step
+		m.ensurePickerVisible()
+	default:
+		m.moveRow(step)
+	}
+	return m, nil
+}
+
+// hasModal reports whether any modal or picker covers the board.
+func (m Model) hasModal() bool {
+	return m.form != nil || m.confirm != nil || m.graph != nil || m.showPicker || m.showHelp ||
+		m.showType || m.showSearch || m.showEpic || m.showDetails
+}
+
+// clickBoard selects the clicked card, or opens it when it is already selected.
+func (m Model) clickBoard(x, y int) (tea.Model, tea.Cmd) {
+	pos, start, ok := m.cardAt(x, y)
+	if !ok {
+		return m, nil
+	}
+	if pos == (cardPosition{lane: m.selectedLane, col: m.selectedCol, row: m.selectedRow}) {
+		return m.runBoardAction(keyDetails)
+	}
+	if pos.lane != m.selectedLane || pos.col != m.selectedCol {
+		// Keep the clicked column where it was drawn instead of jumping to the old offset.
+		m.scrollRow = start
+	}
+	m.selectPosition(pos)
+	return m, nil
+}
+
+// cardAt maps a screen cell to the card drawn there, following renderBoard's
+// layout, and reports the first visible row of that card's column.
+func (m Model) cardAt(x, y int) (cardPosition, int, bool) {
+	rowsVisible := m.visibleRows()
+	if rowsVisible == 0 {
+		return cardPosition{}, 0, false
+	}
+	widths, gap := boardColumnLayout(m.width, len(m.columns))
+	rowWidth := (len(widths) - 1) * gap
+	for _, width := range widths {
+		rowWidth += width
+	}
+	col := -1
+	for i, left := 0, (m.width-rowWidth)/2; i < len(widths); i++ {
+		if x >= left && x < left+widths[i] {
+			col = i
+			break
+		}
+		left += widths[i] + gap
+	}
+	if col < 0 {
+		return cardPosition{}, 0, false
+	}
+
+	lanes := m.lanes()
+	top := headerLines + columnHeaderLines
+	lane := 0
+	if m.hasSwimlanes() {
+		// Walk the lanes drawn below the column headers, skipping each title bar.
+		budget := m.boardHeightBudget() - columnHeaderLines
+		lane = -1
+		for index := m.laneScroll; index < len(lanes); index++ {
+			height := m.laneHeight(lanes[index])
+			if height > budget {
+				break
+			}
+			if y < top+height {
+				lane = index
+				top += laneTitleLines
+				break
+			}
+			budget -= height
+			top += height
+		}
+		if lane < 0 {
+			return cardPosition{}, 0, false
+		}
+	}
+	if y < top || y >= top+rowsVisible*cardOuterHeight {
+		return cardPosition{}, 0, false
+	}
+
+	issues := lanes[lane].columns[col].issues
+	start := 0
+	if lane == m.selectedLane && col == m.selectedCol {
+		start = minInt(m.scrollRow, len(issues))
+	}
+	row := start + (y-top)/cardOuterHeight
+	if row >= len(issues) {
+		return cardPosition{}, 0, false
+	}
+	return cardPosition{lane: lane, col: col, row: row}, start, true
+}
+
+// scrollGraph scrolls the dependency graph body without moving its selection.
+func (m *Model) scrollGraph(delta int) {
+	if m.graph == nil || m.graph.loading || m.graph.err != nil {
+		return
+	}
+	width, _, bodyHeight := m.modalDimensions(false)
+	lines, _ := m.graphLines(m.modalContentWidth(width))
+	m.graph.scroll = clampScrollOffset(m.graph.scroll+delta, len(lines), maxInt(1, bodyHeight-graphChromeLines))
+}
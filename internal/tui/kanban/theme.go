@@ -0,0 +1,213 @@
+package kanban
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rpcarvs/faz/internal/model"
+)
+
+// Theme holds the colors the board is drawn with.
+type Theme struct {
+	headerText       lipgloss.Color
+	headerBackground lipgloss.Color
+	subtitle         lipgloss.Color
+	footer           lipgloss.Color
+	errorText        lipgloss.Color
+	successText      lipgloss.Color
+
+	columnText lipgloss.Color
+	todo       lipgloss.Color
+	blocked    lipgloss.Color
+	ready      lipgloss.Color
+	claimed    lipgloss.Color
+	done       lipgloss.Color
+
+	cardBorder         lipgloss.Color
+	cardBackground     lipgloss.Color
+	cardTitle          lipgloss.Color
+	cardMeta           lipgloss.Color
+	emptyText          lipgloss.Color
+	selectedBorder     lipgloss.Color
+	selectedBackground lipgloss.Color
+	selectedTitle      lipgloss.Color
+	selectedMeta       lipgloss.Color
+
+	laneText       lipgloss.Color
+	laneBackground lipgloss.Color
+	laneSelected   lipgloss.Color
+
+	modalBorder     lipgloss.Color
+	modalBackground lipgloss.Color
+	modalText       lipgloss.Color
+	epicBorder      lipgloss.Color
+	epicBackground  lipgloss.Color
+	epicText        lipgloss.Color
+	labelText       lipgloss.Color
+	labelBackground lipgloss.Color
+}
+
+// themeSlots maps palette keys in [kanban.palettes.<name>] to theme colors.
+var themeSlots = map[string]func(*Theme) *lipgloss.Color{
+	"header_text":         func(t *Theme) *lipgloss.Color { return &t.headerText },
+	"header_background":   func(t *Theme) *lipgloss.Color { return &t.headerBackground },
+	"subtitle":            func(t *Theme) *lipgloss.Color { return &t.subtitle },
+	"footer":              func(t *Theme) *lipgloss.Color { return &t.footer },
+	"error":               func(t *Theme) *lipgloss.Color { return &t.errorText },
+	"success":             func(t *Theme) *lipgloss.Color { return &t.successText },
+	"column_text":         func(t *Theme) *lipgloss.Color { return &t.columnText },
+	"todo":                func(t *Theme) *lipgloss.Color { return &t.todo },
+	"blocked":             func(t *Theme) *lipgloss.Color { return &t.blocked },
+	"ready":               func(t *Theme) *lipgloss.Color { return &t.ready },
+	"claimed":             func(t *Theme) *lipgloss.Color { return &t.claimed },
+	"done":                func(t *Theme) *lipgloss.Color { return &t.done },
+	"card_border":         func(t *Theme) *lipgloss.Color { return &t.cardBorder },
+	"card_background":     func(t *Theme) *lipgloss.Color { return &t.cardBackground },
+	"card_title":          func(t *Theme) *lipgloss.Color { return &t.cardTitle },
+	"card_meta":           func(t *Theme) *lipgloss.Color { return &t.cardMeta },
+	"empty_text":          func(t *Theme) *lipgloss.Color { return &t.emptyText },
+	"selected_border":     func(t *Theme) *lipgloss.Color { return &t.selectedBorder },
+	"selected_background": func(t *Theme) *lipgloss.Color { return &t.selectedBackground },
+	"selected_title":      func(t *Theme) *lipgloss.Color { return &t.selectedTitle },
+	"selected_meta":       func(t *Theme) *lipgloss.Color { return &t.selectedMeta },
+	"lane_text":           func(t *Theme) *lipgloss.Color { return &t.laneText },
+	"lane_background":     func(t *Theme) *lipgloss.Color { return &t.laneBackground },
+	"lane_selected":       func(t *Theme) *lipgloss.Color { return &t.laneSelected },
+	"modal_border":        func(t *Theme) *lipgloss.Color { return &t.modalBorder },
+	"modal_background":    func(t *Theme) *lipgloss.Color { return &t.modalBackground },
+	"modal_text":          func(t *Theme) *lipgloss.Color { return &t.modalText },
+	"epic_border":         func(t *Theme) *lipgloss.Color { return &t.epicBorder },
+	"epic_background":     func(t *Theme) *lipgloss.Color { return &t.epicBackground },
+	"epic_text":           func(t *Theme) *lipgloss.Color { return &t.epicText },
+	"label_text":          func(t *Theme) *lipgloss.Color { return &t.labelText },
+	"label_background":    func(t *Theme) *lipgloss.Color { return &t.labelBackground },
+}
+
+// builtinThemes holds the themes shipped with faz, keyed by name.
+var builtinThemes = map[string]Theme{
+	model.KanbanThemeDark: {
+		headerText: "230", headerBackground: "24", subtitle: "245", footer: "244", errorText: "203", successText: "71",
+		columnText: "230", todo: "178", blocked: "167", ready: "36", claimed: "39", done: "71",
+		cardBorder: "238", cardBackground: "235", cardTitle: "252", cardMeta: "243", emptyText: "244",
+		selectedBorder: "246", selectedBackground: "240", selectedTitle: "230", selectedMeta: "251",
+		laneText: "252", laneBackground: "237", laneSelected: "230",
+		modalBorder: "69", modalBackground: "235",
+		epicBorder: "33", epicBackground: "24", epicText: "230",
+		labelText: "230", labelBackground: "60",
+	},
+	model.KanbanThemeLight: {
+		headerText: "231", headerBackground: "25", subtitle: "240", footer: "242", errorText: "160", successText: "28",
+		columnText: "231", todo: "136", blocked: "124", ready: "30", claimed: "25", done: "28",
+		cardBorder: "250", cardBackground: "255", cardTitle: "235", cardMeta: "242", emptyText: "245",
+		selectedBorder: "240", selectedBackground: "153", selectedTitle: "232", selectedMeta: "237",
+		laneText: "236", laneBackground: "253", laneSelected: "232",
+		modalBorder: "25", modalBackground: "254", modalText: "235",
+		epicBorder: "25", epicBackground: "189", epicText: "235",
+		labelText: "231", labelBackground: "61",
+	},
+	model.KanbanThemeHighContrast: {
+		headerText: "16", headerBackground: "231", subtitle: "231", footer: "231", errorText: "196", successText: "46",
+		columnText: "16", todo: "226", blocked: "196", ready: "51", claimed: "45", done: "46",
+		cardBorder: "231", cardBackground: "16", cardTitle: "231", cardMeta: "231", emptyText: "231",
+		selectedBorder: "226", selectedBackground: "226", selectedTitle: "16", selectedMeta: "16",
+		laneText: "231", laneBackground: "16", laneSelected: "226",
+		modalBorder: "231", modalBackground: "16", modalText: "231",
+		epicBorder: "226", epicBackground: "16", epicText: "231",
+		labelText: "16", labelBackground: "226",
+	},
+}
+
+// DefaultTheme returns the dark theme.
+func DefaultTheme() Theme {
+	return builtinThemes[model.KanbanThemeDark]
+}
+
+// LoadTheme resolves a theme name against the built-in themes and the user
+// palettes. A palette starts from its "base" theme, dark by default, and
+// overrides the colors it lists; a palette named after a built-in theme
+// adjusts that theme.
+func LoadTheme(name string, palettes map[string]map[string]string) (Theme, error) {
+	if name == "" {
+		name = model.KanbanThemeDark
+	}
+	palette, custom := palettes[name]
+	theme, builtin := builtinThemes[name]
+	if !custom {
+		if !builtin {
+			return Theme{}, fmt.Errorf("kanban.theme: unknown theme %q (expected %s or a [kanban.palettes] name)", name, strings.Join(model.KanbanThemes, ", "))
+		}
+		return theme, nil
+	}
+
+	base := palette["base"]
+	if base == "" && !builtin {
+		base = model.KanbanThemeDark
+	}
+	if base != "" {
+		if theme, builtin = builtinThemes[base]; !builtin {
+			return Theme{}, fmt.Errorf("kanban.palettes.%s: unknown base theme %q", name, base)
+		}
+	}
+	keys := make([]string, 0, len(palette))
+	for key := range palette {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "base" {
+			continue
+		}
+		slot, ok := themeSlots[key]
+		if !ok {
+			return Theme{}, fmt.Errorf("kanban.palettes.%s: unknown color %q", name, key)
+		}
+		*slot(&theme) = lipgloss.Color(strings.TrimSpace(palette[key]))
+	}
+	return theme, nil
+}
+
+// WithTheme sets the board colors.
+func WithTheme(theme Theme) Option {
+	return func(m *Model) { m.theme = theme }
+}
+
+// columnAccent returns the header color for a column key.
+func (t Theme) columnAccent(key string) lipgloss.Color {
+	switch key {
+	case model.KanbanColumnBlocked:
+		return t.blocked
+	case model.KanbanColumnReady:
+		return t.ready
+	case model.KanbanColumnClaimed:
+		return t.claimed
+	case model.KanbanColumnDone:
+		return t.done
+	default:
+		return t.todo
+	}
+}
+
+// statusColor matches an issue status to its board column accent.
+func (t Theme) statusColor(status string) lipgloss.Color {
+	switch status {
+	case "in_progress":
+		return t.claimed
+	case "closed":
+		return t.done
+	default:
+		return t.todo
+	}
+}
+
+// modalStyle is the framed box shared by the board's modals.
+func (t Theme) modalStyle(width int) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Width(width).
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(t.modalBorder).
+		Padding(1, 2).
+		Background(t.modalBackground).
+		Foreground(t.modalText)
+}